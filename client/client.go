@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Client holds API procedure calls.
@@ -976,6 +977,19 @@ func (c *Client) UpdateEntryContext(ctx context.Context, entryID int64, entryCha
 	return entry, nil
 }
 
+// SetEntryDisplayDate places the entry in the date sections by the given date, or by its publication date again when nil.
+func (c *Client) SetEntryDisplayDate(entryID int64, displayDate *time.Time) error {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.SetEntryDisplayDateContext(ctx, entryID, displayDate)
+}
+
+// SetEntryDisplayDateContext places the entry in the date sections by the given date, or by its publication date again when nil.
+func (c *Client) SetEntryDisplayDateContext(ctx context.Context, entryID int64, displayDate *time.Time) error {
+	_, err := c.request.Put(ctx, fmt.Sprintf("/v1/entries/%d/display-date", entryID), &EntryDisplayDateRequest{DisplayDate: displayDate})
+	return err
+}
+
 // ToggleStarred toggles entry starred value.
 func (c *Client) ToggleStarred(entryID int64) error {
 	ctx, cancel := withDefaultTimeout()
@@ -1051,6 +1065,384 @@ func (c *Client) FetchCountersContext(ctx context.Context) (*FeedCounters, error
 	return &result, nil
 }
 
+// BacklogFeeds fetches the feeds having the most unread entries older than the given number of days.
+func (c *Client) BacklogFeeds(olderThanDays, limit int) ([]*FeedUnreadCount, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.BacklogFeedsContext(ctx, olderThanDays, limit)
+}
+
+// BacklogFeedsContext fetches the feeds having the most unread entries older than the given number of days.
+func (c *Client) BacklogFeedsContext(ctx context.Context, olderThanDays, limit int) ([]*FeedUnreadCount, error) {
+	values := url.Values{}
+	values.Set("older_than_days", strconv.Itoa(olderThanDays))
+	values.Set("limit", strconv.Itoa(limit))
+
+	body, err := c.request.Get(ctx, "/v1/feeds/backlog?"+values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result []*FeedUnreadCount
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return result, nil
+}
+
+// DateSections fetches the sections of the date view with their unread counts.
+// The scheme selects the section layout, an empty scheme uses the default one.
+func (c *Client) DateSections(scheme string) (*DateSections, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.DateSectionsContext(ctx, scheme)
+}
+
+// DateSectionsContext fetches the sections of the date view with their unread counts.
+// The scheme selects the section layout, an empty scheme uses the default one.
+func (c *Client) DateSectionsContext(ctx context.Context, scheme string) (*DateSections, error) {
+	path := "/v1/entries/date-sections"
+	if scheme != "" {
+		path += "?buckets=" + url.QueryEscape(scheme)
+	}
+
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result DateSections
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// FlatDateSections fetches the sections of the date view followed by a page of their unread entries,
+// newest section first. The scheme selects the section layout, an empty scheme uses the default one.
+func (c *Client) FlatDateSections(scheme string, offset, limit int) (*FlatDateSections, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.FlatDateSectionsContext(ctx, scheme, offset, limit)
+}
+
+// FlatDateSectionsContext fetches the sections of the date view followed by a page of their unread entries,
+// newest section first. The scheme selects the section layout, an empty scheme uses the default one.
+func (c *Client) FlatDateSectionsContext(ctx context.Context, scheme string, offset, limit int) (*FlatDateSections, error) {
+	values := url.Values{}
+	values.Set("flat", "1")
+	values.Set("offset", strconv.Itoa(offset))
+	values.Set("limit", strconv.Itoa(limit))
+	if scheme != "" {
+		values.Set("buckets", scheme)
+	}
+
+	body, err := c.request.Get(ctx, "/v1/entries/date-sections?"+values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result FlatDateSections
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// DateSectionReadingQueue fetches a reading queue taking unread entries in turn from several sections of the date
+// view. The quotas give the number of entries of each section, such as "today:3,last7d:2,earlier:1", empty quotas
+// use the default ones. An empty scheme uses the default layout.
+func (c *Client) DateSectionReadingQueue(scheme, quotas string) (*DateSectionReadingQueue, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.DateSectionReadingQueueContext(ctx, scheme, quotas)
+}
+
+// DateSectionReadingQueueContext fetches a reading queue taking unread entries in turn from several sections of
+// the date view. The quotas give the number of entries of each section, such as "today:3,last7d:2,earlier:1",
+// empty quotas use the default ones. An empty scheme uses the default layout.
+func (c *Client) DateSectionReadingQueueContext(ctx context.Context, scheme, quotas string) (*DateSectionReadingQueue, error) {
+	values := url.Values{}
+	if quotas != "" {
+		values.Set("quotas", quotas)
+	}
+	if scheme != "" {
+		values.Set("buckets", scheme)
+	}
+
+	path := "/v1/entries/date-sections/reading-queue"
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result DateSectionReadingQueue
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// DateSectionLatestEntries fetches the newest unread entry of each section of the date view, keyed by section name.
+// Empty sections map to nil. An empty scheme uses the default layout.
+func (c *Client) DateSectionLatestEntries(scheme string) (DateSectionLatestEntries, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.DateSectionLatestEntriesContext(ctx, scheme)
+}
+
+// DateSectionLatestEntriesContext fetches the newest unread entry of each section of the date view, keyed by
+// section name. Empty sections map to nil. An empty scheme uses the default layout.
+func (c *Client) DateSectionLatestEntriesContext(ctx context.Context, scheme string) (DateSectionLatestEntries, error) {
+	path := "/v1/entries/date-sections/latest"
+	if scheme != "" {
+		path += "?" + url.Values{"buckets": {scheme}}.Encode()
+	}
+
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result DateSectionLatestEntries
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return result, nil
+}
+
+// MarkDateSectionReadUpTo marks as read the unread entries of the date view section listed up to, and including,
+// the entry with the given ID and publication date, newest first like the flat listing. An empty section covers
+// every section.
+func (c *Client) MarkDateSectionReadUpTo(section string, publishedAt time.Time, entryID int64) error {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.MarkDateSectionReadUpToContext(ctx, section, publishedAt, entryID)
+}
+
+// MarkDateSectionReadUpToContext marks as read the unread entries of the date view section listed up to, and
+// including, the entry with the given ID and publication date, newest first like the flat listing. An empty
+// section covers every section.
+func (c *Client) MarkDateSectionReadUpToContext(ctx context.Context, section string, publishedAt time.Time, entryID int64) error {
+	type payload struct {
+		Section   string `json:"section"`
+		SortValue string `json:"sort_value"`
+		EntryID   int64  `json:"id"`
+	}
+
+	_, err := c.request.Put(ctx, "/v1/entries/date-sections/mark-read-up-to", &payload{
+		Section:   section,
+		SortValue: publishedAt.Format(time.RFC3339Nano),
+		EntryID:   entryID,
+	})
+	return err
+}
+
+// DateSectionsIndex fetches the state of the index used to count the sections of the date view (admin only).
+func (c *Client) DateSectionsIndex() (*DateSectionsIndex, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.DateSectionsIndexContext(ctx)
+}
+
+// DateSectionsIndexContext fetches the state of the index used to count the sections of the date view (admin only).
+func (c *Client) DateSectionsIndexContext(ctx context.Context) (*DateSectionsIndex, error) {
+	body, err := c.request.Get(ctx, "/v1/entries/date-sections/index")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result DateSectionsIndex
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// EarlierEntryCounts fetches the number of unread entries older than 30 days of every user (admin only).
+func (c *Client) EarlierEntryCounts() ([]*UserEarlierEntryCount, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.EarlierEntryCountsContext(ctx)
+}
+
+// EarlierEntryCountsContext fetches the number of unread entries older than 30 days of every user (admin only).
+func (c *Client) EarlierEntryCountsContext(ctx context.Context) ([]*UserEarlierEntryCount, error) {
+	body, err := c.request.Get(ctx, "/v1/users/earlier-entry-counts")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var counts []*UserEarlierEntryCount
+	if err := json.NewDecoder(body).Decode(&counts); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return counts, nil
+}
+
+// UserDateSections fetches the sections of the date view of another user with their unread counts (admin only).
+// The scheme selects the section layout, an empty scheme uses the default one.
+func (c *Client) UserDateSections(userID int64, scheme string) (*UserDateSections, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.UserDateSectionsContext(ctx, userID, scheme)
+}
+
+// UserDateSectionsContext fetches the sections of the date view of another user with their unread counts (admin only).
+// The scheme selects the section layout, an empty scheme uses the default one.
+func (c *Client) UserDateSectionsContext(ctx context.Context, userID int64, scheme string) (*UserDateSections, error) {
+	path := fmt.Sprintf("/v1/users/%d/date-sections", userID)
+	if scheme != "" {
+		path += "?buckets=" + url.QueryEscape(scheme)
+	}
+
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result UserDateSections
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// EntryCountsByHourOfDay fetches the number of entries published during each hour of the day over the last days.
+func (c *Client) EntryCountsByHourOfDay(days int) (*HourOfDayEntryCounts, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.EntryCountsByHourOfDayContext(ctx, days)
+}
+
+// EntryCountsByHourOfDayContext fetches the number of entries published during each hour of the day over the last days.
+func (c *Client) EntryCountsByHourOfDayContext(ctx context.Context, days int) (*HourOfDayEntryCounts, error) {
+	body, err := c.request.Get(ctx, "/v1/entries/hour-of-day-counts?days="+strconv.Itoa(days))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result HourOfDayEntryCounts
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// BulkStatusUpdates fetches the last date sections marked as read, or removed, at once.
+func (c *Client) BulkStatusUpdates(limit int) ([]*BulkStatusUpdate, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.BulkStatusUpdatesContext(ctx, limit)
+}
+
+// BulkStatusUpdatesContext fetches the last date sections marked as read, or removed, at once.
+func (c *Client) BulkStatusUpdatesContext(ctx context.Context, limit int) ([]*BulkStatusUpdate, error) {
+	body, err := c.request.Get(ctx, "/v1/entries/bulk-status-updates?limit="+strconv.Itoa(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var updates []*BulkStatusUpdate
+	if err := json.NewDecoder(body).Decode(&updates); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return updates, nil
+}
+
+// BulkStatusUpdateReasons fetches how often each reason was given to bulk status updates.
+func (c *Client) BulkStatusUpdateReasons() ([]*BulkStatusUpdateReasonCount, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.BulkStatusUpdateReasonsContext(ctx)
+}
+
+// BulkStatusUpdateReasonsContext fetches how often each reason was given to bulk status updates.
+func (c *Client) BulkStatusUpdateReasonsContext(ctx context.Context) ([]*BulkStatusUpdateReasonCount, error) {
+	body, err := c.request.Get(ctx, "/v1/entries/bulk-status-updates/reasons")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var counts []*BulkStatusUpdateReasonCount
+	if err := json.NewDecoder(body).Decode(&counts); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return counts, nil
+}
+
+// DateViewSettings fetches every date view preference of the logged user.
+func (c *Client) DateViewSettings() (*DateViewSettings, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.DateViewSettingsContext(ctx)
+}
+
+// DateViewSettingsContext fetches every date view preference of the logged user.
+func (c *Client) DateViewSettingsContext(ctx context.Context) (*DateViewSettings, error) {
+	body, err := c.request.Get(ctx, "/v1/me/date-view-settings")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var settings DateViewSettings
+	if err := json.NewDecoder(body).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &settings, nil
+}
+
+// UpdateDateViewSettings replaces every date view preference of the logged user at once.
+func (c *Client) UpdateDateViewSettings(settings *DateViewSettings) (*DateViewSettings, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.UpdateDateViewSettingsContext(ctx, settings)
+}
+
+// UpdateDateViewSettingsContext replaces every date view preference of the logged user at once.
+func (c *Client) UpdateDateViewSettingsContext(ctx context.Context, settings *DateViewSettings) (*DateViewSettings, error) {
+	body, err := c.request.Put(ctx, "/v1/me/date-view-settings", settings)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var updated DateViewSettings
+	if err := json.NewDecoder(body).Decode(&updated); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &updated, nil
+}
+
 // FlushHistory changes all entries with the status "read" to "removed".
 func (c *Client) FlushHistory() error {
 	ctx, cancel := withDefaultTimeout()
@@ -1163,6 +1555,14 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("published_before", strconv.FormatInt(filter.PublishedBefore, 10))
 		}
 
+		if filter.DisplayDateAfter > 0 {
+			values.Set("display_date_after", strconv.FormatInt(filter.DisplayDateAfter, 10))
+		}
+
+		if filter.DisplayDateBefore > 0 {
+			values.Set("display_date_before", strconv.FormatInt(filter.DisplayDateBefore, 10))
+		}
+
 		if filter.ChangedAfter > 0 {
 			values.Set("changed_after", strconv.FormatInt(filter.ChangedAfter, 10))
 		}
@@ -1199,6 +1599,10 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("globally_visible", "true")
 		}
 
+		if filter.Cursor != "" {
+			values.Set("cursor", filter.Cursor)
+		}
+
 		for _, status := range filter.Statuses {
 			values.Add("status", status)
 		}
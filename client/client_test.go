@@ -1147,6 +1147,23 @@ func TestToggleStarred(t *testing.T) {
 	}
 }
 
+func TestSetEntryDisplayDate(t *testing.T) {
+	displayDate := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodPut, "http://mf/v1/entries/1/display-date", nil, req)
+				expectFromJSON(t, req.Body, &EntryDisplayDateRequest{
+					DisplayDate: &displayDate,
+				})
+				return jsonResponseFrom(t, http.StatusNoContent, http.Header{}, nil)
+			})))
+	if err := client.SetEntryDisplayDateContext(t.Context(), 1, &displayDate); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
 func TestSaveEntry(t *testing.T) {
 	client := NewClientWithOptions(
 		"http://mf",
@@ -1207,6 +1224,200 @@ func TestFetchCounters(t *testing.T) {
 	}
 }
 
+func TestDateSections(t *testing.T) {
+	publishedAfter := time.Date(2025, time.March, 2, 12, 0, 0, 0, time.UTC)
+	expected := &DateSections{
+		Total: 3,
+		Sections: []*DateSection{
+			{
+				Name:           "recent",
+				Label:          "Recent",
+				Count:          3,
+				PublishedAfter: &publishedAfter,
+				EntriesURL:     "http://mf/v1/entries?globally_visible=true&published_after=1740916800&status=unread",
+				PageURL:        "http://mf/entries/by-date?buckets=simple&section=recent",
+			},
+		},
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/entries/date-sections?buckets=simple", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.DateSectionsContext(t.Context(), "simple")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
+func TestFlatDateSections(t *testing.T) {
+	expected := &FlatDateSections{
+		DateSections: DateSections{
+			Total:    1,
+			Sections: []*DateSection{{Name: "today", Label: "Today", Count: 1}},
+		},
+		Entries: []*DateSectionEntry{
+			{Entry: &Entry{ID: 42, Title: "Entry"}, Section: "today", SectionLabel: "Today"},
+		},
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/entries/date-sections?buckets=simple&flat=1&limit=50&offset=100", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.FlatDateSectionsContext(t.Context(), "simple", 100, 50)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
+func TestDateSectionReadingQueue(t *testing.T) {
+	expected := &DateSectionReadingQueue{
+		Total: 2,
+		Entries: []*DateSectionEntry{
+			{Entry: &Entry{ID: 42, Title: "New"}, Section: "today", SectionLabel: "Today"},
+			{Entry: &Entry{ID: 7, Title: "Old"}, Section: "earlier", SectionLabel: "Earlier"},
+		},
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/entries/date-sections/reading-queue?quotas=today%3A1%2Cearlier%3A1", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.DateSectionReadingQueueContext(t.Context(), "", "today:1,earlier:1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
+func TestDateSectionLatestEntries(t *testing.T) {
+	expected := DateSectionLatestEntries{
+		"today":   {Entry: &Entry{ID: 42, Title: "New"}, Section: "today", SectionLabel: "Today"},
+		"earlier": nil,
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/entries/date-sections/latest?buckets=simple", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.DateSectionLatestEntriesContext(t.Context(), "simple")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
+func TestBacklogFeeds(t *testing.T) {
+	expected := []*FeedUnreadCount{
+		{FeedID: 2, FeedTitle: "Busy", UnreadCount: 120},
+		{FeedID: 1, FeedTitle: "Quiet", UnreadCount: 3},
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/feeds/backlog?limit=10&older_than_days=7", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.BacklogFeedsContext(t.Context(), 7, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
+func TestMarkDateSectionReadUpTo(t *testing.T) {
+	publishedAt := time.Date(2025, time.March, 10, 8, 30, 0, 0, time.UTC)
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodPut, "http://mf/v1/entries/date-sections/mark-read-up-to", nil, req)
+				expectFromJSON(t, req.Body, &struct {
+					Section   string `json:"section"`
+					SortValue string `json:"sort_value"`
+					EntryID   int64  `json:"id"`
+				}{
+					Section:   "today",
+					SortValue: "2025-03-10T08:30:00Z",
+					EntryID:   42,
+				})
+				return jsonResponseFrom(t, http.StatusNoContent, http.Header{}, nil)
+			})))
+	if err := client.MarkDateSectionReadUpToContext(t.Context(), "today", publishedAt, 42); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestDateSectionsIndex(t *testing.T) {
+	expected := &DateSectionsIndex{
+		Name:          "entries_user_status_published_idx",
+		Exists:        true,
+		Size:          16384,
+		UnreadEntries: 25,
+		TotalEntries:  100,
+		Selectivity:   0.05,
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/entries/date-sections/index", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.DateSectionsIndexContext(t.Context())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
+func TestUserDateSections(t *testing.T) {
+	expected := &UserDateSections{
+		UserID:   2,
+		Timezone: "Europe/Paris",
+		Total:    3,
+		Sections: []*DateSection{{Name: "today", Label: "Today", Count: 3}},
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/users/2/date-sections?buckets=simple", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.UserDateSectionsContext(t.Context(), 2, "simple")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
 func TestFlushHistory(t *testing.T) {
 	client := NewClientWithOptions(
 		"http://mf",
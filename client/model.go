@@ -48,6 +48,9 @@ type User struct {
 	ExternalFontHosts         string     `json:"external_font_hosts"`
 	AlwaysOpenExternalLinks   bool       `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab bool       `json:"open_external_links_in_new_tab"`
+	DateViewUseLatestDate     bool       `json:"date_view_use_latest_date"`
+	DateViewExcludeSeen       bool       `json:"date_view_exclude_seen"`
+	DateViewDayResetHour      *int       `json:"date_view_day_reset_hour"`
 }
 
 func (u User) String() string {
@@ -94,6 +97,9 @@ type UserModificationRequest struct {
 	ExternalFontHosts         *string  `json:"external_font_hosts"`
 	AlwaysOpenExternalLinks   *bool    `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab *bool    `json:"open_external_links_in_new_tab"`
+	DateViewUseLatestDate     *bool    `json:"date_view_use_latest_date"`
+	DateViewExcludeSeen       *bool    `json:"date_view_exclude_seen"`
+	DateViewDayResetHour      *int     `json:"date_view_day_reset_hour"`
 }
 
 // Users represents a list of users.
@@ -174,6 +180,10 @@ type Feed struct {
 	HideGlobally                bool      `json:"hide_globally"`
 	DisableHTTP2                bool      `json:"disable_http2"`
 	ProxyURL                    string    `json:"proxy_url"`
+	Priority                    bool      `json:"priority"`
+	Pinned                      bool      `json:"pinned"`
+	AgeBasis                    string    `json:"age_basis"`
+	Source                      string    `json:"source"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
@@ -226,6 +236,9 @@ type FeedModificationRequest struct {
 	HideGlobally                *bool   `json:"hide_globally"`
 	DisableHTTP2                *bool   `json:"disable_http2"`
 	ProxyURL                    *string `json:"proxy_url"`
+	Priority                    *bool   `json:"priority"`
+	Pinned                      *bool   `json:"pinned"`
+	AgeBasis                    *string `json:"age_basis"`
 }
 
 // FeedIcon represents the feed icon.
@@ -240,30 +253,140 @@ type FeedCounters struct {
 	UnreadCounters map[int64]int `json:"unreads"`
 }
 
+// FeedUnreadCount represents the number of unread entries of a feed.
+type FeedUnreadCount struct {
+	FeedID      int64  `json:"feed_id"`
+	FeedTitle   string `json:"feed_title"`
+	UnreadCount int    `json:"unread_count"`
+}
+
 // Feeds represents a list of feeds.
 type Feeds []*Feed
 
+// DateSection represents a section of the date view.
+type DateSection struct {
+	Name            string     `json:"name"`
+	Label           string     `json:"label"`
+	Count           int        `json:"count"`
+	PublishedAfter  *time.Time `json:"published_after"`
+	PublishedBefore *time.Time `json:"published_before"`
+	EntriesURL      string     `json:"entries_url"`
+	PageURL         string     `json:"page_url"`
+}
+
+// DateSections represents the list of sections of the date view.
+type DateSections struct {
+	Total    int            `json:"total"`
+	Sections []*DateSection `json:"sections"`
+}
+
+// UserDateSections represents the sections of the date view of another user, as seen by an administrator.
+type UserDateSections struct {
+	UserID   int64          `json:"user_id"`
+	Timezone string         `json:"timezone"`
+	Total    int            `json:"total"`
+	Sections []*DateSection `json:"sections"`
+}
+
+// DateSectionEntry represents an entry of the flat listing of the date view, along with its section.
+type DateSectionEntry struct {
+	*Entry
+	Section      string `json:"section"`
+	SectionLabel string `json:"section_label"`
+}
+
+// FlatDateSections represents the sections of the date view followed by a page of their entries.
+type FlatDateSections struct {
+	DateSections
+	Entries []*DateSectionEntry `json:"entries"`
+}
+
+// DateSectionReadingQueue represents a reading queue mixing the entries of several sections of the date view.
+type DateSectionReadingQueue struct {
+	Total   int                 `json:"total"`
+	Entries []*DateSectionEntry `json:"entries"`
+}
+
+// DateSectionLatestEntries maps the name of each section of the date view to its newest unread entry, or nil.
+type DateSectionLatestEntries map[string]*DateSectionEntry
+
+// UserEarlierEntryCount represents the number of unread entries of a user older than 30 days.
+type UserEarlierEntryCount struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Count    int    `json:"count"`
+}
+
+// BulkStatusUpdate represents the entries of a date section marked as read, or removed, at once.
+type BulkStatusUpdate struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Section    string    `json:"section"`
+	Status     string    `json:"status"`
+	EntryCount int       `json:"entry_count"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BulkStatusUpdateReasonCount represents how often a reason was given to bulk status updates.
+type BulkStatusUpdateReasonCount struct {
+	Reason      string `json:"reason"`
+	UpdateCount int    `json:"update_count"`
+	EntryCount  int    `json:"entry_count"`
+}
+
+// DateViewSettings represents every date view preference of a user. DayResetHour is -1 for rolling 24 hours.
+type DateViewSettings struct {
+	UseLatestDate     bool     `json:"use_latest_date"`
+	ExcludeSeen       bool     `json:"exclude_seen"`
+	DayResetHour      int      `json:"day_reset_hour"`
+	CollapsedSections []string `json:"collapsed_sections"`
+}
+
+// HourOfDayEntryCounts represents the number of entries published during each hour of the day.
+type HourOfDayEntryCounts struct {
+	Days   int     `json:"days"`
+	Counts [24]int `json:"counts"`
+}
+
+// DateSectionsIndex represents the state of the index used to count the sections of the date view.
+type DateSectionsIndex struct {
+	Name          string  `json:"name"`
+	Exists        bool    `json:"exists"`
+	Size          int64   `json:"size"`
+	UnreadEntries int64   `json:"unread_entries"`
+	TotalEntries  int64   `json:"total_entries"`
+	Selectivity   float64 `json:"selectivity"`
+}
+
 // Entry represents a subscription item in the system.
 type Entry struct {
-	ID          int64      `json:"id"`
-	Date        time.Time  `json:"published_at"`
-	ChangedAt   time.Time  `json:"changed_at"`
-	CreatedAt   time.Time  `json:"created_at"`
-	Feed        *Feed      `json:"feed,omitempty"`
-	Hash        string     `json:"hash"`
-	URL         string     `json:"url"`
-	CommentsURL string     `json:"comments_url"`
-	Title       string     `json:"title"`
-	Status      string     `json:"status"`
-	Content     string     `json:"content"`
-	Author      string     `json:"author"`
-	ShareCode   string     `json:"share_code"`
-	Enclosures  Enclosures `json:"enclosures,omitempty"`
-	Tags        []string   `json:"tags"`
-	ReadingTime int        `json:"reading_time"`
-	UserID      int64      `json:"user_id"`
-	FeedID      int64      `json:"feed_id"`
-	Starred     bool       `json:"starred"`
+	ID                  int64      `json:"id"`
+	Date                time.Time  `json:"published_at"`
+	ChangedAt           time.Time  `json:"changed_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	Feed                *Feed      `json:"feed,omitempty"`
+	Hash                string     `json:"hash"`
+	URL                 string     `json:"url"`
+	CommentsURL         string     `json:"comments_url"`
+	Title               string     `json:"title"`
+	Status              string     `json:"status"`
+	Content             string     `json:"content"`
+	Author              string     `json:"author"`
+	ShareCode           string     `json:"share_code"`
+	Enclosures          Enclosures `json:"enclosures,omitempty"`
+	Tags                []string   `json:"tags"`
+	ReadingTime         int        `json:"reading_time"`
+	UserID              int64      `json:"user_id"`
+	FeedID              int64      `json:"feed_id"`
+	Starred             bool       `json:"starred"`
+	Language            string     `json:"language"`
+	OpenedAt            *time.Time `json:"opened_at"`
+	ReadProgress        float64    `json:"read_progress"`
+	DisplayDateOverride *time.Time `json:"display_date_override"`
+	Labels              []string   `json:"labels"`
+	StarredAt           *time.Time `json:"starred_at"`
+	ScrapeStatus        string     `json:"scrape_status"`
 }
 
 // EntryModificationRequest represents a request to modify an entry.
@@ -272,6 +395,11 @@ type EntryModificationRequest struct {
 	Content *string `json:"content"`
 }
 
+// EntryDisplayDateRequest represents a request to set, or clear with a nil date, the display date override of an entry.
+type EntryDisplayDateRequest struct {
+	DisplayDate *time.Time `json:"display_date"`
+}
+
 // Entries represents a list of entries.
 type Entries []*Entry
 
@@ -284,6 +412,7 @@ type Enclosure struct {
 	MimeType         string `json:"mime_type"`
 	Size             int    `json:"size"`
 	MediaProgression int64  `json:"media_progression"`
+	Duration         int64  `json:"duration"`
 }
 
 type EnclosureUpdateRequest struct {
@@ -300,31 +429,35 @@ const (
 
 // Filter is used to filter entries.
 type Filter struct {
-	Status          string
-	Offset          int
-	Limit           int
-	Order           string
-	Direction       string
-	Starred         string
-	Before          int64
-	After           int64
-	PublishedBefore int64
-	PublishedAfter  int64
-	ChangedBefore   int64
-	ChangedAfter    int64
-	BeforeEntryID   int64
-	AfterEntryID    int64
-	Search          string
-	CategoryID      int64
-	FeedID          int64
-	Statuses        []string
-	GloballyVisible bool
+	Status            string
+	Offset            int
+	Limit             int
+	Order             string
+	Direction         string
+	Starred           string
+	Before            int64
+	After             int64
+	PublishedBefore   int64
+	PublishedAfter    int64
+	ChangedBefore     int64
+	ChangedAfter      int64
+	BeforeEntryID     int64
+	AfterEntryID      int64
+	Search            string
+	CategoryID        int64
+	FeedID            int64
+	Statuses          []string
+	GloballyVisible   bool
+	Cursor            string
+	DisplayDateBefore int64
+	DisplayDateAfter  int64
 }
 
 // EntryResultSet represents the response when fetching entries.
 type EntryResultSet struct {
-	Total   int     `json:"total"`
-	Entries Entries `json:"entries"`
+	Total      int     `json:"total"`
+	Entries    Entries `json:"entries"`
+	NextCursor string  `json:"next_cursor,omitempty"`
 }
 
 // VersionResponse represents the version and the build information of the Miniflux instance.
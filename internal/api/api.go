@@ -37,8 +37,12 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/users/{userID:[0-9]+}", handler.updateUser).Methods(http.MethodPut)
 	sr.HandleFunc("/users/{userID:[0-9]+}", handler.removeUser).Methods(http.MethodDelete)
 	sr.HandleFunc("/users/{userID:[0-9]+}/mark-all-as-read", handler.markUserAsRead).Methods(http.MethodPut)
+	sr.HandleFunc("/users/{userID:[0-9]+}/date-sections", handler.getUserDateSections).Methods(http.MethodGet)
+	sr.HandleFunc("/users/earlier-entry-counts", handler.getEarlierEntryCountsByUser).Methods(http.MethodGet)
 	sr.HandleFunc("/users/{username}", handler.userByUsername).Methods(http.MethodGet)
 	sr.HandleFunc("/me", handler.currentUser).Methods(http.MethodGet)
+	sr.HandleFunc("/me/date-view-settings", handler.getDateViewSettings).Methods(http.MethodGet)
+	sr.HandleFunc("/me/date-view-settings", handler.updateDateViewSettings).Methods(http.MethodPut)
 	sr.HandleFunc("/categories", handler.createCategory).Methods(http.MethodPost)
 	sr.HandleFunc("/categories", handler.getCategories).Methods(http.MethodGet)
 	sr.HandleFunc("/categories/{categoryID}", handler.updateCategory).Methods(http.MethodPut)
@@ -52,6 +56,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds", handler.createFeed).Methods(http.MethodPost)
 	sr.HandleFunc("/feeds", handler.getFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/counters", handler.fetchCounters).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/backlog", handler.getBacklogFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}/refresh", handler.refreshFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.getFeed).Methods(http.MethodGet)
@@ -65,8 +70,19 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds/{feedID}/entries/{entryID}", handler.getFeedEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.getEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.setEntryStatus).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/date-sections", handler.getDateSections).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/date-sections/index", handler.getDateSectionsIndex).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/date-sections/counts", handler.getDateSectionCounts).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/date-sections/mark-read-up-to", handler.markDateSectionReadUpToCursor).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/date-sections/feed.json", handler.getDateSectionJSONFeed).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/date-sections/reading-queue", handler.getDateSectionReadingQueue).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/date-sections/latest", handler.getDateSectionLatestEntries).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/hour-of-day-counts", handler.getEntryCountsByHourOfDay).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/bulk-status-updates", handler.getBulkStatusUpdates).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/bulk-status-updates/reasons", handler.getBulkStatusUpdateReasons).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}", handler.getEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}", handler.updateEntry).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/display-date", handler.setEntryDisplayDate).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/bookmark", handler.toggleStarred).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/star", handler.toggleStarred).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/save", handler.saveEntry).Methods(http.MethodPost)
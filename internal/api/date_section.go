@@ -0,0 +1,576 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/validator"
+)
+
+// CUSTOM: getDateSections lists the sections of the date entries view with their unread counts
+// and the URLs to fetch the entries of each section. With "flat=1", a page of the unread entries of all the
+// sections follows, each entry carrying the name of its section, paginated with "offset" and "limit".
+func (h *handler) getDateSections(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	scheme, userTimezone, err := dateSectionsSchemeAndTimezone(r, user)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	pageURL := config.Opts.RootURL() + route.Path(h.router, "dateEntries")
+	entriesURL := config.Opts.BaseURL() + "/v1/entries"
+
+	now := timezone.Now(userTimezone)
+	sections := model.PublicationDateSections(user.DateSections(scheme, now))
+	counts, err := h.countDateSections(user, scheme, userTimezone, now, sections)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	response := &dateSectionsResponse{Sections: make([]*dateSectionResponse, 0)}
+	for i, section := range sections {
+		count := counts[i]
+
+		entriesQuery := url.Values{}
+		entriesQuery.Set("status", model.EntryStatusUnread)
+		entriesQuery.Set("globally_visible", "true")
+
+		if section.After != nil {
+			entriesQuery.Set("display_date_after", strconv.FormatInt(section.After.Unix(), 10))
+		}
+
+		if section.Before != nil {
+			entriesQuery.Set("display_date_before", strconv.FormatInt(section.Before.Unix(), 10))
+		}
+
+		pageQuery := url.Values{}
+		pageQuery.Set("section", section.Name)
+		if scheme != model.DateSectionSchemeDefault {
+			pageQuery.Set("buckets", scheme)
+		}
+
+		response.Sections = append(response.Sections, &dateSectionResponse{
+			Name:            section.Name,
+			Label:           section.DisplayLabel(printer.Printf),
+			Count:           count,
+			PublishedAfter:  section.After,
+			PublishedBefore: section.Before,
+			EntriesURL:      entriesURL + "?" + entriesQuery.Encode(),
+			PageURL:         pageURL + "?" + pageQuery.Encode(),
+		})
+		response.Total += count
+	}
+
+	// The flat mode returns a page of the entries of every section, newest section first
+	if request.QueryBoolParam(r, "flat", false) {
+		limit := request.QueryIntParam(r, "limit", 100)
+		offset := request.QueryIntParam(r, "offset", 0)
+		if err := validator.ValidateRange(offset, limit); err != nil {
+			json.BadRequest(w, r, err)
+			return
+		}
+
+		flatResponse := &flatDateSectionsResponse{dateSectionsResponse: response, Entries: make([]*dateSectionEntryResponse, 0, limit)}
+		for _, page := range dateSectionPages(counts, offset, limit) {
+			section := sections[page.index]
+
+			builder := h.newDateSectionQueryBuilder(user.ID)
+			builder.WithEnclosures()
+			builder.WithinDateSection(section, user.DateViewUseLatestDate)
+			builder.WithStableSorting("published_at", "desc")
+			builder.WithOffset(page.offset)
+			builder.WithLimit(page.limit)
+
+			entries, err := builder.GetEntries()
+			if err != nil {
+				json.ServerError(w, r, err)
+				return
+			}
+
+			for _, entry := range entries {
+				entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entry.Content)
+				flatResponse.Entries = append(flatResponse.Entries, &dateSectionEntryResponse{
+					Entry:        entry,
+					Section:      section.Name,
+					SectionLabel: response.Sections[page.index].Label,
+				})
+			}
+		}
+
+		json.OK(w, r, flatResponse)
+		return
+	}
+
+	json.OK(w, r, response)
+}
+
+// dateSectionPage is the part of a section a page of the flat listing holds.
+type dateSectionPage struct {
+	index  int
+	offset int
+	limit  int
+}
+
+// dateSectionPages splits the page of the flat listing starting at offset into the parts of the sections
+// holding its entries, given the count of each section. A limit of 0 lists every entry after the offset.
+func dateSectionPages(counts []int, offset, limit int) []dateSectionPage {
+	var pages []dateSectionPage
+	for index, count := range counts {
+		if offset >= count {
+			offset -= count
+			continue
+		}
+
+		size := count - offset
+		if limit > 0 {
+			size = min(size, limit)
+		}
+		pages = append(pages, dateSectionPage{index: index, offset: offset, limit: size})
+		offset = 0
+
+		if limit > 0 {
+			limit -= size
+			if limit == 0 {
+				break
+			}
+		}
+	}
+	return pages
+}
+
+// CUSTOM: getDateSectionCounts returns the unread count of each section of the date entries view as
+// "X-Unread-<Section>" headers, such as "X-Unread-Today", along with "X-Unread-Total". The plain text body
+// holds the same counts as a single comma separated line, in section order, for status bars that cannot parse JSON.
+func (h *handler) getDateSectionCounts(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	scheme, userTimezone, err := dateSectionsSchemeAndTimezone(r, user)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	now := timezone.Now(userTimezone)
+	sections := model.PublicationDateSections(user.DateSections(scheme, now))
+	counts, err := h.countDateSections(user, scheme, userTimezone, now, sections)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	builder := response.New(w, r)
+	builder.WithHeader("Content-Type", "text/plain; charset=utf-8")
+	builder.WithHeader("Cache-Control", "no-cache, max-age=0, must-revalidate, no-store")
+
+	values := make([]string, len(counts))
+	total := 0
+	for i, section := range sections {
+		builder.WithHeader(dateSectionCountHeader(section.Name), strconv.Itoa(counts[i]))
+		values[i] = strconv.Itoa(counts[i])
+		total += counts[i]
+	}
+	builder.WithHeader("X-Unread-Total", strconv.Itoa(total))
+	builder.WithBody(strings.Join(values, ",") + "\n")
+	builder.Write()
+}
+
+// CUSTOM: markDateSectionReadUpToCursor marks as read, with a single UPDATE, the unread entries of a section
+// listed up to, and including, the entry at the cursor in the order of the flat mode, newest first. Clients
+// scrolling through the flat mode send the position of the last entry scrolled past rather than every entry ID.
+func (h *handler) markDateSectionReadUpToCursor(w http.ResponseWriter, r *http.Request) {
+	var markReadRequest model.EntriesMarkReadUpToCursorRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&markReadRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if err := validator.ValidateEntriesMarkReadUpToCursorRequest(&markReadRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	scheme, userTimezone, err := dateSectionsSchemeAndTimezone(r, user)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	builder := h.newDateSectionQueryBuilder(user.ID)
+
+	if markReadRequest.Section != "" {
+		sections := model.PublicationDateSections(user.DateSections(scheme, timezone.Now(userTimezone)))
+		section, found := model.FindDateSection(sections, markReadRequest.Section)
+		if !found {
+			json.BadRequest(w, r, fmt.Errorf("unknown date section %q", markReadRequest.Section))
+			return
+		}
+
+		builder.WithinDateSection(section, user.DateViewUseLatestDate)
+	}
+
+	cursor := &model.EntryCursor{SortValue: markReadRequest.SortValue, EntryID: markReadRequest.EntryID}
+	builder.UpToCursor(cursor, "published_at", "desc")
+
+	marked, err := builder.UpdateStatus(model.EntryStatusRead)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	// The bulk action is recorded like the ones of the date view, an empty section standing for all of them
+	section := markReadRequest.Section
+	if section == "" {
+		section = "all"
+	}
+
+	if err := h.store.CreateBulkStatusUpdate(&model.BulkStatusUpdate{
+		UserID:     user.ID,
+		Section:    section,
+		Status:     model.EntryStatusRead,
+		EntryCount: marked,
+	}); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+// dateSectionCountHeader returns the response header holding the unread count of the section,
+// e.g. "X-Unread-Last2d" for "last2d" or "X-Unread-This-Week" for "this_week".
+func dateSectionCountHeader(sectionName string) string {
+	return http.CanonicalHeaderKey("X-Unread-" + strings.ReplaceAll(sectionName, "_", "-"))
+}
+
+// dateSectionsSchemeAndTimezone returns the section scheme requested with the "buckets" query parameter and the
+// timezone of the section boundaries. API clients acting for the user in another display timezone may override
+// the user timezone with the "X-Timezone" header.
+func dateSectionsSchemeAndTimezone(r *http.Request, user *model.User) (string, string, error) {
+	scheme, err := dateSectionsScheme(r)
+	if err != nil {
+		return "", "", err
+	}
+
+	userTimezone := user.Timezone
+	if value := r.Header.Get("X-Timezone"); value != "" {
+		if _, found := timezone.AvailableTimezones()[value]; !found {
+			return "", "", fmt.Errorf("invalid X-Timezone header %q", value)
+		}
+		userTimezone = value
+	}
+
+	return scheme, userTimezone, nil
+}
+
+// dateSectionsScheme returns the section scheme given by the "buckets" query parameter.
+func dateSectionsScheme(r *http.Request) (string, error) {
+	scheme := request.QueryStringParam(r, "buckets", model.DateSectionSchemeDefault)
+	if scheme != model.DateSectionSchemeDefault && scheme != model.DateSectionSchemeSimple {
+		return "", fmt.Errorf("invalid buckets value %q", scheme)
+	}
+	return scheme, nil
+}
+
+// newDateSectionQueryBuilder returns a query builder for the unread entries the date view shows, before
+// the bounds of a section, so the API agrees with the pages of the date view.
+func (h *handler) newDateSectionQueryBuilder(userID int64) *storage.EntryQueryBuilder {
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithDateViewVisibility(false)
+	return builder
+}
+
+// countDateSections returns the unread count of each section, in the same order, going through the
+// date section count cache so both date section endpoints agree.
+func (h *handler) countDateSections(user *model.User, scheme, userTimezone string, now time.Time, sections []model.DateSection) ([]int, error) {
+	counts := make([]int, 0, len(sections))
+	for _, section := range sections {
+		builder := h.newDateSectionQueryBuilder(user.ID)
+		builder.WithinDateSection(section, user.DateViewUseLatestDate)
+
+		countKey := dateSectionCountKey(user, scheme, userTimezone, section.Name)
+		count, err := h.store.DateSectionCount(user.ID, now, countKey, builder.CountEntries)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// dateSectionCountKey identifies the count of a section in the date section count cache. It holds every
+// parameter the count depends on, except the reference time which the cache tracks by minute.
+func dateSectionCountKey(user *model.User, scheme, userTimezone, section string) string {
+	values := url.Values{}
+	values.Set("section", section)
+	values.Set("buckets", scheme)
+	values.Set("tz", userTimezone)
+	values.Set("latest_date", strconv.FormatBool(user.DateViewUseLatestDate))
+	if user.DateViewDayResetHour != nil {
+		values.Set("reset_hour", strconv.Itoa(*user.DateViewDayResetHour))
+	}
+	return "api?" + values.Encode()
+}
+
+// CUSTOM: getEntryCountsByHourOfDay returns the number of entries published during each hour of the day over
+// the last days, 30 unless the "days" query parameter says otherwise, to tell the best time to read.
+func (h *handler) getEntryCountsByHourOfDay(w http.ResponseWriter, r *http.Request) {
+	days := request.QueryIntParam(r, "days", 30)
+	if days < 1 || days > 365 {
+		json.BadRequest(w, r, fmt.Errorf("days must be between 1 and 365, got %d", days))
+		return
+	}
+
+	counts, err := h.store.EntryCountsByHourOfDay(request.UserID(r), days)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, &hourOfDayEntryCountsResponse{Days: days, Counts: counts})
+}
+
+// CUSTOM: getBulkStatusUpdates lists the last date sections the user marked as read, or removed, at once with
+// the number of entries changed, the 50 most recent unless the "limit" query parameter says otherwise.
+func (h *handler) getBulkStatusUpdates(w http.ResponseWriter, r *http.Request) {
+	limit := request.QueryIntParam(r, "limit", 50)
+	if limit < 1 || limit > 1000 {
+		json.BadRequest(w, r, fmt.Errorf("limit must be between 1 and 1000, got %d", limit))
+		return
+	}
+
+	updates, err := h.store.BulkStatusUpdates(request.UserID(r), limit)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, updates)
+}
+
+// CUSTOM: getBulkStatusUpdateReasons reports how often the user gave each reason, such as "bankruptcy",
+// to the date sections marked as read at once, the most used first.
+func (h *handler) getBulkStatusUpdateReasons(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.store.BulkStatusUpdateReasonCounts(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, counts)
+}
+
+// CUSTOM: getEarlierEntryCountsByUser reports to administrators the number of unread entries older than 30 days
+// of every user, the largest first, to spot the date views likely to be slow before they are.
+func (h *handler) getEarlierEntryCountsByUser(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	counts, err := h.store.EarlierEntryCountsByUser(time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, counts)
+}
+
+// CUSTOM: getUserDateSections reports to administrators the unread count of each date section of another user,
+// computed in the timezone of that user, to help them without signing in as them. It only reads, and every
+// access is logged.
+func (h *handler) getUserDateSections(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	scheme, err := dateSectionsScheme(r)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	userID := request.RouteInt64Param(r, "userID")
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	slog.Info("[API] Administrator read the date sections of a user",
+		slog.Int64("admin_user_id", request.UserID(r)),
+		slog.Int64("user_id", user.ID),
+		slog.String("client_ip", request.ClientIP(r)),
+		slog.String("request_uri", r.RequestURI),
+	)
+
+	printer := locale.NewPrinter(user.Language)
+	now := timezone.Now(user.Timezone)
+	sections := model.PublicationDateSections(user.DateSections(scheme, now))
+	counts, err := h.countDateSections(user, scheme, user.Timezone, now, sections)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	response := &userDateSectionsResponse{UserID: user.ID, Timezone: user.Timezone, Sections: make([]*userDateSectionResponse, 0, len(sections))}
+	for i, section := range sections {
+		response.Sections = append(response.Sections, &userDateSectionResponse{
+			Name:            section.Name,
+			Label:           section.DisplayLabel(printer.Printf),
+			Count:           counts[i],
+			PublishedAfter:  section.After,
+			PublishedBefore: section.Before,
+		})
+		response.Total += counts[i]
+	}
+
+	json.OK(w, r, response)
+}
+
+// CUSTOM: getDateViewSettings returns every date view preference of the user in one document.
+func (h *handler) getDateViewSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.dateViewSettings(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if settings == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.OK(w, r, settings)
+}
+
+// CUSTOM: updateDateViewSettings replaces every date view preference of the user at once. The whole bundle
+// is validated first, so an invalid setting leaves all the preferences unchanged.
+func (h *handler) updateDateViewSettings(w http.ResponseWriter, r *http.Request) {
+	var settingsRequest model.DateViewSettingsRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&settingsRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateDateViewSettings(&settingsRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	userID := request.UserID(r)
+	if err := h.store.UpdateDateViewSettings(userID, settingsRequest.Settings()); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	settings, err := h.dateViewSettings(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if settings == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.Created(w, r, settings)
+}
+
+// dateViewSettings returns the date view preferences of the user as stored, nil when the user does not exist.
+func (h *handler) dateViewSettings(userID int64) (*model.DateViewSettings, error) {
+	user, err := h.store.UserByID(userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	collapsedSections, err := h.store.DateViewCollapsedSections(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DateViewSettings{
+		UseLatestDate:     user.DateViewUseLatestDate,
+		ExcludeSeen:       user.DateViewExcludeSeen,
+		DayResetHour:      user.DateViewDayResetHourOrRolling(),
+		CollapsedSections: collapsedSections,
+	}, nil
+}
+
+// CUSTOM: getDateSectionsIndex reports the state of the index scanned to count the date sections,
+// to help diagnose slow counts of the older sections. Only administrators can call it: the size of the
+// index and the estimate of the entries cover the whole server.
+func (h *handler) getDateSectionsIndex(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	status, err := h.store.DateViewIndexStatus(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, status)
+}
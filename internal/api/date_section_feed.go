@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+)
+
+// dateSectionJSONFeedLimit is the number of entries, the newest first, listed in the JSON Feed of a date section.
+const dateSectionJSONFeedLimit = 100
+
+// CUSTOM: getDateSectionJSONFeed serves the unread entries of one section of the date entries view, "today"
+// unless the "section" query parameter says otherwise, as a JSON Feed for feed readers to subscribe to.
+func (h *handler) getDateSectionJSONFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	scheme, userTimezone, err := dateSectionsSchemeAndTimezone(r, user)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	sectionName := request.QueryStringParam(r, "section", "today")
+	sections := model.PublicationDateSections(user.DateSections(scheme, timezone.Now(userTimezone)))
+	section, found := model.FindDateSection(sections, sectionName)
+	if !found {
+		json.BadRequest(w, r, fmt.Errorf("unknown date section %q", sectionName))
+		return
+	}
+
+	builder := h.newDateSectionQueryBuilder(user.ID)
+	builder.WithEnclosures()
+	builder.WithinDateSection(section, user.DateViewUseLatestDate)
+	builder.WithStableSorting("published_at", "desc")
+	builder.WithLimit(dateSectionJSONFeedLimit)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	query := url.Values{}
+	query.Set("section", section.Name)
+	if scheme != model.DateSectionSchemeDefault {
+		query.Set("buckets", scheme)
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	feed := newDateSectionJSONFeed(
+		"Miniflux: "+section.DisplayLabel(printer.Printf),
+		config.Opts.RootURL()+route.Path(h.router, "dateEntries")+"?"+query.Encode(),
+		config.Opts.BaseURL()+"/v1/entries/date-sections/feed.json?"+query.Encode(),
+		user.Language,
+		entries,
+	)
+
+	body, err := json_parser.Marshal(feed)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", "application/feed+json; charset=utf-8").
+		WithBody(body).
+		Write()
+}
+
+// newDateSectionJSONFeed returns the JSON Feed document listing the entries. The language of the user,
+// such as "pt_BR", becomes the matching RFC 5646 tag "pt-BR".
+func newDateSectionJSONFeed(title, homePageURL, feedURL, language string, entries model.Entries) *dateSectionJSONFeed {
+	feed := &dateSectionJSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       title,
+		HomePageURL: homePageURL,
+		FeedURL:     feedURL,
+		Language:    strings.ReplaceAll(language, "_", "-"),
+		Items:       make([]*dateSectionJSONFeedItem, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		item := &dateSectionJSONFeedItem{
+			ID:            entry.Hash,
+			URL:           entry.URL,
+			ExternalURL:   entry.CommentsURL,
+			Title:         entry.Title,
+			ContentHTML:   entry.Content,
+			DatePublished: entry.Date,
+			Tags:          entry.Tags,
+		}
+
+		if entry.Author != "" {
+			item.Authors = []*dateSectionJSONFeedAuthor{{Name: entry.Author}}
+		}
+
+		for _, enclosure := range entry.Enclosures {
+			item.Attachments = append(item.Attachments, &dateSectionJSONFeedAttachment{
+				URL:      enclosure.URL,
+				MimeType: enclosure.MimeType,
+				Size:     enclosure.Size,
+				Duration: enclosure.Duration,
+			})
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed
+}
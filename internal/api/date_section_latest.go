@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+)
+
+// CUSTOM: getDateSectionLatestEntries returns the newest unread entry of each section of the date view, keyed by
+// the section name, for widgets previewing every section at once. Empty sections map to null. Only a single entry
+// is fetched per section, which is much cheaper than listing the sections.
+func (h *handler) getDateSectionLatestEntries(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	scheme, userTimezone, err := dateSectionsSchemeAndTimezone(r, user)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	sections := model.PublicationDateSections(user.DateSections(scheme, timezone.Now(userTimezone)))
+	latestEntries := make(dateSectionLatestEntriesResponse, len(sections))
+	for _, section := range sections {
+		builder := h.newDateSectionQueryBuilder(user.ID)
+		builder.WithEnclosures()
+		builder.WithinDateSection(section, user.DateViewUseLatestDate)
+		builder.WithStableSorting("published_at", "desc")
+		builder.WithLimit(1)
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if len(entries) == 0 {
+			latestEntries[section.Name] = nil
+			continue
+		}
+
+		entry := entries[0]
+		entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entry.Content)
+		latestEntries[section.Name] = &dateSectionEntryResponse{
+			Entry:        entry,
+			Section:      section.Name,
+			SectionLabel: section.DisplayLabel(printer.Printf),
+		}
+	}
+
+	json.OK(w, r, latestEntries)
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+)
+
+// dateSectionQueueDefaultQuotas are the quotas of the reading queue without a "quotas" query parameter.
+const dateSectionQueueDefaultQuotas = "today:3,last7d:2,earlier:1"
+
+// dateSectionQueueMaxQuota bounds the number of entries a single section adds to the reading queue.
+const dateSectionQueueMaxQuota = 50
+
+// dateSectionQueueQuota is the number of entries a section adds to the reading queue.
+type dateSectionQueueQuota struct {
+	section string
+	count   int
+}
+
+// CUSTOM: getDateSectionReadingQueue composes a reading queue of unread entries from the date view sections, with
+// up to the quota of each section given by the "quotas" query parameter, such as "today:3,last7d:2,earlier:1".
+// Each section picks its entries following the sort of the user. The queue then takes an entry from each section
+// in turn, in the order of the quotas, so the newest entries never crowd out the older ones.
+func (h *handler) getDateSectionReadingQueue(w http.ResponseWriter, r *http.Request) {
+	quotas, err := parseDateSectionQueueQuotas(request.QueryStringParam(r, "quotas", dateSectionQueueDefaultQuotas))
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	scheme, userTimezone, err := dateSectionsSchemeAndTimezone(r, user)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	sections := model.PublicationDateSections(user.DateSections(scheme, timezone.Now(userTimezone)))
+	picks := make([][]*dateSectionEntryResponse, 0, len(quotas))
+	for _, quota := range quotas {
+		section, found := model.FindDateSection(sections, quota.section)
+		if !found {
+			json.BadRequest(w, r, fmt.Errorf("unknown date section %q", quota.section))
+			return
+		}
+
+		if quota.count == 0 {
+			picks = append(picks, nil)
+			continue
+		}
+
+		builder := h.newDateSectionQueryBuilder(user.ID)
+		builder.WithEnclosures()
+		builder.WithinDateSection(section, user.DateViewUseLatestDate)
+		builder.WithStableSorting(user.EntryOrder, user.EntryDirection)
+		builder.WithLimit(quota.count)
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		label := section.DisplayLabel(printer.Printf)
+		sectionPicks := make([]*dateSectionEntryResponse, 0, len(entries))
+		for _, entry := range entries {
+			entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entry.Content)
+			sectionPicks = append(sectionPicks, &dateSectionEntryResponse{Entry: entry, Section: section.Name, SectionLabel: label})
+		}
+		picks = append(picks, sectionPicks)
+	}
+
+	queue := interleaveDateSectionQueue(picks)
+	json.OK(w, r, &dateSectionReadingQueueResponse{Total: len(queue), Entries: queue})
+}
+
+// parseDateSectionQueueQuotas parses comma separated "section:count" quotas. A section may only appear once,
+// and a count of 0 leaves the section out of the queue.
+func parseDateSectionQueueQuotas(value string) ([]dateSectionQueueQuota, error) {
+	var quotas []dateSectionQueueQuota
+	for item := range strings.SplitSeq(value, ",") {
+		section, rawCount, found := strings.Cut(strings.TrimSpace(item), ":")
+		if !found || section == "" {
+			return nil, fmt.Errorf(`invalid quota %q, expected "section:count"`, item)
+		}
+
+		count, err := strconv.Atoi(rawCount)
+		if err != nil || count < 0 || count > dateSectionQueueMaxQuota {
+			return nil, fmt.Errorf("the quota of the section %q must be between 0 and %d", section, dateSectionQueueMaxQuota)
+		}
+
+		for _, quota := range quotas {
+			if quota.section == section {
+				return nil, fmt.Errorf("the section %q has several quotas", section)
+			}
+		}
+
+		quotas = append(quotas, dateSectionQueueQuota{section: section, count: count})
+	}
+	return quotas, nil
+}
+
+// interleaveDateSectionQueue returns the entries picked from each section taking one of each section in turn,
+// until every section runs out of entries.
+func interleaveDateSectionQueue(picks [][]*dateSectionEntryResponse) []*dateSectionEntryResponse {
+	queue := make([]*dateSectionEntryResponse, 0)
+	for position := 0; ; position++ {
+		added := false
+		for _, sectionPicks := range picks {
+			if position < len(sectionPicks) {
+				queue = append(queue, sectionPicks[position])
+				added = true
+			}
+		}
+		if !added {
+			return queue
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"context"
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/model"
+
+	"github.com/gorilla/mux"
+)
+
+func TestDateSectionCountHeader(t *testing.T) {
+	for sectionName, expected := range map[string]string{
+		"today":     "X-Unread-Today",
+		"last2d":    "X-Unread-Last2d",
+		"last30d":   "X-Unread-Last30d",
+		"this_week": "X-Unread-This-Week",
+	} {
+		if result := dateSectionCountHeader(sectionName); result != expected {
+			t.Errorf(`Unexpected header for the %q section, got %q instead of %q`, sectionName, result, expected)
+		}
+	}
+}
+
+func TestDateSectionCountKeyDependsOnTheSectionSettings(t *testing.T) {
+	user := &model.User{Timezone: "UTC"}
+	key := dateSectionCountKey(user, model.DateSectionSchemeDefault, "UTC", "today")
+
+	if other := dateSectionCountKey(user, model.DateSectionSchemeDefault, "Asia/Tokyo", "today"); other == key {
+		t.Errorf(`Expected the counts of another timezone under another key, got %q for both`, key)
+	}
+
+	resetHourUser := &model.User{Timezone: "UTC"}
+	resetHourUser.SetDateViewDayResetHour(4)
+	if other := dateSectionCountKey(resetHourUser, model.DateSectionSchemeDefault, "UTC", "today"); other == key {
+		t.Errorf(`Expected the counts of another day reset hour under another key, got %q for both`, key)
+	}
+
+	latestDateUser := &model.User{Timezone: "UTC", DateViewUseLatestDate: true}
+	if other := dateSectionCountKey(latestDateUser, model.DateSectionSchemeDefault, "UTC", "today"); other == key {
+		t.Errorf(`Expected the counts by latest date under another key, got %q for both`, key)
+	}
+}
+
+func TestNewDateSectionJSONFeed(t *testing.T) {
+	entry := model.NewEntry()
+	entry.Hash = "abc"
+	entry.Title = "Entry"
+	entry.URL = "https://example.org/entry"
+	entry.Author = "Jane"
+	entry.Date = time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	entry.Enclosures = model.EnclosureList{{URL: "https://example.org/episode.mp3", MimeType: "audio/mpeg", Duration: 90}}
+
+	feed := newDateSectionJSONFeed("Miniflux: Today", "https://miniflux.example/entries/by-date?section=today", "https://miniflux.example/v1/entries/date-sections/feed.json?section=today", "pt_BR", model.Entries{entry})
+
+	if feed.Version != "https://jsonfeed.org/version/1.1" || feed.Language != "pt-BR" {
+		t.Errorf(`Unexpected version %q or language %q`, feed.Version, feed.Language)
+	}
+
+	if len(feed.Items) != 1 {
+		t.Fatalf(`Expected 1 item, got %d`, len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.ID != "abc" || item.URL != entry.URL || !item.DatePublished.Equal(entry.Date) {
+		t.Errorf(`Unexpected item %+v`, item)
+	}
+
+	if len(item.Authors) != 1 || item.Authors[0].Name != "Jane" {
+		t.Errorf(`Expected the entry author, got %+v`, item.Authors)
+	}
+
+	if len(item.Attachments) != 1 || item.Attachments[0].Duration != 90 {
+		t.Errorf(`Expected the enclosure as attachment, got %+v`, item.Attachments)
+	}
+}
+
+func TestNewDateSectionJSONFeedWithoutEntries(t *testing.T) {
+	feed := newDateSectionJSONFeed("Miniflux: Today", "", "", "en_US", model.Entries{})
+	if feed.Items == nil || len(feed.Items) != 0 {
+		t.Errorf(`Expected an empty list of items, got %+v`, feed.Items)
+	}
+}
+
+func TestDateSectionPages(t *testing.T) {
+	counts := []int{3, 0, 5, 2}
+
+	for _, testCase := range []struct {
+		offset   int
+		limit    int
+		expected []dateSectionPage
+	}{
+		{0, 2, []dateSectionPage{{0, 0, 2}}},
+		{0, 4, []dateSectionPage{{0, 0, 3}, {2, 0, 1}}},
+		{2, 7, []dateSectionPage{{0, 2, 1}, {2, 0, 5}, {3, 0, 1}}},
+		{3, 0, []dateSectionPage{{2, 0, 5}, {3, 0, 2}}},
+		{9, 10, []dateSectionPage{{3, 1, 1}}},
+		{10, 10, nil},
+	} {
+		if pages := dateSectionPages(counts, testCase.offset, testCase.limit); !reflect.DeepEqual(pages, testCase.expected) {
+			t.Errorf(`Unexpected pages at offset %d with limit %d, got %+v instead of %+v`, testCase.offset, testCase.limit, pages, testCase.expected)
+		}
+	}
+}
+
+func TestFlatDateSectionsResponseCarriesTheSectionOfEachEntry(t *testing.T) {
+	entry := model.NewEntry()
+	entry.ID = 42
+	entry.Title = "Entry"
+
+	response := &flatDateSectionsResponse{
+		dateSectionsResponse: &dateSectionsResponse{Total: 1, Sections: []*dateSectionResponse{{Name: "today", Count: 1}}},
+		Entries:              []*dateSectionEntryResponse{{Entry: entry, Section: "today", SectionLabel: "Today"}},
+	}
+
+	data, err := json_parser.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Total    int `json:"total"`
+		Sections []struct {
+			Name string `json:"name"`
+		} `json:"sections"`
+		Entries []struct {
+			ID           int64  `json:"id"`
+			Title        string `json:"title"`
+			Section      string `json:"section"`
+			SectionLabel string `json:"section_label"`
+		} `json:"entries"`
+	}
+	if err := json_parser.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Total != 1 || len(decoded.Sections) != 1 || decoded.Sections[0].Name != "today" {
+		t.Errorf(`Expected the sections next to the entries, got %s`, data)
+	}
+
+	if len(decoded.Entries) != 1 || decoded.Entries[0].ID != 42 || decoded.Entries[0].Title != "Entry" || decoded.Entries[0].Section != "today" || decoded.Entries[0].SectionLabel != "Today" {
+		t.Errorf(`Expected the entry fields along with its section, got %s`, data)
+	}
+}
+
+func TestGetDateSectionsIndexRequiresAdministrator(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/entries/date-sections/index", nil)
+	ctx := context.WithValue(r.Context(), request.UserIDContextKey, int64(1))
+	ctx = context.WithValue(ctx, request.IsAdminUserContextKey, false)
+	w := httptest.NewRecorder()
+
+	(&handler{}).getDateSectionsIndex(w, r.WithContext(ctx))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusForbidden)
+	}
+}
+
+func TestGetUserDateSectionsRequiresAdministrator(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/users/2/date-sections", nil)
+	r = mux.SetURLVars(r, map[string]string{"userID": "2"})
+	ctx := context.WithValue(r.Context(), request.UserIDContextKey, int64(1))
+	ctx = context.WithValue(ctx, request.IsAdminUserContextKey, false)
+	w := httptest.NewRecorder()
+
+	(&handler{}).getUserDateSections(w, r.WithContext(ctx))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusForbidden)
+	}
+}
+
+func TestGetUserDateSectionsRejectsUnknownScheme(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/users/2/date-sections?buckets=weekly", nil)
+	r = mux.SetURLVars(r, map[string]string{"userID": "2"})
+	ctx := context.WithValue(r.Context(), request.UserIDContextKey, int64(1))
+	ctx = context.WithValue(ctx, request.IsAdminUserContextKey, true)
+	w := httptest.NewRecorder()
+
+	(&handler{}).getUserDateSections(w, r.WithContext(ctx))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestMarkDateSectionReadUpToCursorRejectsInvalidCursor(t *testing.T) {
+	body := strings.NewReader(`{"section":"today","sort_value":"yesterday","id":42}`)
+	r := httptest.NewRequest(http.MethodPut, "/v1/entries/date-sections/mark-read-up-to", body)
+	w := httptest.NewRecorder()
+
+	// The cursor is validated before any database access
+	(&handler{}).markDateSectionReadUpToCursor(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestParseDateSectionQueueQuotas(t *testing.T) {
+	quotas, err := parseDateSectionQueueQuotas(dateSectionQueueDefaultQuotas)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := []dateSectionQueueQuota{{"today", 3}, {"last7d", 2}, {"earlier", 1}}
+	if !slices.Equal(quotas, expected) {
+		t.Errorf(`Unexpected quotas, got %v instead of %v`, quotas, expected)
+	}
+
+	for _, value := range []string{"", "today", ":3", "today:x", "today:-1", "today:51", "today:1,today:2"} {
+		if _, err := parseDateSectionQueueQuotas(value); err == nil {
+			t.Errorf(`The quotas %q should be rejected`, value)
+		}
+	}
+}
+
+func TestInterleaveDateSectionQueue(t *testing.T) {
+	pick := func(id int64, section string) *dateSectionEntryResponse {
+		return &dateSectionEntryResponse{Entry: &model.Entry{ID: id}, Section: section}
+	}
+
+	queue := interleaveDateSectionQueue([][]*dateSectionEntryResponse{
+		{pick(1, "today"), pick(2, "today"), pick(3, "today")},
+		nil,
+		{pick(4, "last7d"), pick(5, "last7d")},
+		{pick(6, "earlier")},
+	})
+
+	var entryIDs []int64
+	for _, entry := range queue {
+		entryIDs = append(entryIDs, entry.ID)
+	}
+
+	if expected := []int64{1, 4, 6, 2, 5, 3}; !slices.Equal(entryIDs, expected) {
+		t.Errorf(`Unexpected queue order, got %v instead of %v`, entryIDs, expected)
+	}
+}
+
+func TestGetDateSectionReadingQueueRejectsInvalidQuotas(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/entries/date-sections/reading-queue?quotas=today:1000", nil)
+	w := httptest.NewRecorder()
+
+	// The quotas are validated before any database access
+	(&handler{}).getDateSectionReadingQueue(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusBadRequest)
+	}
+}
@@ -115,6 +115,21 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 		return
 	}
 
+	// CUSTOM: deep pages are listed after the cursor of the previous page rather than after an offset
+	var cursor *model.EntryCursor
+	if token := request.QueryStringParam(r, "cursor", ""); token != "" {
+		if offset > 0 {
+			json.BadRequest(w, r, errors.New("the cursor and offset parameters cannot be combined"))
+			return
+		}
+
+		var err error
+		if cursor, err = model.ParseEntryCursor(token); err != nil {
+			json.BadRequest(w, r, err)
+			return
+		}
+	}
+
 	userID := request.UserID(r)
 	categoryID = request.QueryInt64Param(r, "category_id", categoryID)
 	if categoryID > 0 && !h.store.CategoryIDExists(userID, categoryID) {
@@ -134,7 +149,7 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	builder.WithFeedID(feedID)
 	builder.WithCategoryID(categoryID)
 	builder.WithStatuses(statuses)
-	builder.WithSorting(order, direction)
+	builder.WithStableSorting(order, direction)
 	builder.WithOffset(offset)
 	builder.WithLimit(limit)
 	builder.WithTags(tags)
@@ -151,13 +166,18 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 
 	configureFilters(builder, r)
 
-	entries, err := builder.GetEntries()
+	// The total also counts the entries before the cursor
+	count, err := builder.CountEntries()
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
-	count, err := builder.CountEntries()
+	if cursor != nil {
+		builder.AfterCursor(cursor, order, direction)
+	}
+
+	entries, err := builder.GetEntries()
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
@@ -167,7 +187,12 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 		entries[i].Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entries[i].Content)
 	}
 
-	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
+	response := &entriesResponse{Total: count, Entries: entries}
+	if limit > 0 && len(entries) == limit {
+		response.NextCursor = model.NewEntryCursor(entries[len(entries)-1], order, false, false).Token()
+	}
+
+	json.OK(w, r, response)
 }
 
 func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
@@ -190,6 +215,40 @@ func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
 	json.NoContent(w, r)
 }
 
+// CUSTOM: setEntryDisplayDate overrides the date placing the entry in the date sections, a null date clears the override.
+func (h *handler) setEntryDisplayDate(w http.ResponseWriter, r *http.Request) {
+	var displayDateRequest model.EntryDisplayDateRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&displayDateRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.SetEntryDisplayDateOverride(userID, entryID, displayDateRequest.DisplayDate); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
 func (h *handler) toggleStarred(w http.ResponseWriter, r *http.Request) {
 	entryID := request.RouteInt64Param(r, "entryID")
 	if err := h.store.ToggleStarred(request.UserID(r), entryID); err != nil {
@@ -381,6 +440,15 @@ func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 		builder.AfterPublishedDate(time.Unix(afterPublishedTimestamp, 0))
 	}
 
+	// CUSTOM: the display date places entries in the date sections, see setEntryDisplayDate
+	if beforeDisplayTimestamp := request.QueryInt64Param(r, "display_date_before", 0); beforeDisplayTimestamp > 0 {
+		builder.BeforeDisplayDate(time.Unix(beforeDisplayTimestamp, 0))
+	}
+
+	if afterDisplayTimestamp := request.QueryInt64Param(r, "display_date_after", 0); afterDisplayTimestamp > 0 {
+		builder.AfterDisplayDate(time.Unix(afterDisplayTimestamp, 0))
+	}
+
 	if beforeChangedTimestamp := request.QueryInt64Param(r, "changed_before", 0); beforeChangedTimestamp > 0 {
 		builder.BeforeChangedDate(time.Unix(beforeChangedTimestamp, 0))
 	}
@@ -5,6 +5,7 @@ package api // import "miniflux.app/v2/internal/api"
 
 import (
 	json_parser "encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -41,6 +42,7 @@ func (h *handler) createFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	feedCreationRequest.Source = model.FeedSourceAPI
 	feed, localizedError := feedHandler.CreateFeed(h.store, userID, &feedCreationRequest)
 	if localizedError != nil {
 		json.ServerError(w, r, localizedError.Error())
@@ -198,6 +200,25 @@ func (h *handler) fetchCounters(w http.ResponseWriter, r *http.Request) {
 	json.OK(w, r, counters)
 }
 
+// CUSTOM: getBacklogFeeds lists the feeds having the most unread entries older than "older_than_days" days.
+func (h *handler) getBacklogFeeds(w http.ResponseWriter, r *http.Request) {
+	olderThanDays := request.QueryIntParam(r, "older_than_days", 7)
+	limit := request.QueryIntParam(r, "limit", 10)
+	if limit < 1 || limit > 100 {
+		json.BadRequest(w, r, errors.New("limit must be between 1 and 100"))
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
+	counts, err := h.store.TopFeedsByUnreadOlderThan(request.UserID(r), cutoff, limit)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, counts)
+}
+
 func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	feed, err := h.store.FeedByID(request.UserID(r), feedID)
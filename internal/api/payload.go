@@ -4,6 +4,8 @@
 package api // import "miniflux.app/v2/internal/api"
 
 import (
+	"time"
+
 	"miniflux.app/v2/internal/model"
 )
 
@@ -16,6 +18,102 @@ type feedIconResponse struct {
 type entriesResponse struct {
 	Total   int           `json:"total"`
 	Entries model.Entries `json:"entries"`
+
+	// CUSTOM: NextCursor is the cursor token of the next page, set when a full page was returned.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type dateSectionResponse struct {
+	Name            string     `json:"name"`
+	Label           string     `json:"label"`
+	Count           int        `json:"count"`
+	PublishedAfter  *time.Time `json:"published_after"`
+	PublishedBefore *time.Time `json:"published_before"`
+	EntriesURL      string     `json:"entries_url"`
+	PageURL         string     `json:"page_url"`
+}
+
+type dateSectionsResponse struct {
+	Total    int                    `json:"total"`
+	Sections []*dateSectionResponse `json:"sections"`
+}
+
+// CUSTOM: userDateSectionsResponse holds the date sections of another user, as seen by an administrator.
+type userDateSectionsResponse struct {
+	UserID   int64                      `json:"user_id"`
+	Timezone string                     `json:"timezone"`
+	Total    int                        `json:"total"`
+	Sections []*userDateSectionResponse `json:"sections"`
+}
+
+// userDateSectionResponse is a date section of another user, without the URLs only the user can follow.
+type userDateSectionResponse struct {
+	Name            string     `json:"name"`
+	Label           string     `json:"label"`
+	Count           int        `json:"count"`
+	PublishedAfter  *time.Time `json:"published_after"`
+	PublishedBefore *time.Time `json:"published_before"`
+}
+
+// CUSTOM: flatDateSectionsResponse lists the entries of every section in a single list, in section order,
+// for clients scrolling through all the sections with sticky section headers.
+type flatDateSectionsResponse struct {
+	*dateSectionsResponse
+	Entries []*dateSectionEntryResponse `json:"entries"`
+}
+
+// dateSectionEntryResponse is an entry along with the name and label of the section holding it.
+type dateSectionEntryResponse struct {
+	*model.Entry
+	Section      string `json:"section"`
+	SectionLabel string `json:"section_label"`
+}
+
+// CUSTOM: dateSectionReadingQueueResponse is a reading queue mixing the entries of several date sections.
+type dateSectionReadingQueueResponse struct {
+	Total   int                         `json:"total"`
+	Entries []*dateSectionEntryResponse `json:"entries"`
+}
+
+// CUSTOM: dateSectionLatestEntriesResponse maps the name of each date section to its newest unread entry, or nil.
+type dateSectionLatestEntriesResponse map[string]*dateSectionEntryResponse
+
+type hourOfDayEntryCountsResponse struct {
+	Days   int     `json:"days"`
+	Counts [24]int `json:"counts"`
+}
+
+// CUSTOM: dateSectionJSONFeed is the JSON Feed version 1.1 document of a date section, see https://www.jsonfeed.org/version/1.1/
+type dateSectionJSONFeed struct {
+	Version     string                     `json:"version"`
+	Title       string                     `json:"title"`
+	HomePageURL string                     `json:"home_page_url"`
+	FeedURL     string                     `json:"feed_url"`
+	Language    string                     `json:"language,omitempty"`
+	Items       []*dateSectionJSONFeedItem `json:"items"`
+}
+
+type dateSectionJSONFeedItem struct {
+	ID            string                           `json:"id"`
+	URL           string                           `json:"url,omitempty"`
+	ExternalURL   string                           `json:"external_url,omitempty"`
+	Title         string                           `json:"title"`
+	ContentHTML   string                           `json:"content_html"`
+	DatePublished time.Time                        `json:"date_published"`
+	Authors       []*dateSectionJSONFeedAuthor     `json:"authors,omitempty"`
+	Tags          []string                         `json:"tags,omitempty"`
+	Attachments   []*dateSectionJSONFeedAttachment `json:"attachments,omitempty"`
+}
+
+type dateSectionJSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type dateSectionJSONFeedAttachment struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size_in_bytes,omitempty"`
+	Duration int64  `json:"duration_in_seconds,omitempty"`
 }
 
 type feedCreationResponse struct {
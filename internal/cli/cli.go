@@ -35,6 +35,8 @@ const (
 	flagRunCleanupTasksHelp  = "Run cleanup tasks (delete old sessions and archives old entries)"
 	flagExportUserFeedsHelp  = "Export user feeds (provide the username as argument)"
 	flagResetNextCheckAtHelp = "Reset the next check time for all feeds"
+	flagMarkReadBeforeHelp   = "Mark unread entries published before the given date as read (provide the username with -user)"
+	flagUserHelp             = "Username the -mark-read-before command applies to"
 )
 
 // Parse parses command line arguments.
@@ -56,6 +58,8 @@ func Parse() {
 		flagRefreshFeeds         bool
 		flagRunCleanupTasks      bool
 		flagExportUserFeeds      string
+		flagMarkReadBefore       string
+		flagUser                 string
 	)
 
 	flag.BoolVar(&flagInfo, "info", false, flagInfoHelp)
@@ -76,6 +80,8 @@ func Parse() {
 	flag.BoolVar(&flagRefreshFeeds, "refresh-feeds", false, flagRefreshFeedsHelp)
 	flag.BoolVar(&flagRunCleanupTasks, "run-cleanup-tasks", false, flagRunCleanupTasksHelp)
 	flag.StringVar(&flagExportUserFeeds, "export-user-feeds", "", flagExportUserFeedsHelp)
+	flag.StringVar(&flagMarkReadBefore, "mark-read-before", "", flagMarkReadBeforeHelp)
+	flag.StringVar(&flagUser, "user", "", flagUserHelp)
 	flag.Parse()
 
 	cfg := config.NewConfigParser()
@@ -181,6 +187,11 @@ func Parse() {
 
 	store := storage.NewStorage(db)
 
+	// CUSTOM: cache the date view section counts for a short time
+	if config.Opts.DateViewCountCache() {
+		store.EnableDateSectionCountCache()
+	}
+
 	if err := store.Ping(); err != nil {
 		printErrorAndExit(err)
 	}
@@ -211,6 +222,11 @@ func Parse() {
 		return
 	}
 
+	if flagMarkReadBefore != "" {
+		markEntriesReadBefore(store, flagUser, flagMarkReadBefore)
+		return
+	}
+
 	if flagFlushSessions {
 		flushSessions(store)
 		return
@@ -237,6 +253,15 @@ func Parse() {
 		printErrorAndExit(err)
 	}
 
+	// CUSTOM: the date view sections are counted with the index over (user_id, status, display_date)
+	if exists, err := store.DateViewIndexExists(); err != nil {
+		slog.Warn("Unable to check the date view index", slog.Any("error", err))
+	} else if !exists {
+		slog.Warn("The index used by the date view is missing, counting the date sections may be slow",
+			slog.String("index", storage.DateViewIndexName),
+		)
+	}
+
 	if config.Opts.CreateAdmin() {
 		createAdminUserFromEnvironmentVariables(store)
 	}
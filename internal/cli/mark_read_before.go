@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// CUSTOM: markEntriesReadBefore marks as read the unread entries of a user published before the given date,
+// which is either a day in the user timezone (2006-01-02) or an RFC 3339 timestamp.
+// Like the date view, it leaves the entries of feeds and categories hidden from the unread list alone.
+func markEntriesReadBefore(store *storage.Storage, username, value string) {
+	if username == "" {
+		printErrorAndExit(errors.New("the -user argument is required with -mark-read-before"))
+	}
+
+	user, err := store.UserByUsername(username)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to find user: %w", err))
+	}
+
+	if user == nil {
+		printErrorAndExit(fmt.Errorf("user %q not found", username))
+	}
+
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to load the timezone of user %q: %w", username, err))
+	}
+
+	before, err := time.ParseInLocation("2006-01-02", value, location)
+	if err != nil {
+		if before, err = time.Parse(time.RFC3339, value); err != nil {
+			printErrorAndExit(fmt.Errorf(`invalid date %q, expected a day like "2006-01-02" or an RFC 3339 timestamp`, value))
+		}
+	}
+
+	count, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{
+		Status:          model.EntryStatusRead,
+		Before:          &before,
+		IncludeDisabled: true,
+	})
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	fmt.Printf("%d unread entries of %q published before %s have been marked as read\n", count, username, before.Format(time.RFC3339))
+}
@@ -194,6 +194,35 @@ func NewConfigOptions() *configOptions {
 				ValueType:         secretFileType,
 				TargetKey:         "DATABASE_URL",
 			},
+			"DATE_VIEW_COUNT_CACHE": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
+			"DATE_VIEW_LINKED_ACCOUNTS": {
+				ParsedStringList: []string{},
+				RawValue:         "",
+				ValueType:        stringListType,
+				Validator: func(rawValue string) error {
+					return validateUserIDPairs(strings.Split(rawValue, ","))
+				},
+			},
+			"DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL": {
+				ParsedIntValue: 50,
+				RawValue:       "50",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"DATE_VIEW_MAX_CONCURRENT_LOADS": {
+				ParsedIntValue: 2,
+				RawValue:       "2",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
 			"DISABLE_HSTS": {
 				ParsedBoolValue: false,
 				RawValue:        "0",
@@ -667,6 +696,33 @@ func (c *configOptions) DatabaseURL() string {
 	return c.options["DATABASE_URL"].ParsedStringValue
 }
 
+// CUSTOM: DateViewCountCache returns true if the date view section counts are cached in memory.
+func (c *configOptions) DateViewCountCache() bool {
+	return c.options["DATE_VIEW_COUNT_CACHE"].ParsedBoolValue
+}
+
+// CUSTOM: DateViewLinkedAccount returns the user whose matching entries are also marked as read when the given user
+// marks a date section as read, from the "user_id:linked_user_id" pairs of DATE_VIEW_LINKED_ACCOUNTS.
+func (c *configOptions) DateViewLinkedAccount(userID int64) (int64, bool) {
+	for _, pair := range c.options["DATE_VIEW_LINKED_ACCOUNTS"].ParsedStringList {
+		sourceUserID, linkedUserID, err := parseUserIDPair(pair)
+		if err == nil && sourceUserID == userID {
+			return linkedUserID, true
+		}
+	}
+	return 0, false
+}
+
+// CUSTOM: DateViewMaxEntriesPerSectionInAll returns the maximum number of entries listed for each section of the "all" date view.
+func (c *configOptions) DateViewMaxEntriesPerSectionInAll() int {
+	return c.options["DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL"].ParsedIntValue
+}
+
+// CUSTOM: DateViewMaxConcurrentLoads returns the maximum number of date view pages loaded at once for each user, 0 for no limit.
+func (c *configOptions) DateViewMaxConcurrentLoads() int {
+	return c.options["DATE_VIEW_MAX_CONCURRENT_LOADS"].ParsedIntValue
+}
+
 func (c *configOptions) DisableHSTS() bool {
 	return c.options["DISABLE_HSTS"].ParsedBoolValue
 }
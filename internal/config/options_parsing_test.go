@@ -369,6 +369,93 @@ func TestDatabaseURLOptionParsing(t *testing.T) {
 	}
 }
 
+func TestDateViewCountCacheOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.DateViewCountCache() {
+		t.Fatalf("Expected DATE_VIEW_COUNT_CACHE to be disabled by default")
+	}
+
+	if err := configParser.parseLines([]string{"DATE_VIEW_COUNT_CACHE=1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !configParser.options.DateViewCountCache() {
+		t.Fatalf("Expected DATE_VIEW_COUNT_CACHE to be enabled")
+	}
+}
+
+func TestDateViewMaxEntriesPerSectionInAllOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.DateViewMaxEntriesPerSectionInAll() != 50 {
+		t.Fatalf("Expected DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL to be 50 by default")
+	}
+
+	if err := configParser.parseLines([]string{"DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL=20"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.DateViewMaxEntriesPerSectionInAll() != 20 {
+		t.Fatalf("Expected DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL to be 20")
+	}
+
+	if err := configParser.parseLines([]string{"DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL=0"}); err == nil {
+		t.Fatal("Expected an error for DATE_VIEW_MAX_ENTRIES_PER_SECTION_IN_ALL=0")
+	}
+}
+
+func TestDateViewMaxConcurrentLoadsOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.DateViewMaxConcurrentLoads() != 2 {
+		t.Fatalf("Expected DATE_VIEW_MAX_CONCURRENT_LOADS to be 2 by default")
+	}
+
+	if err := configParser.parseLines([]string{"DATE_VIEW_MAX_CONCURRENT_LOADS=0"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.DateViewMaxConcurrentLoads() != 0 {
+		t.Fatalf("Expected DATE_VIEW_MAX_CONCURRENT_LOADS to be 0")
+	}
+
+	if err := configParser.parseLines([]string{"DATE_VIEW_MAX_CONCURRENT_LOADS=-1"}); err == nil {
+		t.Fatal("Expected an error for DATE_VIEW_MAX_CONCURRENT_LOADS=-1")
+	}
+}
+
+func TestDateViewLinkedAccountsOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if _, found := configParser.options.DateViewLinkedAccount(1); found {
+		t.Fatalf("Expected DATE_VIEW_LINKED_ACCOUNTS to link no account by default")
+	}
+
+	if err := configParser.parseLines([]string{"DATE_VIEW_LINKED_ACCOUNTS=1:2, 3:1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if linkedUserID, found := configParser.options.DateViewLinkedAccount(1); !found || linkedUserID != 2 {
+		t.Fatalf("Expected the user 1 to be linked to the user 2, got %d", linkedUserID)
+	}
+
+	if linkedUserID, found := configParser.options.DateViewLinkedAccount(3); !found || linkedUserID != 1 {
+		t.Fatalf("Expected the user 3 to be linked to the user 1, got %d", linkedUserID)
+	}
+
+	// Links are only followed in the given direction
+	if _, found := configParser.options.DateViewLinkedAccount(2); found {
+		t.Fatalf("Expected the user 2 to be linked to no account")
+	}
+
+	for _, value := range []string{"1", "1:1", "a:2", "1:0"} {
+		if err := configParser.parseLines([]string{"DATE_VIEW_LINKED_ACCOUNTS=" + value}); err == nil {
+			t.Fatalf("Expected an error for DATE_VIEW_LINKED_ACCOUNTS=%s", value)
+		}
+	}
+}
+
 func TestDisableHSTSOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
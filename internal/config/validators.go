@@ -59,3 +59,39 @@ func validateRange(rawValue string, min, max int) error {
 	}
 	return nil
 }
+
+// CUSTOM: validateUserIDPairs checks that each non-empty value is a "user_id:linked_user_id" pair of two different users.
+func validateUserIDPairs(inputValues []string) error {
+	for _, value := range inputValues {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		if _, _, err := parseUserIDPair(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseUserIDPair(value string) (int64, int64, error) {
+	source, linked, found := strings.Cut(value, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("value %q must be a pair of user IDs such as 1:2", value)
+	}
+
+	sourceUserID, err := strconv.ParseInt(strings.TrimSpace(source), 10, 64)
+	if err != nil || sourceUserID < 1 {
+		return 0, 0, fmt.Errorf("value %q must start with a valid user ID", value)
+	}
+
+	linkedUserID, err := strconv.ParseInt(strings.TrimSpace(linked), 10, 64)
+	if err != nil || linkedUserID < 1 {
+		return 0, 0, fmt.Errorf("value %q must end with a valid user ID", value)
+	}
+
+	if sourceUserID == linkedUserID {
+		return 0, 0, fmt.Errorf("value %q must link two different users", value)
+	}
+
+	return sourceUserID, linkedUserID, nil
+}
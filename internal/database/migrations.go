@@ -1373,4 +1373,210 @@ var migrations = [...]func(tx *sql.Tx) error{
 		_, err = tx.Exec(sql)
 		return err
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN updated_at timestamp with time zone;
+			ALTER TABLE users ADD COLUMN date_view_use_latest_date bool default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN last_opened_at timestamp with time zone`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE entries ADD COLUMN language text not null default ''`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE users ADD COLUMN date_view_collapsed_sections jsonb not null default '[]'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN priority bool not null default 'f'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE entries ADD COLUMN opened_at timestamp with time zone`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// Entries stored without a publication date got the zero time, use their creation date instead
+		_, err = tx.Exec(`UPDATE entries SET published_at=created_at WHERE published_at < '0002-01-01'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN pinned bool not null default 'f'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN read_progress real not null default 0;
+			ALTER TABLE entries ADD COLUMN read_progress_updated_at timestamp with time zone;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE users ADD COLUMN date_view_exclude_seen bool default 'f'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN display_date_override timestamp with time zone;
+			CREATE INDEX entries_user_status_display_date_idx ON entries(user_id, status, (COALESCE(display_date_override, published_at)));
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_tags (
+				feed_id bigint not null references feeds(id) on delete cascade,
+				tag text not null,
+				primary key(feed_id, tag)
+			);
+			CREATE INDEX feed_tags_tag_idx ON feed_tags(tag);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN source text not null default ''`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE categories ADD COLUMN share_code text not null default '';
+			CREATE UNIQUE INDEX categories_share_code_idx ON categories(share_code) WHERE share_code <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE users ADD COLUMN date_view_day_reset_hour int`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_labels (
+				entry_id bigint not null references entries(id) on delete cascade,
+				label text not null,
+				primary key(entry_id, label)
+			);
+			CREATE INDEX entry_labels_label_idx ON entry_labels(label);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN starred_at timestamp with time zone;
+			UPDATE entries SET starred_at = changed_at WHERE starred;
+			CREATE INDEX entries_user_starred_at_idx ON entries(user_id, starred_at) WHERE starred;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE enclosures ADD COLUMN duration int not null default 0`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE bulk_status_updates (
+				id bigserial not null,
+				user_id int not null references users(id) on delete cascade,
+				section text not null,
+				status entry_status not null,
+				entry_count int not null,
+				created_at timestamp with time zone not null default now(),
+				primary key(id)
+			);
+			CREATE INDEX bulk_status_updates_user_created_at_idx ON bulk_status_updates(user_id, created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN age_basis text not null default 'published'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE bulk_status_updates ADD COLUMN reason text not null default ''`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE inbox_zero_days (
+				user_id int not null references users(id) on delete cascade,
+				day date not null,
+				primary key(user_id, day)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE entries ADD COLUMN scrape_status text not null default ''`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE users ADD COLUMN date_view_section_definitions jsonb not null default '[]'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// Existing feeds are dated by their oldest entry rather than by the migration, so they are not all new
+		sql := `
+			ALTER TABLE feeds ADD COLUMN created_at timestamp with time zone;
+			UPDATE feeds SET created_at = COALESCE((SELECT min(e.created_at) FROM entries e WHERE e.feed_id = feeds.id), checked_at, now());
+			ALTER TABLE feeds ALTER COLUMN created_at SET DEFAULT now();
+			ALTER TABLE feeds ALTER COLUMN created_at SET NOT NULL;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// Entries removed before the trash existed have no removal date and stay out of it
+		sql := `
+			ALTER TABLE entries ADD COLUMN removed_at timestamp with time zone;
+			CREATE INDEX entries_user_removed_at_idx ON entries(user_id, removed_at) WHERE status = 'removed';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE users ADD COLUMN date_view_blocked_domains jsonb not null default '[]'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN read_at timestamp with time zone;
+			UPDATE entries SET read_at = changed_at WHERE status = 'read';
+			CREATE INDEX entries_user_read_at_idx ON entries(user_id, read_at) WHERE status = 'read';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The display date depends on the age basis of the feed, which an expression index on entries cannot read
+		sql := `
+			ALTER TABLE entries ADD COLUMN display_date timestamp with time zone;
+			UPDATE entries e SET display_date = COALESCE(
+				e.display_date_override,
+				CASE f.age_basis WHEN 'created' THEN e.created_at WHEN 'updated' THEN GREATEST(e.published_at, e.updated_at) ELSE e.published_at END
+			) FROM feeds f WHERE f.id = e.feed_id;
+			ALTER TABLE entries ALTER COLUMN display_date SET NOT NULL;
+			DROP INDEX IF EXISTS entries_user_status_display_date_idx;
+			CREATE INDEX entries_user_status_display_date_idx ON entries(user_id, status, display_date);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The entries settled before now were never tracked as opened, their read ratio would be zero
+		_, err = tx.Exec(`ALTER TABLE users ADD COLUMN entry_opened_tracked_since timestamp with time zone not null default now()`)
+		return err
+	},
 }
@@ -490,6 +490,7 @@ func subscribe(newFeed Stream, category Stream, title string, store *storage.Sto
 	feedRequest := model.FeedCreationRequest{
 		FeedURL:    newFeed.ID,
 		CategoryID: destCategory.ID,
+		Source:     model.FeedSourceAPI,
 	}
 	verr := validator.ValidateFeedCreation(store, userID, &feedRequest)
 	if verr != nil {
@@ -6,6 +6,7 @@ package request // import "miniflux.app/v2/internal/http/request"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"miniflux.app/v2/internal/model"
@@ -31,6 +32,8 @@ const (
 	FlashMessageContextKey
 	FlashErrorMessageContextKey
 	LastForceRefreshContextKey
+	LastDateViewLoadedAtContextKey
+	DateViewSeenEntryIDsContextKey
 	ClientIPContextKey
 	GoogleReaderTokenKey
 	WebAuthnDataContextKey
@@ -145,6 +148,33 @@ func LastForceRefresh(r *http.Request) time.Time {
 	return time.Unix(timestamp, 0)
 }
 
+// LastDateViewLoadedAt returns the timestamp of the last date entries page load.
+func LastDateViewLoadedAt(r *http.Request) time.Time {
+	jsonStringValue := getContextStringValue(r, LastDateViewLoadedAtContextKey)
+	timestamp, err := strconv.ParseInt(jsonStringValue, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(timestamp, 0)
+}
+
+// DateViewSeenEntryIDs returns the IDs of the entries the date entries page showed in the newest section
+// during the previous loads of the session.
+func DateViewSeenEntryIDs(r *http.Request) []int64 {
+	jsonStringValue := getContextStringValue(r, DateViewSeenEntryIDsContextKey)
+	if jsonStringValue == "" {
+		return nil
+	}
+
+	var entryIDs []int64
+	for _, value := range strings.Split(jsonStringValue, ",") {
+		if entryID, err := strconv.ParseInt(value, 10, 64); err == nil {
+			entryIDs = append(entryIDs, entryID)
+		}
+	}
+	return entryIDs
+}
+
 // ClientIP returns the client IP address stored in the context.
 func ClientIP(r *http.Request) string {
 	return getContextStringValue(r, ClientIPContextKey)
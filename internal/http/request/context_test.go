@@ -7,6 +7,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestContextStringValue(t *testing.T) {
@@ -390,6 +391,42 @@ func TestFlashErrorMessage(t *testing.T) {
 	}
 }
 
+func TestLastDateViewLoadedAt(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.org", nil)
+
+	if result := LastDateViewLoadedAt(r); !result.IsZero() {
+		t.Errorf(`Unexpected context value, got %v instead of the zero time`, result)
+	}
+
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, LastDateViewLoadedAtContextKey, "1700000000")
+	r = r.WithContext(ctx)
+
+	result := LastDateViewLoadedAt(r)
+	expected := time.Unix(1700000000, 0)
+
+	if !result.Equal(expected) {
+		t.Errorf(`Unexpected context value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDateViewSeenEntryIDs(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.org", nil)
+
+	if result := DateViewSeenEntryIDs(r); len(result) != 0 {
+		t.Errorf(`Unexpected context value, got %v instead of no entry`, result)
+	}
+
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, DateViewSeenEntryIDsContextKey, "3,invalid,42")
+	r = r.WithContext(ctx)
+
+	result := DateViewSeenEntryIDs(r)
+	if len(result) != 2 || result[0] != 3 || result[1] != 42 {
+		t.Errorf(`Unexpected context value, got %v instead of [3 42]`, result)
+	}
+}
+
 func TestClientIP(t *testing.T) {
 	r, _ := http.NewRequest("GET", "http://example.org", nil)
 
@@ -107,6 +107,11 @@ func (b *Builder) writeHeaders() {
 }
 
 func (b *Builder) compress(data []byte) {
+	if b.enableCompression {
+		// CUSTOM: the body encoding depends on the request, caches must not serve it to other clients as is.
+		b.headers["Vary"] = "Accept-Encoding"
+	}
+
 	if b.enableCompression && len(data) > compressionThreshold {
 		acceptEncoding := b.r.Header.Get("Accept-Encoding")
 		switch {
@@ -7,6 +7,7 @@ import (
 	"html"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
@@ -113,6 +114,29 @@ func NotFound(w http.ResponseWriter, r *http.Request) {
 	builder.Write()
 }
 
+// CUSTOM: TooManyRequests sends a too many requests error to the client, asking it to retry after the given number of seconds.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, retryAfterSeconds int) {
+	slog.Warn(http.StatusText(http.StatusTooManyRequests),
+		slog.String("client_ip", request.ClientIP(r)),
+		slog.Group("request",
+			slog.String("method", r.Method),
+			slog.String("uri", r.RequestURI),
+			slog.String("user_agent", r.UserAgent()),
+		),
+		slog.Group("response",
+			slog.Int("status_code", http.StatusTooManyRequests),
+		),
+	)
+
+	builder := response.New(w, r)
+	builder.WithStatus(http.StatusTooManyRequests)
+	builder.WithHeader("Content-Type", "text/html; charset=utf-8")
+	builder.WithHeader("Cache-Control", "no-cache, max-age=0, must-revalidate, no-store")
+	builder.WithHeader("Retry-After", strconv.Itoa(retryAfterSeconds))
+	builder.WithBody("Too Many Requests")
+	builder.Write()
+}
+
 // Redirect redirects the user to another location.
 func Redirect(w http.ResponseWriter, r *http.Request, uri string) {
 	http.Redirect(w, r, uri, http.StatusFound)
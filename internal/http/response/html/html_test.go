@@ -4,9 +4,12 @@
 package html // import "miniflux.app/v2/internal/http/response/html"
 
 import (
+	"compress/gzip"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -49,6 +52,46 @@ func TestOKResponse(t *testing.T) {
 	}
 }
 
+func TestOKResponseWithGzipCompression(t *testing.T) {
+	r, err := http.NewRequest("GET", "/entries/by-date?section=all", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	// A date section with many entries, well above the compression threshold.
+	body := strings.Repeat(`<article class="item entry-item item-status-unread"><h3 class="item-title">Entry</h3></article>`, 500)
+
+	w := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		OK(w, r, body)
+	})
+
+	handler.ServeHTTP(w, r)
+	resp := w.Result()
+
+	expectedEncoding := "gzip"
+	actualEncoding := resp.Header.Get("Content-Encoding")
+	if actualEncoding != expectedEncoding {
+		t.Fatalf(`Unexpected header value, got %q instead of %q`, actualEncoding, expectedEncoding)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decompressedBody, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(decompressedBody) != body {
+		t.Fatalf(`Unexpected decompressed body of %d bytes, expected %d bytes`, len(decompressedBody), len(body))
+	}
+}
+
 func TestServerErrorResponse(t *testing.T) {
 	r, err := http.NewRequest("GET", "/", nil)
 	if err != nil {
@@ -238,3 +281,32 @@ func TestRequestedRangeNotSatisfiable(t *testing.T) {
 		t.Fatalf(`Unexpected content range header, got %q instead of %q`, actualContentRangeHeader, expectedContentRangeHeader)
 	}
 }
+
+func TestTooManyRequestsResponse(t *testing.T) {
+	r, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TooManyRequests(w, r, 5)
+	})
+
+	handler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	expectedStatusCode := http.StatusTooManyRequests
+	if resp.StatusCode != expectedStatusCode {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, expectedStatusCode)
+	}
+
+	expectedRetryAfterHeader := "5"
+	actualRetryAfterHeader := resp.Header.Get("Retry-After")
+	if actualRetryAfterHeader != expectedRetryAfterHeader {
+		t.Fatalf(`Unexpected retry after header, got %q instead of %q`, actualRetryAfterHeader, expectedRetryAfterHeader)
+	}
+}
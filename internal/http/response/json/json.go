@@ -61,6 +61,11 @@ func Accepted(w http.ResponseWriter, r *http.Request) {
 
 // ServerError sends an internal error to the client.
 func ServerError(w http.ResponseWriter, r *http.Request, err error) {
+	ServerErrorWithCode(w, r, "", err)
+}
+
+// ServerErrorWithCode sends an internal error with a machine-readable error code to the client.
+func ServerErrorWithCode(w http.ResponseWriter, r *http.Request, errorCode string, err error) {
 	slog.Error(http.StatusText(http.StatusInternalServerError),
 		slog.Any("error", err),
 		slog.String("client_ip", request.ClientIP(r)),
@@ -74,7 +79,7 @@ func ServerError(w http.ResponseWriter, r *http.Request, err error) {
 		),
 	)
 
-	responseBody, jsonErr := generateJSONError(err)
+	responseBody, jsonErr := generateJSONErrorWithCode(errorCode, err)
 	if jsonErr != nil {
 		slog.Error("Unable to generate JSON error", slog.Any("error", jsonErr))
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -90,6 +95,11 @@ func ServerError(w http.ResponseWriter, r *http.Request, err error) {
 
 // BadRequest sends a bad request error to the client.
 func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
+	BadRequestWithCode(w, r, "", err)
+}
+
+// BadRequestWithCode sends a bad request error with a machine-readable error code to the client.
+func BadRequestWithCode(w http.ResponseWriter, r *http.Request, errorCode string, err error) {
 	slog.Warn(http.StatusText(http.StatusBadRequest),
 		slog.Any("error", err),
 		slog.String("client_ip", request.ClientIP(r)),
@@ -103,7 +113,7 @@ func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
 		),
 	)
 
-	responseBody, jsonErr := generateJSONError(err)
+	responseBody, jsonErr := generateJSONErrorWithCode(errorCode, err)
 	if jsonErr != nil {
 		slog.Error("Unable to generate JSON error", slog.Any("error", jsonErr))
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -202,11 +212,16 @@ func NotFound(w http.ResponseWriter, r *http.Request) {
 }
 
 func generateJSONError(err error) ([]byte, error) {
+	return generateJSONErrorWithCode("", err)
+}
+
+func generateJSONErrorWithCode(errorCode string, err error) ([]byte, error) {
 	type errorMsg struct {
 		ErrorMessage string `json:"error_message"`
+		ErrorCode    string `json:"error_code,omitempty"`
 	}
 
-	encodedBody, err := json.Marshal(errorMsg{ErrorMessage: err.Error()})
+	encodedBody, err := json.Marshal(errorMsg{ErrorMessage: err.Error(), ErrorCode: errorCode})
 	if err != nil {
 		return nil, err
 	}
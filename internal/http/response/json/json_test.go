@@ -4,7 +4,10 @@
 package json // import "miniflux.app/v2/internal/http/response/json"
 
 import (
+	"compress/gzip"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -45,6 +48,50 @@ func TestOKResponse(t *testing.T) {
 	}
 }
 
+func TestOKResponseWithGzipCompression(t *testing.T) {
+	r, err := http.NewRequest("GET", "/v1/entries/date-sections", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	counts := make(map[string]int)
+	for i := range 200 {
+		counts[fmt.Sprintf("section-%d", i)] = i
+	}
+
+	w := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		OK(w, r, counts)
+	})
+
+	handler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	expectedEncoding := "gzip"
+	actualEncoding := resp.Header.Get("Content-Encoding")
+	if actualEncoding != expectedEncoding {
+		t.Fatalf(`Unexpected content encoding, got %q instead of %q`, actualEncoding, expectedEncoding)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decodedCounts map[string]int
+	if err := json.NewDecoder(reader).Decode(&decodedCounts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decodedCounts) != len(counts) {
+		t.Fatalf(`Unexpected number of decoded counts, got %d instead of %d`, len(decodedCounts), len(counts))
+	}
+}
+
 func TestCreatedResponse(t *testing.T) {
 	r, err := http.NewRequest("GET", "/", nil)
 	if err != nil {
@@ -179,6 +226,60 @@ func TestBadRequestResponse(t *testing.T) {
 	}
 }
 
+func TestBadRequestWithCodeResponse(t *testing.T) {
+	r, err := http.NewRequest("POST", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BadRequestWithCode(w, r, "invalid_section", errors.New("Some Error"))
+	})
+
+	handler.ServeHTTP(w, r)
+	resp := w.Result()
+
+	expectedStatusCode := http.StatusBadRequest
+	if resp.StatusCode != expectedStatusCode {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, expectedStatusCode)
+	}
+
+	expectedBody := `{"error_message":"Some Error","error_code":"invalid_section"}`
+	actualBody := w.Body.String()
+	if actualBody != expectedBody {
+		t.Fatalf(`Unexpected body, got %s instead of %s`, actualBody, expectedBody)
+	}
+}
+
+func TestServerErrorWithCodeResponse(t *testing.T) {
+	r, err := http.NewRequest("POST", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerErrorWithCode(w, r, "server_error", errors.New("Some Error"))
+	})
+
+	handler.ServeHTTP(w, r)
+	resp := w.Result()
+
+	expectedStatusCode := http.StatusInternalServerError
+	if resp.StatusCode != expectedStatusCode {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, expectedStatusCode)
+	}
+
+	expectedBody := `{"error_message":"Some Error","error_code":"server_error"}`
+	actualBody := w.Body.String()
+	if actualBody != expectedBody {
+		t.Fatalf(`Unexpected body, got %s instead of %s`, actualBody, expectedBody)
+	}
+}
+
 func TestUnauthorizedResponse(t *testing.T) {
 	r, err := http.NewRequest("GET", "/", nil)
 	if err != nil {
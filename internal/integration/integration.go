@@ -4,6 +4,8 @@
 package integration // import "miniflux.app/v2/internal/integration"
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"miniflux.app/v2/internal/integration/apprise"
@@ -38,7 +40,10 @@ import (
 )
 
 // SendEntry sends the entry to third-party providers when the user click on "Save".
-func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
+func SendEntry(entry *model.Entry, userIntegrations *model.Integration) error {
+	// CUSTOM: the errors of every integration are returned together for the bulk save of the date view
+	var errs []error
+
 	if userIntegrations.BetulaEnabled {
 		slog.Debug("Sending entry to Betula",
 			slog.Int64("user_id", userIntegrations.UserID),
@@ -60,6 +65,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Betula", err))
 		}
 	}
 
@@ -85,6 +91,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Pinboard", err))
 		}
 	}
 
@@ -103,6 +110,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Instapaper", err))
 		}
 	}
 
@@ -132,6 +140,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Wallabag", err))
 		}
 	}
 
@@ -153,6 +162,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Notion", err))
 		}
 	}
 
@@ -175,6 +185,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "NunuxKeeper", err))
 		}
 	}
 
@@ -197,6 +208,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Espial", err))
 		}
 	}
 
@@ -221,6 +233,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "LinkAce", err))
 		}
 	}
 
@@ -244,6 +257,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Linkding", err))
 		}
 	}
 
@@ -267,6 +281,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "LinkTaco", err))
 		}
 	}
 
@@ -288,6 +303,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Linkwarden", err))
 		}
 	}
 
@@ -311,6 +327,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Readeck", err))
 		}
 	}
 
@@ -332,6 +349,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Readwise", err))
 		}
 	}
 
@@ -351,6 +369,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Cubox", err))
 		}
 	}
 
@@ -374,6 +393,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Shiori", err))
 		}
 	}
 
@@ -396,6 +416,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Shaarli", err))
 		}
 	}
 
@@ -433,6 +454,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("webhook_url", webhookURL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Webhook", err))
 		}
 	}
 
@@ -451,6 +473,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Omnivore", err))
 		}
 	}
 
@@ -475,6 +498,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Karakeep", err))
 		}
 	}
 
@@ -493,8 +517,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			errs = append(errs, fmt.Errorf("%s: %w", "Raindrop", err))
 		}
 	}
+
+	return errors.Join(errs...)
 }
 
 // PushEntries pushes a list of entries to activated third-party providers during feed refreshes.
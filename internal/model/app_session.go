@@ -12,19 +12,21 @@ import (
 
 // SessionData represents the data attached to the session.
 type SessionData struct {
-	CSRF                string          `json:"csrf"`
-	OAuth2State         string          `json:"oauth2_state"`
-	OAuth2CodeVerifier  string          `json:"oauth2_code_verifier"`
-	FlashMessage        string          `json:"flash_message"`
-	FlashErrorMessage   string          `json:"flash_error_message"`
-	Language            string          `json:"language"`
-	Theme               string          `json:"theme"`
-	LastForceRefresh    string          `json:"last_force_refresh"`
-	WebAuthnSessionData WebAuthnSession `json:"webauthn_session_data"`
+	CSRF                 string          `json:"csrf"`
+	OAuth2State          string          `json:"oauth2_state"`
+	OAuth2CodeVerifier   string          `json:"oauth2_code_verifier"`
+	FlashMessage         string          `json:"flash_message"`
+	FlashErrorMessage    string          `json:"flash_error_message"`
+	Language             string          `json:"language"`
+	Theme                string          `json:"theme"`
+	LastForceRefresh     string          `json:"last_force_refresh"`
+	LastDateViewLoadedAt string          `json:"last_date_view_loaded_at"`
+	DateViewSeenEntryIDs string          `json:"date_view_seen_entry_ids"`
+	WebAuthnSessionData  WebAuthnSession `json:"webauthn_session_data"`
 }
 
 func (s *SessionData) String() string {
-	return fmt.Sprintf(`CSRF=%q, OAuth2State=%q, OAuth2CodeVerifier=%q, FlashMsg=%q, FlashErrMsg=%q, Lang=%q, Theme=%q, LastForceRefresh=%s, WebAuthnSession=%q`,
+	return fmt.Sprintf(`CSRF=%q, OAuth2State=%q, OAuth2CodeVerifier=%q, FlashMsg=%q, FlashErrMsg=%q, Lang=%q, Theme=%q, LastForceRefresh=%s, LastDateViewLoadedAt=%s, DateViewSeenEntryIDs=%q, WebAuthnSession=%q`,
 		s.CSRF,
 		s.OAuth2State,
 		s.OAuth2CodeVerifier,
@@ -33,6 +35,8 @@ func (s *SessionData) String() string {
 		s.Language,
 		s.Theme,
 		s.LastForceRefresh,
+		s.LastDateViewLoadedAt,
+		s.DateViewSeenEntryIDs,
 		s.WebAuthnSessionData,
 	)
 }
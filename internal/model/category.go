@@ -14,6 +14,9 @@ type Category struct {
 	// Pointers are needed to avoid breaking /v1/categories?counts=true
 	FeedCount   *int `json:"feed_count,omitempty"`
 	TotalUnread *int `json:"total_unread,omitempty"`
+
+	// CUSTOM: ShareCode gives read-only access to the date digest of the category without an account, empty when not shared.
+	ShareCode string `json:"-"`
 }
 
 func (c *Category) String() string {
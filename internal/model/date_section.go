@@ -0,0 +1,427 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CUSTOM: date section schemes of the date entries view.
+const (
+	DateSectionSchemeDefault = "default"
+	DateSectionSchemeSimple  = "simple"
+)
+
+// CUSTOM: DateSectionOpenedRecently is the section of the unread entries opened during the last day.
+const DateSectionOpenedRecently = "opened_recently"
+
+// CUSTOM: DateSectionInProgress is the section of the unread entries the user started reading without finishing them.
+const DateSectionInProgress = "in_progress"
+
+// CUSTOM: DateSectionNewFeeds is the section of the unread entries of the feeds subscribed to recently.
+const DateSectionNewFeeds = "new_feeds"
+
+// CUSTOM: DateSectionNewFeedsDays is how many days a feed stays in the new feeds section after the subscription.
+const DateSectionNewFeedsDays = 7
+
+// CUSTOM: DateSectionFuture is the section of the unread entries published after the reference time.
+const DateSectionFuture = "future"
+
+// CUSTOM: DateSectionCustom is the section of an explicit range of days chosen by the user.
+const DateSectionCustom = "custom"
+
+// DateSection is a named window of publication dates. A nil bound leaves that side of the window open.
+type DateSection struct {
+	Name     string
+	LabelKey string
+	After    *time.Time
+	Before   *time.Time
+
+	// Label is the label the user gave to a defined section, shown as is instead of the LabelKey translation.
+	Label string
+
+	// Month is the first day of the calendar month covered by a month section, zero otherwise.
+	Month time.Time
+
+	// OpenedAfter bounds the last opening date of the entries instead of their publication date.
+	OpenedAfter *time.Time
+
+	// InProgress selects the entries partially read instead of bounding their publication date.
+	InProgress bool
+
+	// FeedCreatedAfter bounds the subscription date of the feeds of the entries instead of their publication date.
+	FeedCreatedAfter *time.Time
+
+	// Future marks the section of the entries published after the reference time, which the After bound holds.
+	Future bool
+}
+
+// ByPublicationDate reports whether the section partitions entries by their dates, unlike the opened recently,
+// in progress, new feeds and future sections which hold entries of the other sections again.
+func (s DateSection) ByPublicationDate() bool {
+	return s.OpenedAfter == nil && !s.InProgress && s.FeedCreatedAfter == nil && !s.Future
+}
+
+// DisplayLabel returns the label the user gave to the section, or the translation of its label key.
+func (s DateSection) DisplayLabel(translate func(key string, args ...any) string) string {
+	if s.Label != "" {
+		return s.Label
+	}
+	return translate(s.LabelKey)
+}
+
+// CUSTOM: DateRange is a range of dates whose bounds are both exclusive, nil when that side is open.
+type DateRange struct {
+	After  *time.Time
+	Before *time.Time
+}
+
+// DateRangeStatusUpdate describes a status change of the unread entries published within a date range.
+type DateRangeStatusUpdate struct {
+	Status string
+
+	// After and Before are exclusive, so the range holds the same entries as the date section it comes from.
+	After  *time.Time
+	Before *time.Time
+
+	// KeepStarred leaves starred entries unread.
+	KeepStarred bool
+
+	// IncludeDisabled also changes the entries of disabled feeds.
+	IncludeDisabled bool
+
+	// UseLatestDate compares the most recent of the publication and modification dates with the range.
+	UseLatestDate bool
+
+	// CreatedAsOf leaves out the entries created after it, such as the ones fetched since the page was rendered.
+	CreatedAsOf *time.Time
+
+	// SkipErroredFeeds leaves alone the entries of the feeds that failed to be parsed.
+	SkipErroredFeeds bool
+
+	// ExceptSearchQuery leaves unread the entries matching the full-text search query, when not empty.
+	ExceptSearchQuery string
+}
+
+// DateViewIndexStatus describes the index the date view relies on to scan unread entries by publication date.
+type DateViewIndexStatus struct {
+	Name   string `json:"name"`
+	Exists bool   `json:"exists"`
+	Size   int64  `json:"size"`
+
+	// UnreadEntries and TotalEntries are the unread and overall entries of the user.
+	UnreadEntries int64 `json:"unread_entries"`
+	TotalEntries  int64 `json:"total_entries"`
+
+	// Selectivity is the estimated share of the table rows matched by the unread entries of the user.
+	Selectivity float64 `json:"selectivity"`
+}
+
+// MonthlyEntryCount is the number of entries published during a calendar month.
+type MonthlyEntryCount struct {
+	Month time.Time
+	Count int
+}
+
+// DailyEntryCount is the number of entries published during a calendar day.
+type DailyEntryCount struct {
+	Day   time.Time
+	Count int
+}
+
+// UserEarlierEntryCount is the number of unread entries of a user in the oldest section of the date view.
+type UserEarlierEntryCount struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Count    int    `json:"count"`
+}
+
+// BulkStatusUpdate records the entries of a date section marked as read, or removed, at once by the user.
+type BulkStatusUpdate struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Section    string    `json:"section"`
+	Status     string    `json:"status"`
+	EntryCount int       `json:"entry_count"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BulkStatusUpdateReasonCount is how often the user gave a reason to bulk status updates, and the entries they changed.
+type BulkStatusUpdateReasonCount struct {
+	Reason      string `json:"reason"`
+	UpdateCount int    `json:"update_count"`
+	EntryCount  int    `json:"entry_count"`
+}
+
+// DateSectionDefinition is a date section defined by the user, holding the entries dated within the last Days days
+// that are older than the ones of the previous definition.
+type DateSectionDefinition struct {
+	Label string `json:"label"`
+	Days  int    `json:"days"`
+}
+
+// DateSectionDefinitions are the date sections defined by the user, in their display order, stored as JSON.
+type DateSectionDefinitions []DateSectionDefinition
+
+// Value converts the definitions to JSON, an empty list staying a JSON array.
+func (d DateSectionDefinitions) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]DateSectionDefinition(d))
+}
+
+// Scan converts raw JSON data.
+func (d *DateSectionDefinitions) Scan(src any) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("date section definitions: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, (*[]DateSectionDefinition)(d)); err != nil {
+		return fmt.Errorf("date section definitions: %v", err)
+	}
+	return nil
+}
+
+// NewDefinedDateSections returns the sections defined by the user, in the given order, then the section of the
+// entries older than the last definition. The windows are rolling days, or calendar days starting at the reset
+// hour when one is given, like the sections of NewCalendarDateSections.
+func NewDefinedDateSections(definitions DateSectionDefinitions, now time.Time, resetHour *int) []DateSection {
+	daysBefore := func(days int) *time.Time {
+		t := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &t
+	}
+
+	if resetHour != nil {
+		todayStart := time.Date(now.Year(), now.Month(), now.Day(), *resetHour, 0, 0, 0, now.Location())
+		if todayStart.After(now) {
+			todayStart = todayStart.AddDate(0, 0, -1)
+		}
+
+		daysBefore = func(days int) *time.Time {
+			t := todayStart.AddDate(0, 0, 1-days)
+			return &t
+		}
+	}
+
+	sections := make([]DateSection, 0, len(definitions)+1)
+	var before *time.Time
+	for i, definition := range definitions {
+		after := daysBefore(definition.Days)
+		sections = append(sections, DateSection{
+			Name:   fmt.Sprintf("defined-%d", i+1),
+			Label:  definition.Label,
+			After:  after,
+			Before: before,
+		})
+		before = after
+	}
+
+	return append(sections, DateSection{Name: "earlier", LabelKey: "date_group.earlier", Before: before})
+}
+
+// NewDateSections returns the ordered sections of the given scheme, newest first.
+// The default windows are rolling to align with the elapsedTime template function:
+// "X hours ago" is today, "yesterday" is the last 2 days, then the last 7 and 30 days.
+func NewDateSections(scheme string, now time.Time) []DateSection {
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	const day = 24 * time.Hour
+
+	return newDateSections(scheme, ago(day), ago(2*day), ago(7*day), ago(30*day))
+}
+
+// NewCalendarDateSections returns the ordered sections of the given scheme, newest first, with calendar days
+// starting at the given hour in the timezone of now instead of rolling 24 hours: today starts at the most recent
+// occurrence of that hour, and the other sections cover whole days before it.
+func NewCalendarDateSections(scheme string, now time.Time, resetHour int) []DateSection {
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), resetHour, 0, 0, 0, now.Location())
+	if todayStart.After(now) {
+		todayStart = todayStart.AddDate(0, 0, -1)
+	}
+
+	daysBefore := func(days int) *time.Time {
+		t := todayStart.AddDate(0, 0, -days)
+		return &t
+	}
+
+	return newDateSections(scheme, &todayStart, daysBefore(1), daysBefore(6), daysBefore(29))
+}
+
+func newDateSections(scheme string, todayStart, last2dStart, last7dStart, last30dStart *time.Time) []DateSection {
+	if scheme == DateSectionSchemeSimple {
+		return []DateSection{
+			{Name: "recent", LabelKey: "date_group.recent", After: last2dStart},
+			{Name: "this_week", LabelKey: "date_group.this_week", After: last7dStart, Before: last2dStart},
+			{Name: "older", LabelKey: "date_group.older", Before: last7dStart},
+		}
+	}
+
+	return []DateSection{
+		{Name: "today", LabelKey: "date_group.today", After: todayStart},
+		{Name: "last2d", LabelKey: "date_group.last_2d", After: last2dStart, Before: todayStart},
+		{Name: "last7d", LabelKey: "date_group.last_7d", After: last7dStart, Before: last2dStart},
+		{Name: "last30d", LabelKey: "date_group.last_30d", After: last30dStart, Before: last7dStart},
+		{Name: "earlier", LabelKey: "date_group.earlier", Before: last30dStart},
+	}
+}
+
+// NewOpenedRecentlyDateSection returns the section of the entries opened during the last 24 hours,
+// whatever their publication date.
+func NewOpenedRecentlyDateSection(now time.Time) DateSection {
+	openedAfter := now.Add(-24 * time.Hour)
+	return DateSection{Name: DateSectionOpenedRecently, LabelKey: "date_group.opened_recently", OpenedAfter: &openedAfter}
+}
+
+// NewInProgressDateSection returns the section of the entries partially read, whatever their publication date.
+func NewInProgressDateSection() DateSection {
+	return DateSection{Name: DateSectionInProgress, LabelKey: "date_group.in_progress", InProgress: true}
+}
+
+// NewNewFeedsDateSection returns the section of the entries of the feeds subscribed to during the last
+// DateSectionNewFeedsDays days, whatever their publication date, so the backfill of a new feed can be reviewed apart.
+func NewNewFeedsDateSection(now time.Time) DateSection {
+	createdAfter := now.AddDate(0, 0, -DateSectionNewFeedsDays)
+	return DateSection{Name: DateSectionNewFeeds, LabelKey: "date_group.new_feeds", FeedCreatedAfter: &createdAfter}
+}
+
+// NewCustomDateSection returns the section of the entries dated from the start of the from day, included, to the
+// start of the to day, excluded. The After bound being exclusive, it is set a microsecond before from, the
+// precision of the stored dates.
+func NewCustomDateSection(from, to time.Time) DateSection {
+	after := from.Add(-time.Microsecond)
+	return DateSection{Name: DateSectionCustom, LabelKey: "date_group.custom", After: &after, Before: &to}
+}
+
+// NewFutureDateSection returns the section of the entries dated after now, usually by a feed scheduling bug.
+// The newest section holds them as well, since it is not bounded on that side.
+func NewFutureDateSection(now time.Time) DateSection {
+	return DateSection{Name: DateSectionFuture, LabelKey: "date_group.future", After: &now, Future: true}
+}
+
+// DateSections returns the ordered sections of the date view for the user: the publication date sections
+// of the scheme, newest first, then the opened recently, in progress, new feeds and future sections. The date entries page and
+// the bulk actions both take the section boundaries from it, so they always agree on the entries of a section.
+// Without a day reset hour the windows are rolling, otherwise they are calendar days starting at that hour.
+// The sections the user defined, when there are any, replace the ones of the scheme.
+func (u *User) DateSections(scheme string, now time.Time) []DateSection {
+	sections := NewDateSections(scheme, now)
+	if len(u.DateViewSectionDefinitions) > 0 {
+		sections = NewDefinedDateSections(u.DateViewSectionDefinitions, now, u.DateViewDayResetHour)
+	} else if u.DateViewDayResetHour != nil {
+		sections = NewCalendarDateSections(scheme, now, *u.DateViewDayResetHour)
+	}
+	return append(sections, NewOpenedRecentlyDateSection(now), NewInProgressDateSection(), NewNewFeedsDateSection(now), NewFutureDateSection(now))
+}
+
+// PublicationDateSections returns the sections partitioning entries by their dates, in the same order.
+func PublicationDateSections(sections []DateSection) []DateSection {
+	publicationSections := make([]DateSection, 0, len(sections))
+	for _, section := range sections {
+		if section.ByPublicationDate() {
+			publicationSections = append(publicationSections, section)
+		}
+	}
+	return publicationSections
+}
+
+// FindDateSection returns the section with the given name.
+func FindDateSection(sections []DateSection, name string) (DateSection, bool) {
+	for _, section := range sections {
+		if section.Name == name {
+			return section, true
+		}
+	}
+	return DateSection{}, false
+}
+
+// NewMonthDateSections splits the open-ended oldest section into one section per given month, in the
+// same order. Months are calendar months in the location of the oldest section's upper bound, and the
+// newest one is cut at that bound so the month sections never overlap the other sections.
+func NewMonthDateSections(oldest DateSection, months []time.Time) []DateSection {
+	if oldest.Before == nil {
+		return nil
+	}
+
+	sections := make([]DateSection, 0, len(months))
+	for _, month := range months {
+		sections = append(sections, newMonthDateSection(oldest, month))
+	}
+	return sections
+}
+
+// ParseMonthDateSection returns the month section of the oldest section with the given name,
+// e.g. "earlier-2024-12" for December 2024.
+func ParseMonthDateSection(oldest DateSection, name string) (DateSection, bool) {
+	if oldest.Before == nil {
+		return DateSection{}, false
+	}
+
+	value, found := strings.CutPrefix(name, oldest.Name+"-")
+	if !found {
+		return DateSection{}, false
+	}
+
+	month, err := time.ParseInLocation("2006-01", value, oldest.Before.Location())
+	if err != nil || !month.Before(*oldest.Before) {
+		return DateSection{}, false
+	}
+
+	return newMonthDateSection(oldest, month), true
+}
+
+func newMonthDateSection(oldest DateSection, month time.Time) DateSection {
+	location := oldest.Before.Location()
+	month = month.In(location)
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, location)
+	end := start.AddDate(0, 1, 0)
+	if end.After(*oldest.Before) {
+		end = *oldest.Before
+	}
+
+	return DateSection{
+		Name:   oldest.Name + "-" + start.Format("2006-01"),
+		After:  &start,
+		Before: &end,
+		Month:  start,
+	}
+}
+
+// CalendarDaysAgo returns the number of calendar days between the given date and now in the timezone of now:
+// 0 for any time of the current day, 1 for yesterday, whatever the hours between them.
+func CalendarDaysAgo(date, now time.Time) int {
+	date = date.In(now.Location())
+	dateDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(today.Sub(dateDay).Hours() / 24)
+}
+
+// CUSTOM: InboxZeroStreak returns the number of consecutive days ending today, or yesterday while today is not
+// cleared yet, found in the days inbox zero was reached, given as "2006-01-02" days from the most recent.
+func InboxZeroStreak(days []string, today time.Time) int {
+	day := today
+	if len(days) > 0 && days[0] != day.Format(time.DateOnly) {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for _, clearedDay := range days {
+		if clearedDay != day.Format(time.DateOnly) {
+			break
+		}
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewDateSectionsAreContiguous(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	for scheme, expectedNames := range map[string][]string{
+		DateSectionSchemeDefault: {"today", "last2d", "last7d", "last30d", "earlier"},
+		DateSectionSchemeSimple:  {"recent", "this_week", "older"},
+	} {
+		sections := NewDateSections(scheme, now)
+		if len(sections) != len(expectedNames) {
+			t.Fatalf(`Unexpected number of sections for scheme %q: %d`, scheme, len(sections))
+		}
+
+		for i, section := range sections {
+			if section.Name != expectedNames[i] {
+				t.Errorf(`Unexpected section name at position %d for scheme %q: %q`, i, scheme, section.Name)
+			}
+
+			if i == 0 && section.Before != nil {
+				t.Errorf(`The newest section of scheme %q should not have an upper bound`, scheme)
+			}
+
+			if i == len(sections)-1 && section.After != nil {
+				t.Errorf(`The oldest section of scheme %q should not have a lower bound`, scheme)
+			}
+
+			if i > 0 && !sections[i-1].After.Equal(*section.Before) {
+				t.Errorf(`Section %q of scheme %q should end where %q starts`, section.Name, scheme, sections[i-1].Name)
+			}
+		}
+	}
+}
+
+func TestNewDateSectionsSimpleSchemeBoundaries(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	sections := NewDateSections(DateSectionSchemeSimple, now)
+
+	if expected := now.Add(-48 * time.Hour); !sections[0].After.Equal(expected) {
+		t.Errorf(`The recent section should start 48 hours ago, got %v`, sections[0].After)
+	}
+
+	if expected := now.Add(-7 * 24 * time.Hour); !sections[1].After.Equal(expected) {
+		t.Errorf(`The this_week section should start 7 days ago, got %v`, sections[1].After)
+	}
+}
+
+func TestNewCalendarDateSectionsStartAtTheMostRecentResetHour(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	for _, testCase := range []struct {
+		now                time.Time
+		expectedTodayStart time.Time
+	}{
+		{time.Date(2025, time.March, 10, 12, 0, 0, 0, location), time.Date(2025, time.March, 10, 4, 0, 0, 0, location)},
+		{time.Date(2025, time.March, 10, 3, 0, 0, 0, location), time.Date(2025, time.March, 9, 4, 0, 0, 0, location)},
+		{time.Date(2025, time.March, 10, 4, 0, 0, 0, location), time.Date(2025, time.March, 10, 4, 0, 0, 0, location)},
+	} {
+		sections := NewCalendarDateSections(DateSectionSchemeDefault, testCase.now, 4)
+		if !sections[0].After.Equal(testCase.expectedTodayStart) {
+			t.Errorf(`Today should start at %v at %v, got %v`, testCase.expectedTodayStart, testCase.now, sections[0].After)
+		}
+
+		if expected := testCase.expectedTodayStart.AddDate(0, 0, -1); !sections[1].After.Equal(expected) {
+			t.Errorf(`The last2d section should start at %v, got %v`, expected, sections[1].After)
+		}
+	}
+
+	// Days are calendar days even across a daylight saving time change
+	now := time.Date(2025, time.April, 1, 12, 0, 0, 0, location)
+	sections := NewCalendarDateSections(DateSectionSchemeDefault, now, 4)
+	if expected := time.Date(2025, time.March, 3, 4, 0, 0, 0, location); !sections[3].After.Equal(expected) {
+		t.Errorf(`The last30d section should start at %v, got %v`, expected, sections[3].After)
+	}
+}
+
+func TestUserDateSectionsFollowTheDayResetHour(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	user := &User{}
+	if sections := user.DateSections(DateSectionSchemeDefault, now); !sections[0].After.Equal(now.Add(-24 * time.Hour)) {
+		t.Errorf(`Today should be the rolling 24 hours without a reset hour, got %v`, sections[0].After)
+	}
+
+	user.SetDateViewDayResetHour(6)
+	if sections := user.DateSections(DateSectionSchemeDefault, now); !sections[0].After.Equal(time.Date(2025, time.March, 10, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Today should start at the reset hour, got %v`, sections[0].After)
+	}
+
+	user.SetDateViewDayResetHour(-1)
+	if user.DateViewDayResetHour != nil {
+		t.Errorf(`A reset hour of -1 should go back to the rolling 24 hours`)
+	}
+}
+
+func TestUserDateSectionsFollowTheDefinitions(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	user := &User{DateViewSectionDefinitions: DateSectionDefinitions{{Label: "Fresh", Days: 3}, {Label: "Fortnight", Days: 14}}}
+
+	sections := PublicationDateSections(user.DateSections(DateSectionSchemeSimple, now))
+	if len(sections) != 3 {
+		t.Fatalf(`Expected the defined sections and the earlier one, got %d sections`, len(sections))
+	}
+
+	if sections[0].Name != "defined-1" || sections[0].DisplayLabel(nil) != "Fresh" || sections[0].Before != nil {
+		t.Errorf(`Unexpected first section %+v`, sections[0])
+	}
+
+	if expected := now.AddDate(0, 0, -3); !sections[0].After.Equal(expected) || !sections[1].Before.Equal(expected) {
+		t.Errorf(`The first two sections should meet at %v`, expected)
+	}
+
+	if expected := now.AddDate(0, 0, -14); !sections[1].After.Equal(expected) || !sections[2].Before.Equal(expected) {
+		t.Errorf(`The earlier section should end at %v, got %v`, expected, sections[2].Before)
+	}
+
+	if sections[2].Name != "earlier" || sections[2].After != nil {
+		t.Errorf(`Unexpected oldest section %+v`, sections[2])
+	}
+
+	user.SetDateViewDayResetHour(6)
+	sections = user.DateSections(DateSectionSchemeDefault, now)
+	if expected := time.Date(2025, time.March, 8, 6, 0, 0, 0, time.UTC); !sections[0].After.Equal(expected) {
+		t.Errorf(`The first section should start on the calendar day at %v, got %v`, expected, sections[0].After)
+	}
+}
+
+func TestDateSectionDefinitionsRoundTrip(t *testing.T) {
+	var definitions DateSectionDefinitions
+	value, err := definitions.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(value.([]byte)) != "[]" {
+		t.Errorf(`Empty definitions should be stored as an empty array, got %s`, value)
+	}
+
+	definitions = DateSectionDefinitions{{Label: "Today", Days: 1}}
+	if value, err = definitions.Value(); err != nil {
+		t.Fatal(err)
+	}
+
+	var scanned DateSectionDefinitions
+	if err := scanned.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scanned) != 1 || scanned[0] != definitions[0] {
+		t.Errorf(`Unexpected definitions after a round trip: %+v`, scanned)
+	}
+}
+
+func TestNewMonthDateSectionsUseCalendarMonthsInLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, time.January, 20, 12, 0, 0, 0, location)
+	sections := NewDateSections(DateSectionSchemeDefault, now)
+	oldest := sections[len(sections)-1]
+
+	months := []time.Time{
+		time.Date(2024, time.December, 1, 0, 0, 0, 0, location),
+		time.Date(2024, time.November, 1, 0, 0, 0, 0, location),
+	}
+	monthSections := NewMonthDateSections(oldest, months)
+	if len(monthSections) != 2 {
+		t.Fatalf(`Expected 2 month sections, got %d`, len(monthSections))
+	}
+
+	december := monthSections[0]
+	if december.Name != "earlier-2024-12" {
+		t.Errorf(`Unexpected month section name: %q`, december.Name)
+	}
+
+	if !december.After.Equal(months[0]) {
+		t.Errorf(`The December section should start on December 1st in the user location, got %v`, december.After)
+	}
+
+	// December 21st is 30 days before now, so the December section stops where the last 30 days begin.
+	if !december.Before.Equal(*oldest.Before) {
+		t.Errorf(`The newest month section should end at the oldest section bound, got %v`, december.Before)
+	}
+
+	november := monthSections[1]
+	if !november.Before.Equal(months[0]) {
+		t.Errorf(`The November section should end on December 1st, got %v`, november.Before)
+	}
+
+	parsed, found := ParseMonthDateSection(oldest, "earlier-2024-11")
+	if !found {
+		t.Fatal(`Expected the November section to be parsed`)
+	}
+
+	if !parsed.After.Equal(*november.After) || !parsed.Before.Equal(*november.Before) {
+		t.Errorf(`Parsed month section %v does not match %v`, parsed, november)
+	}
+
+	for _, name := range []string{"earlier", "earlier-2024-13", "older-2024-11", "earlier-2025-01"} {
+		if _, found := ParseMonthDateSection(oldest, name); found {
+			t.Errorf(`The section name %q should not be parsed as a month section`, name)
+		}
+	}
+}
+
+func TestCalendarDaysAgoCountsDaysInTheTimezoneOfNow(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, time.March, 10, 0, 30, 0, 0, location)
+	scenarios := []struct {
+		date     time.Time
+		expected int
+	}{
+		{now.Add(-10 * time.Minute), 0},
+		// Less than an hour ago, but on the previous calendar day
+		{now.Add(-40 * time.Minute), 1},
+		// The UTC date of the entry is already the current day of now
+		{time.Date(2025, time.March, 10, 2, 0, 0, 0, time.UTC), 1},
+		// Across the daylight saving time change of March 9th
+		{time.Date(2025, time.March, 3, 23, 0, 0, 0, location), 7},
+	}
+
+	for _, scenario := range scenarios {
+		if result := CalendarDaysAgo(scenario.date, now); result != scenario.expected {
+			t.Errorf(`Unexpected days ago for %v, got %d instead of %d`, scenario.date, result, scenario.expected)
+		}
+	}
+}
+
+func TestInboxZeroStreak(t *testing.T) {
+	today := time.Date(2025, time.March, 10, 21, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		days     []string
+		expected int
+	}{
+		{nil, 0},
+		{[]string{"2025-03-10", "2025-03-09", "2025-03-08", "2025-03-06"}, 3},
+		// The streak goes on while today is not cleared yet
+		{[]string{"2025-03-09", "2025-03-08"}, 2},
+		{[]string{"2025-03-08", "2025-03-07"}, 0},
+		{[]string{"2025-03-10", "2025-03-08"}, 1},
+		// Across a month boundary
+		{[]string{"2025-03-01", "2025-02-28", "2025-02-27"}, 0},
+	} {
+		if streak := InboxZeroStreak(testCase.days, today); streak != testCase.expected {
+			t.Errorf(`Unexpected streak for %v, got %d instead of %d`, testCase.days, streak, testCase.expected)
+		}
+	}
+
+	firstOfMarch := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+	if streak := InboxZeroStreak([]string{"2025-03-01", "2025-02-28", "2025-02-27"}, firstOfMarch); streak != 3 {
+		t.Errorf(`Expected the streak to cross the month boundary, got %d`, streak)
+	}
+}
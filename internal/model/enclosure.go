@@ -20,6 +20,9 @@ type Enclosure struct {
 	MimeType         string `json:"mime_type"`
 	Size             int64  `json:"size"`
 	MediaProgression int64  `json:"media_progression"`
+
+	// CUSTOM: Duration is the playing time of the media in seconds, 0 when the feed does not tell.
+	Duration int64 `json:"duration"`
 }
 
 type EnclosureUpdateRequest struct {
@@ -16,6 +16,12 @@ const (
 	DefaultSortingDirection = "asc"
 )
 
+// CUSTOM: Entry scrape statuses, an entry never scraped has an empty status.
+const (
+	EntryScrapeStatusSucceeded = "succeeded"
+	EntryScrapeStatusFailed    = "failed"
+)
+
 // Entry represents a feed item in the system.
 type Entry struct {
 	ID          int64         `json:"id"`
@@ -37,12 +43,46 @@ type Entry struct {
 	Enclosures  EnclosureList `json:"enclosures"`
 	Feed        *Feed         `json:"feed,omitempty"`
 	Tags        []string      `json:"tags"`
+
+	// CUSTOM: UpdatedAt is the last modification date announced by the feed, zero when the feed has none.
+	// It is only written to the database and used by the date view.
+	UpdatedAt time.Time `json:"-"`
+
+	// CUSTOM: Language is the lowercase language tag announced by the feed, such as "en" or "de-at".
+	Language string `json:"language"`
+
+	// CUSTOM: OpenedAt is the last time the user viewed the entry page, nil when it was never opened.
+	OpenedAt *time.Time `json:"opened_at"`
+
+	// CUSTOM: ReadProgress is the share of the entry content the user scrolled through, from 0 to 1.
+	ReadProgress float64 `json:"read_progress"`
+
+	// CUSTOM: DisplayDateOverride places the entry in the date view sections instead of its publication date.
+	DisplayDateOverride *time.Time `json:"display_date_override"`
+
+	// CUSTOM: ScrapeStatus tells whether the scraper replaced the feed content, empty when the entry was not scraped.
+	ScrapeStatus string `json:"scrape_status"`
+
+	// CUSTOM: StarredAt is the last time the user starred the entry, nil when it is not starred.
+	StarredAt *time.Time `json:"starred_at"`
+
+	// CUSTOM: Labels are the sorted labels the user gave to the entry, unlike Tags which come from the feed.
+	Labels []string `json:"labels"`
+}
+
+// CUSTOM: DateViewDate returns the date placing the entry in the date view sections.
+func (e *Entry) DateViewDate() time.Time {
+	if e.DisplayDateOverride != nil {
+		return *e.DisplayDateOverride
+	}
+	return e.Date
 }
 
 func NewEntry() *Entry {
 	return &Entry{
 		Enclosures: make(EnclosureList, 0),
 		Tags:       make([]string, 0),
+		Labels:     make([]string, 0),
 		Feed: &Feed{
 			Category: &Category{},
 			Icon:     &FeedIcon{},
@@ -75,6 +115,14 @@ type EntriesStatusUpdateRequest struct {
 	Status   string  `json:"status"`
 }
 
+// CUSTOM: EntriesMarkReadUpToCursorRequest represents a request to mark as read the unread entries of a date section
+// listed up to, and including, the entry at the cursor. An empty section covers every section.
+type EntriesMarkReadUpToCursorRequest struct {
+	Section   string `json:"section"`
+	SortValue string `json:"sort_value"`
+	EntryID   int64  `json:"id"`
+}
+
 // EntryUpdateRequest represents a request to update an entry.
 type EntryUpdateRequest struct {
 	Title   *string `json:"title"`
@@ -90,3 +138,8 @@ func (e *EntryUpdateRequest) Patch(entry *Entry) {
 		entry.Content = *e.Content
 	}
 }
+
+// CUSTOM: EntryDisplayDateRequest represents a request to set, or clear with a null date, the display date override of an entry.
+type EntryDisplayDateRequest struct {
+	DisplayDate *time.Time `json:"display_date"`
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// CUSTOM: EntryCursor is the position of the last entry of a page in a listing paginated by keyset,
+// the next page starts with the entries sorted after it.
+type EntryCursor struct {
+	// Pinned is the pinned flag of the entry feed, nil when the listing does not sort pinned feeds first.
+	Pinned *bool `json:"pinned,omitempty"`
+
+	// Manual tells whether the entry feed was subscribed to by hand, nil when the listing does not sort those first.
+	Manual *bool `json:"manual,omitempty"`
+
+	// SortValue is the value of the entry for the sorting order of the listing.
+	SortValue string `json:"sort_value"`
+
+	// Tiebreak is the order of the entries sharing the same sort value, before their ID, and TiebreakValue
+	// the value of the entry for it. Both are empty when the listing breaks the ties by ID only.
+	Tiebreak      string `json:"tiebreak,omitempty"`
+	TiebreakValue string `json:"tiebreak_value,omitempty"`
+
+	EntryID int64 `json:"id"`
+}
+
+// CUSTOM: the entries sharing the same sort value can be sorted alphabetically by title or feed title before their ID.
+const (
+	EntryTiebreakTitle     = "title"
+	EntryTiebreakFeedTitle = "feed_title"
+)
+
+// NewEntryCursor returns the cursor of the entry in a listing sorted by the given order, then by ID.
+func NewEntryCursor(entry *Entry, order string, pinnedFirst, manualFirst bool) *EntryCursor {
+	cursor := &EntryCursor{SortValue: entrySortValue(entry, order), EntryID: entry.ID}
+	if pinnedFirst {
+		pinned := entry.Feed != nil && entry.Feed.Pinned
+		cursor.Pinned = &pinned
+	}
+	if manualFirst {
+		manual := entry.Feed != nil && entry.Feed.Source == FeedSourceManual
+		cursor.Manual = &manual
+	}
+	return cursor
+}
+
+// WithTiebreak records the value of the entry for the tiebreak order of the listing, if any.
+func (c *EntryCursor) WithTiebreak(entry *Entry, tiebreak string) *EntryCursor {
+	switch tiebreak {
+	case EntryTiebreakTitle:
+		c.Tiebreak, c.TiebreakValue = tiebreak, entry.Title
+	case EntryTiebreakFeedTitle:
+		c.Tiebreak = tiebreak
+		if entry.Feed != nil {
+			c.TiebreakValue = entry.Feed.Title
+		}
+	}
+	return c
+}
+
+// ParseEntryCursor decodes a cursor token returned by EntryCursor.Token.
+func ParseEntryCursor(token string) (*EntryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor encoding")
+	}
+
+	var cursor EntryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.EntryID <= 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// Token encodes the cursor for a query parameter.
+func (c *EntryCursor) Token() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// entrySortValue returns the value of the entry for the sorting order, as the database compares it.
+func entrySortValue(entry *Entry, order string) string {
+	switch order {
+	case "status":
+		return entry.Status
+	case "changed_at":
+		return entry.ChangedAt.Format(time.RFC3339Nano)
+	case "published_at":
+		return entry.Date.Format(time.RFC3339Nano)
+	case "created_at":
+		return entry.CreatedAt.Format(time.RFC3339Nano)
+	case "title":
+		return entry.Title
+	case "author":
+		return entry.Author
+	}
+
+	if entry.Feed != nil && entry.Feed.Category != nil {
+		switch order {
+		case "category_title":
+			return entry.Feed.Category.Title
+		case "category_id":
+			return strconv.FormatInt(entry.Feed.Category.ID, 10)
+		}
+	}
+	return strconv.FormatInt(entry.ID, 10)
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryCursorTokenRoundTrip(t *testing.T) {
+	entry := NewEntry()
+	entry.ID = 42
+	entry.Date = time.Date(2025, time.March, 10, 12, 0, 0, 123456000, time.FixedZone("EST", -5*3600))
+	entry.Feed.Pinned = true
+
+	cursor, err := ParseEntryCursor(NewEntryCursor(entry, "published_at", true, false).Token())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cursor.EntryID != 42 || cursor.SortValue != "2025-03-10T12:00:00.123456-05:00" {
+		t.Errorf(`Unexpected cursor position: %+v`, cursor)
+	}
+
+	if cursor.Pinned == nil || !*cursor.Pinned {
+		t.Error(`Expected the cursor to hold the pinned flag of the entry feed`)
+	}
+
+	cursor, err = ParseEntryCursor(NewEntryCursor(entry, "id", false, false).Token())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cursor.SortValue != "42" || cursor.Pinned != nil {
+		t.Errorf(`Unexpected cursor sorted by ID: %+v`, cursor)
+	}
+}
+
+func TestEntryCursorWithTiebreak(t *testing.T) {
+	entry := NewEntry()
+	entry.ID = 42
+	entry.Title = "Entry"
+	entry.Feed.Title = "Feed"
+
+	for tiebreak, expected := range map[string]string{EntryTiebreakTitle: "Entry", EntryTiebreakFeedTitle: "Feed"} {
+		cursor, err := ParseEntryCursor(NewEntryCursor(entry, "published_at", false, false).WithTiebreak(entry, tiebreak).Token())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cursor.Tiebreak != tiebreak || cursor.TiebreakValue != expected {
+			t.Errorf(`Unexpected tiebreak for %q: %+v`, tiebreak, cursor)
+		}
+	}
+
+	if cursor := NewEntryCursor(entry, "published_at", false, false).WithTiebreak(entry, ""); cursor.Tiebreak != "" || cursor.TiebreakValue != "" {
+		t.Errorf(`Expected no tiebreak, got %+v`, cursor)
+	}
+}
+
+func TestParseEntryCursorRejectsInvalidTokens(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "eyJzb3J0X3ZhbHVlIjoieCJ9"} {
+		if _, err := ParseEntryCursor(token); err == nil {
+			t.Errorf(`Expected the token %q to be rejected`, token)
+		}
+	}
+}
@@ -20,6 +20,29 @@ const (
 	DefaultFeedSortingDirection = "desc"
 )
 
+// CUSTOM: how the user subscribed to a feed. Feeds created before the source was recorded have none.
+const (
+	FeedSourceManual = "manual"
+	FeedSourceImport = "import"
+	FeedSourceAPI    = "api"
+)
+
+// CUSTOM: which date of the entries of a feed places them in the date view sections.
+const (
+	FeedAgeBasisPublished = "published"
+	FeedAgeBasisCreated   = "created"
+	FeedAgeBasisUpdated   = "updated"
+)
+
+// CUSTOM: IsValidFeedAgeBasis tells whether the value is one of the feed age bases.
+func IsValidFeedAgeBasis(ageBasis string) bool {
+	switch ageBasis {
+	case FeedAgeBasisPublished, FeedAgeBasisCreated, FeedAgeBasisUpdated:
+		return true
+	}
+	return false
+}
+
 // Feed represents a feed in the application.
 type Feed struct {
 	ID                          int64     `json:"id"`
@@ -47,6 +70,8 @@ type Feed struct {
 	Password                    string    `json:"password"`
 	Disabled                    bool      `json:"disabled"`
 	NoMediaPlayer               bool      `json:"no_media_player"`
+	Priority                    bool      `json:"priority"`
+	Pinned                      bool      `json:"pinned"`
 	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
 	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
 	FetchViaProxy               bool      `json:"fetch_via_proxy"`
@@ -62,6 +87,13 @@ type Feed struct {
 	PushoverPriority            int       `json:"pushover_priority"`
 	ProxyURL                    string    `json:"proxy_url"`
 
+	// CUSTOM: Source records how the user subscribed to the feed, it never changes afterwards.
+	Source string `json:"source"`
+
+	// CUSTOM: AgeBasis is the date of the entries placing them in the date view sections: their publication
+	// date, the date they were fetched, or the most recent of the publication and modification dates.
+	AgeBasis string `json:"age_basis"`
+
 	// Non-persisted attributes
 	Category *Category `json:"category,omitempty"`
 	Icon     *FeedIcon `json:"icon"`
@@ -80,6 +112,31 @@ type FeedCounters struct {
 	UnreadCounters map[int64]int `json:"unreads"`
 }
 
+// CUSTOM: FeedUnreadCount is the number of unread entries of a feed.
+type FeedUnreadCount struct {
+	FeedID      int64  `json:"feed_id"`
+	FeedTitle   string `json:"feed_title"`
+	UnreadCount int    `json:"unread_count"`
+}
+
+// CUSTOM: FeedRefreshEntryCount is the number of entries a single refresh of a feed created.
+type FeedRefreshEntryCount struct {
+	FeedID     int64     `json:"feed_id"`
+	FeedTitle  string    `json:"feed_title"`
+	CreatedAt  time.Time `json:"created_at"`
+	EntryCount int       `json:"entry_count"`
+}
+
+// CUSTOM: FeedReadRatio is the share of the entries of a feed the user actually opened, among its entries
+// no longer unread. Entries marked as read in bulk without being opened lower the ratio.
+type FeedReadRatio struct {
+	FeedID       int64   `json:"feed_id"`
+	FeedTitle    string  `json:"feed_title"`
+	OpenedCount  int     `json:"opened_count"`
+	SettledCount int     `json:"settled_count"`
+	ReadRatio    float64 `json:"read_ratio"`
+}
+
 func (f *Feed) String() string {
 	return fmt.Sprintf("ID=%d, UserID=%d, FeedURL=%s, SiteURL=%s, Title=%s, Category={%s}",
 		f.ID,
@@ -171,6 +228,9 @@ type FeedCreationRequest struct {
 	KeepFilterEntryRules        string `json:"keep_filter_entry_rules"`
 	UrlRewriteRules             string `json:"urlrewrite_rules"`
 	ProxyURL                    string `json:"proxy_url"`
+
+	// CUSTOM: Source is set by the handler receiving the request, clients cannot choose it.
+	Source string `json:"-"`
 }
 
 type FeedCreationRequestFromSubscriptionDiscovery struct {
@@ -202,6 +262,9 @@ type FeedModificationRequest struct {
 	CategoryID                  *int64  `json:"category_id"`
 	Disabled                    *bool   `json:"disabled"`
 	NoMediaPlayer               *bool   `json:"no_media_player"`
+	Priority                    *bool   `json:"priority"`
+	Pinned                      *bool   `json:"pinned"`
+	AgeBasis                    *string `json:"age_basis"`
 	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
 	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
@@ -288,6 +351,18 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 		feed.NoMediaPlayer = *f.NoMediaPlayer
 	}
 
+	if f.Priority != nil {
+		feed.Priority = *f.Priority
+	}
+
+	if f.Pinned != nil {
+		feed.Pinned = *f.Pinned
+	}
+
+	if f.AgeBasis != nil {
+		feed.AgeBasis = *f.AgeBasis
+	}
+
 	if f.IgnoreHTTPCache != nil {
 		feed.IgnoreHTTPCache = *f.IgnoreHTTPCache
 	}
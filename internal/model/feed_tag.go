@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"slices"
+	"strings"
+)
+
+// CUSTOM: ParseFeedTags returns the lowercase tags of a comma separated list, sorted and without duplicates.
+func ParseFeedTags(value string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// CUSTOM: ParseEntryLabels returns the lowercase labels of a comma separated list, sorted and without duplicates,
+// the same way as feed tags.
+func ParseEntryLabels(value string) []string {
+	return ParseFeedTags(value)
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseFeedTags(t *testing.T) {
+	scenarios := map[string][]string{
+		"":                        {},
+		" , ,":                    {},
+		"News":                    {"news"},
+		"tech, News ,tech,Golang": {"golang", "news", "tech"},
+	}
+
+	for value, expected := range scenarios {
+		if tags := ParseFeedTags(value); !slices.Equal(tags, expected) {
+			t.Errorf(`Unexpected tags for %q, got %v instead of %v`, value, tags, expected)
+		}
+	}
+}
@@ -376,3 +376,17 @@ func TestFeedScheduleNextCheckEntryFrequencyLargeNewTTL(t *testing.T) {
 		t.Error(`The next_check_at should be after timeBefore + entry frequency min interval`)
 	}
 }
+
+func TestIsValidFeedAgeBasis(t *testing.T) {
+	for _, ageBasis := range []string{FeedAgeBasisPublished, FeedAgeBasisCreated, FeedAgeBasisUpdated} {
+		if !IsValidFeedAgeBasis(ageBasis) {
+			t.Errorf(`Expected %q to be a valid age basis`, ageBasis)
+		}
+	}
+
+	for _, ageBasis := range []string{"", "changed_at", "Published"} {
+		if IsValidFeedAgeBasis(ageBasis) {
+			t.Errorf(`Expected %q to be an invalid age basis`, ageBasis)
+		}
+	}
+}
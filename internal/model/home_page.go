@@ -11,5 +11,16 @@ func HomePages() map[string]string {
 		"history":    "menu.history",
 		"feeds":      "menu.feeds",
 		"categories": "menu.categories",
+		// CUSTOM: the date view can be pinned as the landing page
+		"date_entries": "menu.date_entries",
 	}
 }
+
+// CUSTOM: HomePageRouteName returns the name of the route the home page redirects to.
+// Most home pages are named after their route, the date view is not.
+func HomePageRouteName(homePage string) string {
+	if homePage == "date_entries" {
+		return "dateEntries"
+	}
+	return homePage
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestHomePageRouteName(t *testing.T) {
+	scenarios := map[string]string{
+		"unread":       "unread",
+		"categories":   "categories",
+		"date_entries": "dateEntries",
+	}
+
+	for homePage, expected := range scenarios {
+		if _, found := HomePages()[homePage]; !found {
+			t.Errorf(`Expected %q to be an available home page`, homePage)
+		}
+		if routeName := HomePageRouteName(homePage); routeName != expected {
+			t.Errorf(`Unexpected route name for %q, got %q instead of %q`, homePage, routeName, expected)
+		}
+	}
+}
@@ -43,6 +43,12 @@ type User struct {
 	KeepFilterEntryRules            string     `json:"keep_filter_entry_rules"`
 	AlwaysOpenExternalLinks         bool       `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab       bool       `json:"open_external_links_in_new_tab"`
+	DateViewUseLatestDate           bool       `json:"date_view_use_latest_date"`
+	DateViewExcludeSeen             bool       `json:"date_view_exclude_seen"`
+	DateViewDayResetHour            *int       `json:"date_view_day_reset_hour"`
+
+	// CUSTOM: DateViewSectionDefinitions are the date sections defined by the user, the scheme ones when empty.
+	DateViewSectionDefinitions DateSectionDefinitions `json:"date_view_section_definitions"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -86,6 +92,9 @@ type UserModificationRequest struct {
 	KeepFilterEntryRules            *string  `json:"keep_filter_entry_rules"`
 	AlwaysOpenExternalLinks         *bool    `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab       *bool    `json:"open_external_links_in_new_tab"`
+	DateViewUseLatestDate           *bool    `json:"date_view_use_latest_date"`
+	DateViewExcludeSeen             *bool    `json:"date_view_exclude_seen"`
+	DateViewDayResetHour            *int     `json:"date_view_day_reset_hour"`
 }
 
 // Patch updates the User object with the modification request.
@@ -209,6 +218,18 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.OpenExternalLinksInNewTab != nil {
 		user.OpenExternalLinksInNewTab = *u.OpenExternalLinksInNewTab
 	}
+
+	if u.DateViewUseLatestDate != nil {
+		user.DateViewUseLatestDate = *u.DateViewUseLatestDate
+	}
+
+	if u.DateViewExcludeSeen != nil {
+		user.DateViewExcludeSeen = *u.DateViewExcludeSeen
+	}
+
+	if u.DateViewDayResetHour != nil {
+		user.SetDateViewDayResetHour(*u.DateViewDayResetHour)
+	}
 }
 
 // UseTimezone converts last login date to the given timezone.
@@ -218,6 +239,49 @@ func (u *User) UseTimezone(tz string) {
 	}
 }
 
+// CUSTOM: SetDateViewDayResetHour sets the hour starting the days of the date view, -1 going back to rolling 24 hours.
+func (u *User) SetDateViewDayResetHour(hour int) {
+	if hour < 0 {
+		u.DateViewDayResetHour = nil
+		return
+	}
+	u.DateViewDayResetHour = &hour
+}
+
+// CUSTOM: DateViewDayResetHourOrRolling returns the hour starting the days of the date view, -1 for rolling 24 hours.
+func (u *User) DateViewDayResetHourOrRolling() int {
+	if u.DateViewDayResetHour == nil {
+		return -1
+	}
+	return *u.DateViewDayResetHour
+}
+
+// CUSTOM: DateViewSettings bundles every date view preference of a user. DayResetHour is -1 for rolling 24 hours.
+type DateViewSettings struct {
+	UseLatestDate     bool     `json:"use_latest_date"`
+	ExcludeSeen       bool     `json:"exclude_seen"`
+	DayResetHour      int      `json:"day_reset_hour"`
+	CollapsedSections []string `json:"collapsed_sections"`
+}
+
+// CUSTOM: DateViewSettingsRequest replaces every date view preference of a user at once, none can be left out.
+type DateViewSettingsRequest struct {
+	UseLatestDate     *bool     `json:"use_latest_date"`
+	ExcludeSeen       *bool     `json:"exclude_seen"`
+	DayResetHour      *int      `json:"day_reset_hour"`
+	CollapsedSections *[]string `json:"collapsed_sections"`
+}
+
+// Settings returns the date view preferences of the request, once validated.
+func (r *DateViewSettingsRequest) Settings() *DateViewSettings {
+	return &DateViewSettings{
+		UseLatestDate:     *r.UseLatestDate,
+		ExcludeSeen:       *r.ExcludeSeen,
+		DayResetHour:      *r.DayResetHour,
+		CollapsedSections: *r.CollapsedSections,
+	}
+}
+
 // Users represents a list of users.
 type Users []*User
 
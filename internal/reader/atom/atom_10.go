@@ -22,6 +22,10 @@ import (
 type atom10Feed struct {
 	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
 
+	// CUSTOM: The "xml:lang" attribute indicates the natural language of the feed.
+	// It is inherited by the entries that do not set their own.
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+
 	// The "atom:id" element conveys a permanent, universally unique
 	// identifier for an entry or feed.
 	//
@@ -85,6 +89,9 @@ type atom10Feed struct {
 }
 
 type atom10Entry struct {
+	// CUSTOM: The "xml:lang" attribute indicates the natural language of the entry.
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+
 	// The "atom:id" element conveys a permanent, universally unique
 	// identifier for an entry or feed.
 	//
@@ -132,6 +132,19 @@ func (a *atom10Adapter) populateEntries(siteURL string) model.Entries {
 			entry.Date = time.Now()
 		}
 
+		// CUSTOM: keep the modification date for the date view, which can sort edited entries by it.
+		if atomEntry.Updated != "" {
+			if parsedDate, err := date.Parse(atomEntry.Updated); err == nil {
+				entry.UpdatedAt = parsedDate
+			}
+		}
+
+		// CUSTOM: populate the entry language, falling back to the feed language.
+		entry.Language = strings.ToLower(strings.TrimSpace(atomEntry.Lang))
+		if entry.Language == "" {
+			entry.Language = strings.ToLower(strings.TrimSpace(a.atomFeed.Lang))
+		}
+
 		// Populate categories.
 		categories := atomEntry.Categories.CategoryNames()
 		if len(categories) == 0 {
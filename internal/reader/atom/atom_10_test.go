@@ -1344,6 +1344,70 @@ func TestParseEntryWithPublishedAndUpdated(t *testing.T) {
 	if !feed.Entries[0].Date.Equal(time.Date(2002, time.November, 12, 18, 30, 2, 0, time.UTC)) {
 		t.Errorf("Incorrect entry date, got: %v", feed.Entries[0].Date)
 	}
+
+	if !feed.Entries[0].UpdatedAt.Equal(time.Date(2003, time.December, 13, 18, 30, 2, 0, time.UTC)) {
+		t.Errorf("Incorrect entry modification date, got: %v", feed.Entries[0].UpdatedAt)
+	}
+}
+
+func TestParseEntryWithInvalidPublishedFallsBackToUpdated(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom">
+	  <title>Example Feed</title>
+	  <link href="http://example.org/"/>
+
+	  <entry>
+		<link href="http://example.org/2003/12/13/atom03"/>
+		<id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</id>
+		<published>last week</published>
+		<updated>2003-12-13T18:30:02Z</updated>
+		<summary>Some text.</summary>
+	  </entry>
+
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !feed.Entries[0].Date.Equal(time.Date(2003, time.December, 13, 18, 30, 2, 0, time.UTC)) {
+		t.Errorf("Incorrect entry date, got: %v", feed.Entries[0].Date)
+	}
+}
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en-US">
+	  <title>Example Feed</title>
+	  <link href="http://example.org/"/>
+
+	  <entry xml:lang="de">
+		<link href="http://example.org/2003/12/13/german"/>
+		<id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</id>
+		<updated>2003-12-13T18:30:02Z</updated>
+	  </entry>
+
+	  <entry>
+		<link href="http://example.org/2003/12/13/english"/>
+		<id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6b</id>
+		<updated>2003-12-13T18:30:02Z</updated>
+	  </entry>
+
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "de" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "en-us" {
+		t.Errorf("Incorrect inherited entry language, got: %q", feed.Entries[1].Language)
+	}
 }
 
 func TestParseInvalidXml(t *testing.T) {
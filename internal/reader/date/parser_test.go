@@ -5,6 +5,7 @@ package date // import "miniflux.app/v2/internal/reader/date"
 
 import (
 	"testing"
+	"time"
 )
 
 func FuzzParse(f *testing.F) {
@@ -178,6 +179,24 @@ func TestParseRSSDateOffset(t *testing.T) {
 	}
 }
 
+func TestParseAtomDateWithPositiveOffset(t *testing.T) {
+	date, err := Parse("2025-03-10T08:30:00+09:00")
+	if err != nil {
+		t.Fatalf(`Atom dates with offset should be parsed correctly: %v`, err)
+	}
+
+	expected := time.Date(2025, time.March, 9, 23, 30, 0, 0, time.UTC)
+	if !date.Equal(expected) {
+		t.Errorf(`The date should be %v instead of %v`, expected, date.UTC())
+	}
+
+	_, offset := date.Zone()
+	expectedOffset := 9 * 60 * 60
+	if offset != expectedOffset {
+		t.Errorf(`The offset should be %v instead of %v`, expectedOffset, offset)
+	}
+}
+
 func TestParseWeirdDateFormat(t *testing.T) {
 	dates := []string{
 		"Sun, 17 Dec 2017 1:55 PM EST",
@@ -12,4 +12,9 @@ type DublinCoreItemElement struct {
 	DublinCoreDate    string `xml:"http://purl.org/dc/elements/1.1/ date"`
 	DublinCoreCreator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
 	DublinCoreContent string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+
+	// CUSTOM: the DCMI Metadata Terms dates, used by feeds giving no dc:date.
+	DublinCoreTermsIssued   string `xml:"http://purl.org/dc/terms/ issued"`
+	DublinCoreTermsCreated  string `xml:"http://purl.org/dc/terms/ created"`
+	DublinCoreTermsModified string `xml:"http://purl.org/dc/terms/ modified"`
 }
@@ -123,6 +123,19 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 			entry.Date = time.Now()
 		}
 
+		// CUSTOM: keep the modification date for the date view, which can sort edited entries by it.
+		if value := strings.TrimSpace(item.DateModified); value != "" {
+			if date, err := date.Parse(value); err == nil {
+				entry.UpdatedAt = date
+			}
+		}
+
+		// CUSTOM: populate the entry language, falling back to the feed language.
+		entry.Language = strings.ToLower(strings.TrimSpace(item.Language))
+		if entry.Language == "" {
+			entry.Language = strings.ToLower(strings.TrimSpace(j.jsonFeed.Language))
+		}
+
 		// Populate the entry author.
 		itemAuthors := j.jsonFeed.Authors
 		itemAuthors = append(itemAuthors, item.Authors...)
@@ -149,6 +162,7 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 						URL:      absoluteAttachmentURL,
 						MimeType: attachment.MimeType,
 						Size:     attachment.Size,
+						Duration: int64(attachment.Duration),
 					})
 				}
 			}
@@ -972,6 +972,42 @@ func TestParseFeedWithRelativeAttachmentURL(t *testing.T) {
 	}
 }
 
+func TestParseEntryLanguage(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"language": "en",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/german",
+				"content_text": "Hallo",
+				"language": "de-AT"
+			},
+			{
+				"id": "2",
+				"url": "https://example.org/english",
+				"content_text": "Hello"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "de-at" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "en" {
+		t.Errorf("Incorrect inherited entry language, got: %q", feed.Entries[1].Language)
+	}
+}
+
 func TestParseInvalidJSON(t *testing.T) {
 	data := `garbage`
 	_, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
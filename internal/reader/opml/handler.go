@@ -75,6 +75,7 @@ func (h *Handler) Import(userID int64, data io.Reader) error {
 				SiteURL:     subscription.SiteURL,
 				Description: subscription.Description,
 				Category:    category,
+				Source:      model.FeedSourceImport,
 			}
 
 			if err := h.store.CreateFeed(feed); err != nil {
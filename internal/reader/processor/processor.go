@@ -136,6 +136,9 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 				// We replace the entry content only if the scraper doesn't return any error.
 				entry.Content = minifyContent(extractedContent)
 			}
+
+			// CUSTOM: Remember whether the content was enriched by the scraper, the date view can filter on it
+			entry.ScrapeStatus = scrapeStatus(extractedContent, scraperErr)
 		}
 
 		rewrite.ApplyContentRewriteRules(entry, feed.RewriteRules)
@@ -189,6 +192,9 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 		metric.ScraperRequestDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
 	}
 
+	// CUSTOM: Remember whether the content was enriched by the scraper, the date view can filter on it
+	entry.ScrapeStatus = scrapeStatus(extractedContent, scraperErr)
+
 	if scraperErr != nil {
 		return scraperErr
 	}
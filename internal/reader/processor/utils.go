@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"miniflux.app/v2/internal/model"
+
 	"github.com/tdewolff/minify/v2"
 	"github.com/tdewolff/minify/v2/html"
 )
@@ -77,3 +79,12 @@ func minifyContent(content string) string {
 
 	return content
 }
+
+// CUSTOM: scrapeStatus returns the scrape status of an entry from the scraper result.
+// A page without any extracted content counts as a failure since the entry keeps the feed content.
+func scrapeStatus(extractedContent string, scraperErr error) string {
+	if scraperErr != nil || extractedContent == "" {
+		return model.EntryScrapeStatusFailed
+	}
+	return model.EntryScrapeStatusSucceeded
+}
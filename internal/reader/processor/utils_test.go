@@ -4,8 +4,11 @@
 package processor // import "miniflux.app/v2/internal/reader/processor"
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	"miniflux.app/v2/internal/model"
 )
 
 func TestISO8601DurationParsing(t *testing.T) {
@@ -125,3 +128,17 @@ func TestMinifyContentWithSpecialComments(t *testing.T) {
 		t.Errorf(`Unexpected result, got %q`, result)
 	}
 }
+
+func TestScrapeStatus(t *testing.T) {
+	if status := scrapeStatus("<p>Article</p>", nil); status != model.EntryScrapeStatusSucceeded {
+		t.Errorf(`Unexpected status for extracted content, got %q`, status)
+	}
+
+	if status := scrapeStatus("", nil); status != model.EntryScrapeStatusFailed {
+		t.Errorf(`Unexpected status without extracted content, got %q`, status)
+	}
+
+	if status := scrapeStatus("<p>Article</p>", errors.New("timeout")); status != model.EntryScrapeStatusFailed {
+		t.Errorf(`Unexpected status for a scraper error, got %q`, status)
+	}
+}
@@ -79,18 +79,24 @@ func (r *rdfAdapter) buildFeed(baseURL string) *model.Feed {
 		entry.Hash = crypto.SHA256(hashValue)
 
 		// Populate the entry date.
-		entry.Date = time.Now()
-		if item.DublinCoreDate != "" {
-			if itemDate, err := date.Parse(item.DublinCoreDate); err != nil {
-				slog.Debug("Unable to parse date from RDF feed",
-					slog.String("date", item.DublinCoreDate),
-					slog.String("link", itemLink),
-					slog.Any("error", err),
-				)
-			} else {
-				entry.Date = itemDate
+		// CUSTOM: fall back to the DCMI Metadata Terms dates before the fetch time.
+		for _, value := range []string{item.DublinCoreDate, item.DublinCoreTermsIssued, item.DublinCoreTermsCreated, item.DublinCoreTermsModified} {
+			if value != "" {
+				if itemDate, err := date.Parse(value); err != nil {
+					slog.Debug("Unable to parse date from RDF feed",
+						slog.String("date", value),
+						slog.String("link", itemLink),
+						slog.Any("error", err),
+					)
+				} else {
+					entry.Date = itemDate
+					break
+				}
 			}
 		}
+		if entry.Date.IsZero() {
+			entry.Date = time.Now()
+		}
 
 		// Populate the entry author.
 		switch {
@@ -69,6 +69,7 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 	for _, item := range r.rss.Channel.Items {
 		entry := model.NewEntry()
 		entry.Date = findEntryDate(&item)
+		entry.UpdatedAt = findEntryUpdatedDate(&item)
 		entry.Content = findEntryContent(&item)
 		entry.Enclosures = findEntryEnclosures(&item, feed.SiteURL)
 
@@ -126,6 +127,9 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 			}
 		}
 
+		// CUSTOM: RSS items have no language of their own, so they take the channel language.
+		entry.Language = strings.ToLower(strings.TrimSpace(r.rss.Channel.Language))
+
 		// Populate entry categories.
 		entry.Tags = findEntryTags(&item)
 		if len(entry.Tags) == 0 {
@@ -233,13 +237,22 @@ func findEntryContent(rssItem *rssItem) string {
 	return ""
 }
 
+// CUSTOM: findEntryDate returns the first publication date of the item that can be parsed, then its
+// modification date, so an unparsable or missing pubDate does not date the entry at the fetch time.
 func findEntryDate(rssItem *rssItem) time.Time {
-	value := rssItem.PubDate
-	if rssItem.DublinCoreDate != "" {
-		value = rssItem.DublinCoreDate
-	}
+	for _, value := range []string{
+		rssItem.DublinCoreDate,
+		rssItem.PubDate,
+		rssItem.AtomPublished,
+		rssItem.DublinCoreTermsIssued,
+		rssItem.DublinCoreTermsCreated,
+		rssItem.AtomUpdated,
+		rssItem.DublinCoreTermsModified,
+	} {
+		if value == "" {
+			continue
+		}
 
-	if value != "" {
 		result, err := date.Parse(value)
 		if err != nil {
 			slog.Debug("Unable to parse date from RSS feed",
@@ -247,7 +260,7 @@ func findEntryDate(rssItem *rssItem) time.Time {
 				slog.String("guid", rssItem.GUID.Data),
 				slog.Any("error", err),
 			)
-			return time.Now()
+			continue
 		}
 
 		return result
@@ -256,6 +269,18 @@ func findEntryDate(rssItem *rssItem) time.Time {
 	return time.Now()
 }
 
+// CUSTOM: findEntryUpdatedDate returns the modification date of the item, zero when it does not give one.
+func findEntryUpdatedDate(rssItem *rssItem) time.Time {
+	for _, value := range []string{rssItem.AtomUpdated, rssItem.DublinCoreTermsModified} {
+		if value != "" {
+			if result, err := date.Parse(value); err == nil {
+				return result
+			}
+		}
+	}
+	return time.Time{}
+}
+
 func findEntryAuthor(rssItem *rssItem) string {
 	var author string
 
@@ -326,6 +351,9 @@ func findEntryEnclosures(rssItem *rssItem, siteURL string) model.EnclosureList {
 		}
 	}
 
+	// CUSTOM: the iTunes duration of the item is the playing time of its episode enclosures
+	episodeDuration, _ := getDurationInSeconds(rssItem.ItunesDuration)
+
 	for _, enclosure := range rssItem.Enclosures {
 		enclosureURL := enclosure.URL
 
@@ -352,6 +380,7 @@ func findEntryEnclosures(rssItem *rssItem, siteURL string) model.EnclosureList {
 				URL:      enclosureURL,
 				MimeType: enclosure.Type,
 				Size:     enclosure.Size(),
+				Duration: int64(episodeDuration),
 			})
 		}
 	}
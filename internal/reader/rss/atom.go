@@ -15,6 +15,12 @@ func (a *atomAuthor) PersonName() string {
 	return a.Author.PersonName()
 }
 
+// CUSTOM: atomDates holds the Atom dates some RSS feeds give instead of pubDate.
+type atomDates struct {
+	AtomPublished string `xml:"http://www.w3.org/2005/Atom published"`
+	AtomUpdated   string `xml:"http://www.w3.org/2005/Atom updated"`
+}
+
 type atomLinks struct {
 	Links []*atom.AtomLink `xml:"http://www.w3.org/2005/Atom link"`
 }
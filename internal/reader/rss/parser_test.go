@@ -1065,6 +1065,107 @@ func TestParseEntryWithDublinCoreDate(t *testing.T) {
 	}
 }
 
+func TestParseEntryWithInvalidDublinCoreDateFallsBackToPubDate(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+				<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+				<channel>
+					<title>Example</title>
+					<link>http://example.org/</link>
+					<item>
+						<title>Item 1</title>
+						<link>http://example.org/item1</link>
+						<dc:date>yesterday</dc:date>
+						<pubDate>Tue, 10 Jun 2003 04:00:00 GMT</pubDate>
+					</item>
+				</channel>
+			</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDate := time.Date(2003, time.June, 10, 4, 0, 0, 0, time.UTC)
+	if !feed.Entries[0].Date.Equal(expectedDate) {
+		t.Errorf("Incorrect entry date, got: %v, want: %v", feed.Entries[0].Date, expectedDate)
+	}
+}
+
+func TestParseEntryWithAtomDates(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+				<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+				<channel>
+					<title>Example</title>
+					<link>http://example.org/</link>
+					<item>
+						<title>Item 1</title>
+						<link>http://example.org/item1</link>
+						<atom:updated>2024-03-02T10:00:00Z</atom:updated>
+						<atom:published>2024-03-01T08:00:00Z</atom:published>
+					</item>
+					<item>
+						<title>Item 2</title>
+						<link>http://example.org/item2</link>
+						<atom:updated>2024-03-02T10:00:00Z</atom:updated>
+					</item>
+				</channel>
+			</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The publication date comes first, the modification date only dates items without one
+	published := time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, time.March, 2, 10, 0, 0, 0, time.UTC)
+	if !feed.Entries[0].Date.Equal(published) || !feed.Entries[0].UpdatedAt.Equal(updated) {
+		t.Errorf("Incorrect entry dates, got: %v and %v", feed.Entries[0].Date, feed.Entries[0].UpdatedAt)
+	}
+
+	if !feed.Entries[1].Date.Equal(updated) || !feed.Entries[1].UpdatedAt.Equal(updated) {
+		t.Errorf("Incorrect entry dates, got: %v and %v", feed.Entries[1].Date, feed.Entries[1].UpdatedAt)
+	}
+}
+
+func TestParseEntryWithDublinCoreTermsDates(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+				<rss version="2.0" xmlns:dcterms="http://purl.org/dc/terms/">
+				<channel>
+					<title>Example</title>
+					<link>http://example.org/</link>
+					<item>
+						<title>Item 1</title>
+						<link>http://example.org/item1</link>
+						<dcterms:modified>2024-05-02</dcterms:modified>
+						<dcterms:issued>2024-05-01</dcterms:issued>
+					</item>
+					<item>
+						<title>Item 2</title>
+						<link>http://example.org/item2</link>
+						<dcterms:created>2024-04-30T12:00:00Z</dcterms:created>
+					</item>
+				</channel>
+			</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expectedDate := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC); !feed.Entries[0].Date.Equal(expectedDate) {
+		t.Errorf("Incorrect entry date, got: %v, want: %v", feed.Entries[0].Date, expectedDate)
+	}
+
+	if expectedDate := time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC); !feed.Entries[0].UpdatedAt.Equal(expectedDate) {
+		t.Errorf("Incorrect entry modification date, got: %v, want: %v", feed.Entries[0].UpdatedAt, expectedDate)
+	}
+
+	if expectedDate := time.Date(2024, time.April, 30, 12, 0, 0, 0, time.UTC); !feed.Entries[1].Date.Equal(expectedDate) {
+		t.Errorf("Incorrect entry date, got: %v, want: %v", feed.Entries[1].Date, expectedDate)
+	}
+}
+
 func TestParseEntryWithContentEncoded(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
@@ -1798,6 +1899,36 @@ func TestParseItunesDuration(t *testing.T) {
 	}
 }
 
+func TestParseItunesDurationOfEnclosures(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+		<channel>
+			<title>Podcast Example</title>
+			<link>http://www.example.com/index.html</link>
+			<item>
+				<title>Podcast Episode</title>
+				<guid>http://example.com/episode.m4a</guid>
+				<pubDate>Tue, 08 Mar 2016 12:00:00 GMT</pubDate>
+				<enclosure url="http://example.com/episode.m4a" length="1024" type="audio/x-m4a"/>
+				<itunes:duration>25:30</itunes:duration>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries[0].Enclosures) != 1 {
+		t.Fatalf(`Expected 1 enclosure, got %d`, len(feed.Entries[0].Enclosures))
+	}
+
+	if duration := feed.Entries[0].Enclosures[0].Duration; duration != 1530 {
+		t.Errorf(`Unexpected enclosure duration, got %d instead of 1530 seconds`, duration)
+	}
+}
+
 func TestParseIncorrectItunesDuration(t *testing.T) {
 	data := `<?xml version="1.0" encoding="UTF-8"?>
 		<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
@@ -2179,3 +2310,27 @@ func TestParseFeedWithIncorrectTTLValue(t *testing.T) {
 		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
 	}
 }
+
+func TestParseEntryLanguageFromChannel(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<language>de-DE</language>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "de-de" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+}
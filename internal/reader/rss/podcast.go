@@ -13,6 +13,16 @@ import (
 var errInvalidDurationFormat = errors.New("rss: invalid duration format")
 
 func getDurationInMinutes(rawDuration string) (int, error) {
+	sumSeconds, err := getDurationInSeconds(rawDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	return sumSeconds / 60, nil
+}
+
+// CUSTOM: getDurationInSeconds parses an iTunes duration given as seconds, MM:SS or HH:MM:SS.
+func getDurationInSeconds(rawDuration string) (int, error) {
 	var sumSeconds int
 
 	durationParts := strings.Split(rawDuration, ":")
@@ -29,5 +39,5 @@ func getDurationInMinutes(rawDuration string) (int, error) {
 		sumSeconds += int(math.Pow(60, float64(len(durationParts)-i-1))) * durationPartValue
 	}
 
-	return sumSeconds / 60, nil
+	return sumSeconds, nil
 }
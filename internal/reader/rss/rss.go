@@ -165,6 +165,7 @@ type rssItem struct {
 	media.MediaItemElement
 	atomAuthor
 	atomLinks
+	atomDates
 	itunes.ItunesItemElement
 	googleplay.GooglePlayItemElement
 }
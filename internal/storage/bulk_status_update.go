@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: CreateBulkStatusUpdate records that the user changed the status of the entries of a date section at once.
+func (s *Storage) CreateBulkStatusUpdate(update *model.BulkStatusUpdate) error {
+	query := `
+		INSERT INTO bulk_status_updates
+			(user_id, section, status, entry_count, reason)
+		VALUES
+			($1, $2, $3, $4, $5)
+	`
+	if _, err := s.db.Exec(query, update.UserID, update.Section, update.Status, update.EntryCount, update.Reason); err != nil {
+		return fmt.Errorf(`store: unable to record the bulk status update of user #%d: %v`, update.UserID, err)
+	}
+
+	return nil
+}
+
+// CUSTOM: BulkStatusUpdates returns the last bulk status updates of the user, the most recent first.
+func (s *Storage) BulkStatusUpdates(userID int64, limit int) ([]*model.BulkStatusUpdate, error) {
+	query := `
+		SELECT
+			id, user_id, section, status, entry_count, reason, created_at
+		FROM
+			bulk_status_updates
+		WHERE
+			user_id=$1
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT $2
+	`
+	rows, err := s.db.Query(query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the bulk status updates of user #%d: %v`, userID, err)
+	}
+	defer rows.Close()
+
+	updates := make([]*model.BulkStatusUpdate, 0)
+	for rows.Next() {
+		var update model.BulkStatusUpdate
+		if err := rows.Scan(&update.ID, &update.UserID, &update.Section, &update.Status, &update.EntryCount, &update.Reason, &update.CreatedAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch bulk status update row: %v`, err)
+		}
+		updates = append(updates, &update)
+	}
+
+	return updates, nil
+}
+
+// CUSTOM: BulkStatusUpdateReasonCounts returns how often the user gave each reason to bulk status updates,
+// the most used first. Updates made without a reason are left out.
+func (s *Storage) BulkStatusUpdateReasonCounts(userID int64) ([]*model.BulkStatusUpdateReasonCount, error) {
+	query := `
+		SELECT
+			reason, count(*) AS update_count, sum(entry_count)
+		FROM
+			bulk_status_updates
+		WHERE
+			user_id=$1 AND reason <> ''
+		GROUP BY
+			reason
+		ORDER BY
+			update_count DESC, reason ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to count the bulk status update reasons of user #%d: %v`, userID, err)
+	}
+	defer rows.Close()
+
+	counts := make([]*model.BulkStatusUpdateReasonCount, 0)
+	for rows.Next() {
+		var count model.BulkStatusUpdateReasonCount
+		if err := rows.Scan(&count.Reason, &count.UpdateCount, &count.EntryCount); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch bulk status update reason row: %v`, err)
+		}
+		counts = append(counts, &count)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestBulkStatusUpdatesAreListedMostRecentFirst(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+	otherUser := createIntegrationTestUser(t, store)
+
+	for _, section := range []string{"earlier", "last30d", "today"} {
+		update := &model.BulkStatusUpdate{UserID: user.ID, Section: section, Status: model.EntryStatusRead, EntryCount: 3}
+		if err := store.CreateBulkStatusUpdate(update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.CreateBulkStatusUpdate(&model.BulkStatusUpdate{UserID: otherUser.ID, Section: "all", Status: model.EntryStatusRemoved, EntryCount: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	updates, err := store.BulkStatusUpdates(user.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updates) != 2 {
+		t.Fatalf(`Expected the 2 most recent updates, got %d`, len(updates))
+	}
+
+	if updates[0].Section != "today" || updates[1].Section != "last30d" {
+		t.Errorf(`Unexpected order of the updates: %q, %q`, updates[0].Section, updates[1].Section)
+	}
+
+	if updates[0].EntryCount != 3 || updates[0].Status != model.EntryStatusRead || updates[0].UserID != user.ID {
+		t.Errorf(`Unexpected update %+v`, updates[0])
+	}
+}
+
+func TestBulkStatusUpdateReasonCounts(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	for _, update := range []*model.BulkStatusUpdate{
+		{UserID: user.ID, Section: "earlier", Status: model.EntryStatusRead, EntryCount: 40, Reason: "bankruptcy"},
+		{UserID: user.ID, Section: "today", Status: model.EntryStatusRead, EntryCount: 2, Reason: "reviewed"},
+		{UserID: user.ID, Section: "last2d", Status: model.EntryStatusRead, EntryCount: 5, Reason: "reviewed"},
+		{UserID: user.ID, Section: "all", Status: model.EntryStatusRead, EntryCount: 7},
+	} {
+		if err := store.CreateBulkStatusUpdate(update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts, err := store.BulkStatusUpdateReasonCounts(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counts) != 2 {
+		t.Fatalf(`Expected the 2 reasons, got %d`, len(counts))
+	}
+
+	if counts[0].Reason != "reviewed" || counts[0].UpdateCount != 2 || counts[0].EntryCount != 7 {
+		t.Errorf(`Unexpected most used reason %+v`, counts[0])
+	}
+
+	if counts[1].Reason != "bankruptcy" || counts[1].UpdateCount != 1 || counts[1].EntryCount != 40 {
+		t.Errorf(`Unexpected reason %+v`, counts[1])
+	}
+}
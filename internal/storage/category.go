@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/lib/pq"
+	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
 )
 
@@ -40,8 +41,8 @@ func (s *Storage) CategoryIDExists(userID, categoryID int64) bool {
 func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND id=$2`
-	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, share_code FROM categories WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ShareCode)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -53,6 +54,57 @@ func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 	}
 }
 
+// CUSTOM: CategoryByShareCode returns the category shared with the given code, nil when none is.
+func (s *Storage) CategoryByShareCode(shareCode string) (*model.Category, error) {
+	var category model.Category
+
+	query := `SELECT id, user_id, title, hide_globally, share_code FROM categories WHERE share_code=$1 AND share_code <> ''`
+	err := s.db.QueryRow(query, shareCode).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ShareCode)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch shared category: %v`, err)
+	default:
+		return &category, nil
+	}
+}
+
+// CUSTOM: CategoryShareCode returns the share code of the given category.
+// It generates a new one if not already defined.
+func (s *Storage) CategoryShareCode(userID, categoryID int64) (shareCode string, err error) {
+	query := `SELECT share_code FROM categories WHERE user_id=$1 AND id=$2`
+	err = s.db.QueryRow(query, userID, categoryID).Scan(&shareCode)
+	if err != nil {
+		err = fmt.Errorf(`store: unable to get share code for category #%d: %v`, categoryID, err)
+		return
+	}
+
+	if shareCode == "" {
+		shareCode = crypto.GenerateRandomStringHex(20)
+
+		query = `UPDATE categories SET share_code = $1 WHERE user_id=$2 AND id=$3`
+		_, err = s.db.Exec(query, shareCode, userID, categoryID)
+		if err != nil {
+			err = fmt.Errorf(`store: unable to set share code for category #%d: %v`, categoryID, err)
+			return
+		}
+	}
+
+	return
+}
+
+// CUSTOM: UnshareCategory removes the share code for the given category.
+func (s *Storage) UnshareCategory(userID, categoryID int64) (err error) {
+	query := `UPDATE categories SET share_code='' WHERE user_id=$1 AND id=$2`
+	_, err = s.db.Exec(query, userID, categoryID)
+	if err != nil {
+		err = fmt.Errorf(`store: unable to remove share code for category #%d: %v`, categoryID, err)
+	}
+	return
+}
+
 // FirstCategory returns the first category for the given user.
 func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
 	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
@@ -213,6 +265,8 @@ func (s *Storage) UpdateCategory(category *model.Category) error {
 		return fmt.Errorf(`store: unable to update category: %v`, err)
 	}
 
+	// CUSTOM: the date view only counts entries of categories that are not hidden globally
+	s.invalidateDateSectionCounts(category.UserID)
 	return nil
 }
 
@@ -233,6 +287,8 @@ func (s *Storage) RemoveCategory(userID, categoryID int64) error {
 		return errors.New(`store: no category has been removed`)
 	}
 
+	// CUSTOM: the entries of the category are removed with it
+	s.invalidateDateSectionCounts(userID)
 	return nil
 }
 
@@ -281,5 +337,8 @@ func (s *Storage) RemoveAndReplaceCategoriesByName(userid int64, titles []string
 		return fmt.Errorf("store: unable to delete categories: %v", err)
 	}
 	tx.Commit()
+
+	// CUSTOM: feeds moved to another category may change their global visibility
+	s.invalidateDateSectionCounts(userid)
 	return nil
 }
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"sync"
+	"time"
+)
+
+// CUSTOM: dateSectionCountCache keeps the date view section counts of each user for the current minute.
+// Counts are dropped for a user as soon as one of their entries changes status or new entries arrive.
+type dateSectionCountCache struct {
+	mu    sync.Mutex
+	users map[int64]*userDateSectionCounts
+}
+
+type userDateSectionCounts struct {
+	// generation is bumped on each invalidation so a count computed before it is never stored.
+	generation uint64
+	minute     time.Time
+	counts     map[string]int
+}
+
+func newDateSectionCountCache() *dateSectionCountCache {
+	return &dateSectionCountCache{users: make(map[int64]*userDateSectionCounts)}
+}
+
+// get returns the cached count, or the current generation of the user counts when the count must be computed.
+func (c *dateSectionCountCache) get(userID int64, minute time.Time, key string) (int, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userCounts, found := c.users[userID]
+	if !found {
+		return 0, 0, false
+	}
+
+	if userCounts.minute.Equal(minute) {
+		if count, found := userCounts.counts[key]; found {
+			return count, userCounts.generation, true
+		}
+	}
+
+	return 0, userCounts.generation, false
+}
+
+// set stores a count computed for the given generation, unless the user counts were invalidated meanwhile.
+func (c *dateSectionCountCache) set(userID int64, minute time.Time, key string, generation uint64, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userCounts, found := c.users[userID]
+	if !found {
+		userCounts = &userDateSectionCounts{}
+		c.users[userID] = userCounts
+	}
+
+	if userCounts.generation != generation {
+		return
+	}
+
+	// Counts of previous minutes are dropped, so the cache holds at most one minute per user.
+	if !userCounts.minute.Equal(minute) || userCounts.counts == nil {
+		userCounts.minute = minute
+		userCounts.counts = make(map[string]int)
+	}
+
+	userCounts.counts[key] = count
+}
+
+func (c *dateSectionCountCache) invalidate(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userCounts, found := c.users[userID]
+	if !found {
+		userCounts = &userDateSectionCounts{}
+		c.users[userID] = userCounts
+	}
+
+	userCounts.generation++
+	userCounts.minute = time.Time{}
+	userCounts.counts = nil
+}
+
+func (c *dateSectionCountCache) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, userCounts := range c.users {
+		userCounts.generation++
+		userCounts.minute = time.Time{}
+		userCounts.counts = nil
+	}
+}
+
+// CUSTOM: EnableDateSectionCountCache keeps the counts returned by DateSectionCount in memory.
+// It must be called before the storage is shared between goroutines.
+func (s *Storage) EnableDateSectionCountCache() {
+	s.dateSectionCounts = newDateSectionCountCache()
+}
+
+// CUSTOM: DateSectionCount returns the count identified by key for the minute of now, calling count
+// only when the cache is disabled or does not hold a fresh value.
+func (s *Storage) DateSectionCount(userID int64, now time.Time, key string, count func() (int, error)) (int, error) {
+	if s.dateSectionCounts == nil {
+		return count()
+	}
+
+	minute := now.Truncate(time.Minute)
+	cachedCount, generation, found := s.dateSectionCounts.get(userID, minute, key)
+	if found {
+		return cachedCount, nil
+	}
+
+	computedCount, err := count()
+	if err != nil {
+		return 0, err
+	}
+
+	s.dateSectionCounts.set(userID, minute, key, generation, computedCount)
+	return computedCount, nil
+}
+
+func (s *Storage) invalidateDateSectionCounts(userID int64) {
+	if s.dateSectionCounts != nil {
+		s.dateSectionCounts.invalidate(userID)
+	}
+}
+
+func (s *Storage) invalidateAllDateSectionCounts() {
+	if s.dateSectionCounts != nil {
+		s.dateSectionCounts.invalidateAll()
+	}
+}
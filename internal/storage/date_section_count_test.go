@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestDateSectionCountIsCachedForTheMinute(t *testing.T) {
+	store := &Storage{}
+	store.EnableDateSectionCountCache()
+
+	calls := 0
+	count := func() (int, error) {
+		calls++
+		return calls * 10, nil
+	}
+
+	now := time.Date(2025, time.March, 10, 12, 0, 5, 0, time.UTC)
+	for _, at := range []time.Time{now, now.Add(30 * time.Second)} {
+		result, err := store.DateSectionCount(1, at, "today", count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != 10 || calls != 1 {
+			t.Errorf(`Expected the cached count 10 after one query, got %d after %d queries`, result, calls)
+		}
+	}
+
+	// Another user or another key is counted on its own
+	if result, _ := store.DateSectionCount(2, now, "today", count); result != 20 {
+		t.Errorf(`Expected a fresh count for another user, got %d`, result)
+	}
+
+	if result, _ := store.DateSectionCount(1, now, "yesterday", count); result != 30 {
+		t.Errorf(`Expected a fresh count for another key, got %d`, result)
+	}
+
+	if result, _ := store.DateSectionCount(1, now.Add(time.Minute), "today", count); result != 40 {
+		t.Errorf(`Expected a fresh count in the next minute, got %d`, result)
+	}
+}
+
+func TestDateSectionCountIsRecomputedAfterInvalidation(t *testing.T) {
+	store := &Storage{}
+	store.EnableDateSectionCountCache()
+
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	store.DateSectionCount(1, now, "today", func() (int, error) { return 5, nil })
+	store.DateSectionCount(2, now, "today", func() (int, error) { return 7, nil })
+
+	store.invalidateDateSectionCounts(1)
+
+	if result, _ := store.DateSectionCount(1, now, "today", func() (int, error) { return 4, nil }); result != 4 {
+		t.Errorf(`Expected the count to be recomputed after the invalidation, got %d`, result)
+	}
+
+	if result, _ := store.DateSectionCount(2, now, "today", func() (int, error) { return 0, nil }); result != 7 {
+		t.Errorf(`Expected the counts of other users to stay cached, got %d`, result)
+	}
+}
+
+func TestDateSectionCountComputedDuringInvalidationIsNotCached(t *testing.T) {
+	store := &Storage{}
+	store.EnableDateSectionCountCache()
+
+	// Entries are marked as read while the count is being computed
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	result, _ := store.DateSectionCount(1, now, "today", func() (int, error) {
+		store.invalidateDateSectionCounts(1)
+		return 5, nil
+	})
+	if result != 5 {
+		t.Errorf(`Expected the computed count, got %d`, result)
+	}
+
+	if result, _ := store.DateSectionCount(1, now, "today", func() (int, error) { return 3, nil }); result != 3 {
+		t.Errorf(`Expected the stale count to be dropped, got %d`, result)
+	}
+}
+
+func TestDateSectionCountWithoutCache(t *testing.T) {
+	store := &Storage{}
+
+	calls := 0
+	count := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	store.DateSectionCount(1, now, "today", count)
+	if result, _ := store.DateSectionCount(1, now, "today", count); result != 2 {
+		t.Errorf(`Expected every count to be computed when the cache is disabled, got %d`, result)
+	}
+}
+
+func TestDateSectionCountFollowsGlobalVisibilityChanges(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	store.EnableDateSectionCountCache()
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	feed := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("First", now.Add(-time.Hour)),
+		newIntegrationTestEntry("Second", now.Add(-time.Hour)),
+	})
+
+	countToday := func() int {
+		t.Helper()
+		count, err := store.DateSectionCount(user.ID, now, "today", func() (int, error) {
+			builder := store.NewEntryQueryBuilder(user.ID)
+			builder.WithStatus(model.EntryStatusUnread)
+			builder.WithGloballyVisible()
+			builder.AfterPublishedDate(now.Add(-24 * time.Hour))
+			return builder.CountEntries()
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if count := countToday(); count != 2 {
+		t.Fatalf(`Expected 2 visible entries, got %d`, count)
+	}
+
+	// Hiding the feed changes the very next count, within the same minute
+	feed.HideGlobally = true
+	if err := store.UpdateFeed(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 0 {
+		t.Errorf(`Expected no visible entry once the feed is hidden, got %d`, count)
+	}
+
+	feed.HideGlobally = false
+	if err := store.UpdateFeed(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 2 {
+		t.Errorf(`Expected the entries to be visible again, got %d`, count)
+	}
+
+	// The same goes for the category of the feed
+	feed.Category.HideGlobally = true
+	if err := store.UpdateCategory(feed.Category); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 0 {
+		t.Errorf(`Expected no visible entry once the category is hidden, got %d`, count)
+	}
+}
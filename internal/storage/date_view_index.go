@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: DateViewIndexName is the index over (user_id, status, display_date) scanned by the date view sections,
+// the display date being the display date override of the entry or else the date of the age basis of its feed.
+const DateViewIndexName = "entries_user_status_display_date_idx"
+
+// DateViewIndexExists reports whether the index used by the date view sections exists.
+func (s *Storage) DateViewIndexExists() (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_indexes WHERE schemaname=current_schema() AND tablename='entries' AND indexname=$1
+		)
+	`
+	if err := s.db.QueryRow(query, DateViewIndexName).Scan(&exists); err != nil {
+		return false, fmt.Errorf(`store: unable to check the date view index: %v`, err)
+	}
+
+	return exists, nil
+}
+
+// DateViewIndexStatus reports whether the index used by the date view sections exists, its size and
+// how selective it is for the unread entries of the given user.
+func (s *Storage) DateViewIndexStatus(userID int64) (*model.DateViewIndexStatus, error) {
+	exists, err := s.DateViewIndexExists()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &model.DateViewIndexStatus{Name: DateViewIndexName, Exists: exists}
+	if exists {
+		if err := s.db.QueryRow(`SELECT pg_relation_size($1::regclass)`, DateViewIndexName).Scan(&status.Size); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch the size of the date view index: %v`, err)
+		}
+	}
+
+	query := `
+		SELECT
+			count(*) FILTER (WHERE status=$2),
+			count(*)
+		FROM
+			entries
+		WHERE
+			user_id=$1
+	`
+	if err := s.db.QueryRow(query, userID, model.EntryStatusUnread).Scan(&status.UnreadEntries, &status.TotalEntries); err != nil {
+		return nil, fmt.Errorf(`store: unable to count the entries of user #%d: %v`, userID, err)
+	}
+
+	// The row estimate of the planner is cheap to read, unlike an exact count over every user
+	var tableRows float64
+	if err := s.db.QueryRow(`SELECT reltuples FROM pg_class WHERE oid='entries'::regclass`).Scan(&tableRows); err != nil {
+		return nil, fmt.Errorf(`store: unable to estimate the number of entries: %v`, err)
+	}
+
+	status.Selectivity = dateViewIndexSelectivity(status.UnreadEntries, tableRows)
+	return status, nil
+}
+
+// dateViewIndexSelectivity returns the share of the estimated table rows matched by the given entries.
+// The estimate is negative for a table never analyzed and may lag behind, so the result is kept in [0, 1].
+func dateViewIndexSelectivity(entries int64, tableRows float64) float64 {
+	if entries <= 0 {
+		return 0
+	}
+
+	if tableRows < float64(entries) {
+		return 1
+	}
+	return float64(entries) / tableRows
+}
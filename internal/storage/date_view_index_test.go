@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import "testing"
+
+func TestDateViewIndexSelectivity(t *testing.T) {
+	scenarios := []struct {
+		entries   int64
+		tableRows float64
+		expected  float64
+	}{
+		{0, 1000, 0},
+		{250, 1000, 0.25},
+		{1000, 1000, 1},
+		// The table was never analyzed
+		{10, -1, 1},
+		// The estimate lags behind new entries
+		{1200, 1000, 1},
+	}
+
+	for _, scenario := range scenarios {
+		if result := dateViewIndexSelectivity(scenario.entries, scenario.tableRows); result != scenario.expected {
+			t.Errorf(`Unexpected selectivity for %d entries out of %v rows, got %v instead of %v`, scenario.entries, scenario.tableRows, result, scenario.expected)
+		}
+	}
+}
@@ -23,7 +23,8 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			duration
 		FROM
 			enclosures
 		WHERE
@@ -48,6 +49,7 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			&enclosure.Size,
 			&enclosure.MimeType,
 			&enclosure.MediaProgression,
+			&enclosure.Duration,
 		)
 
 		if err != nil {
@@ -69,7 +71,8 @@ func (s *Storage) GetEnclosuresForEntries(entryIDs []int64) (map[int64]model.Enc
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			duration
 		FROM
 			enclosures
 		WHERE
@@ -94,6 +97,7 @@ func (s *Storage) GetEnclosuresForEntries(entryIDs []int64) (map[int64]model.Enc
 			&enclosure.Size,
 			&enclosure.MimeType,
 			&enclosure.MediaProgression,
+			&enclosure.Duration,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("store: unable to scan enclosure row: %w", err)
@@ -114,7 +118,8 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			duration
 		FROM
 			enclosures
 		WHERE
@@ -133,6 +138,7 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 		&enclosure.Size,
 		&enclosure.MimeType,
 		&enclosure.MediaProgression,
+		&enclosure.Duration,
 	)
 
 	if err == sql.ErrNoRows {
@@ -152,9 +158,9 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 
 	query := `
 		INSERT INTO enclosures
-			(url, size, mime_type, entry_id, user_id, media_progression)
+			(url, size, mime_type, entry_id, user_id, media_progression, duration)
 		VALUES
-			($1, $2, $3, $4, $5, $6)
+			($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (user_id, entry_id, md5(url)) DO NOTHING
 		RETURNING
 			id
@@ -167,6 +173,7 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 		enclosure.EntryID,
 		enclosure.UserID,
 		enclosure.MediaProgression,
+		enclosure.Duration,
 	).Scan(&enclosure.ID); err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf(`store: unable to create enclosure: %w`, err)
 	}
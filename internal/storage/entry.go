@@ -8,10 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
 
 	"github.com/lib/pq"
 )
@@ -46,11 +49,8 @@ func (s *Storage) CountAllEntries() map[string]int64 {
 
 // CountUnreadEntries returns the number of unread entries.
 func (s *Storage) CountUnreadEntries(userID int64) int {
-	builder := s.NewEntryQueryBuilder(userID)
-	builder.WithStatus(model.EntryStatusUnread)
-	builder.WithGloballyVisible()
-
-	n, err := builder.CountEntries()
+	// CUSTOM: the disabled feeds are left out, like in the date view by default
+	n, err := s.CountGloballyVisibleUnreadEntries(userID, false)
 	if err != nil {
 		slog.Error("Unable to count unread entries",
 			slog.Int64("user_id", userID),
@@ -62,6 +62,187 @@ func (s *Storage) CountUnreadEntries(userID int64) int {
 	return n
 }
 
+// CUSTOM: CountGloballyVisibleUnreadEntries returns the number of unread entries shown on the unread page.
+// The sidebar counter and the date view total both use it, so they cannot disagree. Entries of disabled
+// feeds are left out unless includeDisabled is true, as the date view does by default.
+func (s *Storage) CountGloballyVisibleUnreadEntries(userID int64, includeDisabled bool) (int, error) {
+	builder := s.NewEntryQueryBuilder(userID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithGloballyVisible()
+	if !includeDisabled {
+		builder.WithoutDisabledFeeds()
+	}
+
+	return builder.CountEntries()
+}
+
+// CUSTOM: ReadingTimeMarkedReadSince returns the sum of the reading times, in minutes, of the entries marked as
+// read since the given date: how much the user read over the period.
+func (s *Storage) ReadingTimeMarkedReadSince(userID int64, since time.Time) (int, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(reading_time), 0)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND status=$2 AND read_at >= $3
+	`
+
+	var minutes int
+	if err := s.db.QueryRow(query, userID, model.EntryStatusRead, since).Scan(&minutes); err != nil {
+		return 0, fmt.Errorf(`store: unable to sum the reading time of entries marked as read since %s: %v`, since.Format(time.RFC3339), err)
+	}
+
+	return minutes, nil
+}
+
+// CUSTOM: CountEntriesMarkedReadSince returns the number of read entries marked as read since the given date.
+// Other changes, such as starring an entry read earlier, move the change date but not the read date.
+func (s *Storage) CountEntriesMarkedReadSince(userID int64, since time.Time) (int, error) {
+	query := `
+		SELECT
+			count(*)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND status=$2 AND read_at >= $3
+	`
+
+	var count int
+	if err := s.db.QueryRow(query, userID, model.EntryStatusRead, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to count entries marked as read since %s: %v`, since.Format(time.RFC3339), err)
+	}
+
+	return count, nil
+}
+
+// CUSTOM: DailyEntryCounts returns the number of globally visible entries published on each of the last days
+// in the timezone of the user, today included and oldest day first. Days without entries are counted as zero,
+// so there is always one count per day.
+func (s *Storage) DailyEntryCounts(userID int64, days int) ([]model.DailyEntryCount, error) {
+	tz, err := s.userTimezone(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := timezone.Now(tz)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	since := today.AddDate(0, 0, 1-days)
+
+	builder := s.NewEntryQueryBuilder(userID)
+	builder.WithGloballyVisible()
+	counts, err := builder.CountEntriesByDay(tz, since)
+	if err != nil {
+		return nil, err
+	}
+
+	countsByDay := make(map[string]int, len(counts))
+	for _, count := range counts {
+		countsByDay[count.Day.Format(time.DateOnly)] = count.Count
+	}
+
+	dailyCounts := make([]model.DailyEntryCount, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		dailyCounts = append(dailyCounts, model.DailyEntryCount{Day: day, Count: countsByDay[day.Format(time.DateOnly)]})
+	}
+
+	return dailyCounts, nil
+}
+
+// CUSTOM: EntryCountsByHourOfDay returns the number of entries published during each hour of the day,
+// from midnight to 11 PM in the timezone of the user, over the last days.
+func (s *Storage) EntryCountsByHourOfDay(userID int64, days int) ([24]int, error) {
+	var counts [24]int
+
+	tz, err := s.userTimezone(userID)
+	if err != nil {
+		return counts, err
+	}
+
+	query := `
+		SELECT
+			extract(hour from published_at AT TIME ZONE $2)::int AS hour, count(*)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND published_at >= $3
+		GROUP BY
+			hour
+	`
+	since := timezone.Now(tz).AddDate(0, 0, -days)
+	rows, err := s.db.Query(query, userID, tz, since)
+	if err != nil {
+		return counts, fmt.Errorf(`store: unable to count entries by hour of day: %v`, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hour, count int
+		if err := rows.Scan(&hour, &count); err != nil {
+			return counts, fmt.Errorf(`store: unable to fetch entry count by hour of day: %v`, err)
+		}
+		if hour >= 0 && hour < len(counts) {
+			counts[hour] = count
+		}
+	}
+
+	return counts, nil
+}
+
+// CUSTOM: EarlierEntryCountsByUser returns, for every user with such entries, the number of unread entries published
+// before the given time that the date view shows, the largest count first. The entries are placed by their display date, as in
+// the date view, and the entries of disabled or hidden feeds, or of the domains blocked by their user, are not counted.
+func (s *Storage) EarlierEntryCountsByUser(before time.Time) ([]*model.UserEarlierEntryCount, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			u.id, u.username, count(*) AS entry_count
+		FROM
+			entries e
+		JOIN
+			users u ON u.id=e.user_id
+		JOIN
+			feeds f ON f.id=e.feed_id
+		JOIN
+			categories c ON c.id=f.category_id
+		WHERE
+			e.status=$1 AND
+			%s < $2 AND
+			f.disabled IS FALSE AND
+			f.hide_globally IS FALSE AND
+			c.hide_globally IS FALSE AND
+			%s
+		GROUP BY
+			u.id, u.username
+		ORDER BY
+			entry_count DESC, u.id ASC
+	`, displayEntryDateExpression, withoutBlockedDomainsCondition)
+	rows, err := s.db.Query(query, model.EntryStatusUnread, before)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to count earlier entries by user: %v`, err)
+	}
+	defer rows.Close()
+
+	counts := make([]*model.UserEarlierEntryCount, 0)
+	for rows.Next() {
+		var count model.UserEarlierEntryCount
+		if err := rows.Scan(&count.UserID, &count.Username, &count.Count); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch earlier entry count: %v`, err)
+		}
+		counts = append(counts, &count)
+	}
+
+	return counts, nil
+}
+
+// userTimezone returns the timezone of the user, in which the entry dates are grouped.
+func (s *Storage) userTimezone(userID int64) (string, error) {
+	var tz string
+	if err := s.db.QueryRow(`SELECT timezone FROM users WHERE id=$1`, userID).Scan(&tz); err != nil {
+		return "", fmt.Errorf(`store: unable to fetch user timezone: %v`, err)
+	}
+	return tz, nil
+}
+
 // NewEntryQueryBuilder returns a new EntryQueryBuilder
 func (s *Storage) NewEntryQueryBuilder(userID int64) *EntryQueryBuilder {
 	return NewEntryQueryBuilder(s, userID)
@@ -77,7 +258,8 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 			title=$1,
 			content=$2,
 			reading_time=$3,
-			document_vectors = setweight(to_tsvector($4), 'A') || setweight(to_tsvector($5), 'B')
+			document_vectors = setweight(to_tsvector($4), 'A') || setweight(to_tsvector($5), 'B'),
+			scrape_status=$8
 		WHERE
 			id=$6 AND user_id=$7
 	`
@@ -90,7 +272,8 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 		truncatedTitle,
 		truncatedContent,
 		entry.ID,
-		entry.UserID); err != nil {
+		entry.UserID,
+		entry.ScrapeStatus); err != nil {
 		return fmt.Errorf(`store: unable to update entry #%d: %v`, entry.ID, err)
 	}
 
@@ -100,6 +283,7 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 // createEntry add a new entry.
 func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 	truncatedTitle, truncatedContent := truncateTitleAndContentForTSVectorField(entry.Title, entry.Content)
+	// The display date is that of the age basis of the feed: the entry has no display date override yet
 	query := `
 		INSERT INTO entries
 			(
@@ -115,7 +299,11 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				reading_time,
 				changed_at,
 				document_vectors,
-				tags
+				tags,
+				updated_at,
+				language,
+				scrape_status,
+				display_date
 			)
 		VALUES
 			(
@@ -123,7 +311,7 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$2,
 				$3,
 				$4,
-				$5,
+				COALESCE($5, now()),
 				$6,
 				$7,
 				$8,
@@ -131,10 +319,14 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$10,
 				now(),
 				setweight(to_tsvector($11), 'A') || setweight(to_tsvector($12), 'B'),
-				$13
+				$13,
+				$14,
+				$15,
+				$16,
+				(SELECT ` + ageBasisDateCase("f.age_basis", "now()", "COALESCE($5, now())", "$14") + ` FROM feeds f WHERE f.id=$9)
 			)
 		RETURNING
-			id, status, created_at, changed_at
+			id, status, created_at, changed_at, published_at
 	`
 	err := tx.QueryRow(
 		query,
@@ -142,7 +334,7 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.Hash,
 		entry.URL,
 		entry.CommentsURL,
-		entry.Date,
+		entryPublishedAt(entry),
 		entry.Content,
 		entry.Author,
 		entry.UserID,
@@ -151,11 +343,15 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		truncatedTitle,
 		truncatedContent,
 		pq.Array(entry.Tags),
+		entryUpdatedAt(entry),
+		entry.Language,
+		entry.ScrapeStatus,
 	).Scan(
 		&entry.ID,
 		&entry.Status,
 		&entry.CreatedAt,
 		&entry.ChangedAt,
+		&entry.Date,
 	)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create entry %q (feed #%d): %v`, entry.URL, entry.FeedID, err)
@@ -173,6 +369,17 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 	return nil
 }
 
+// CUSTOM: entryPublishedAt returns the publication date of the entry, NULL for an undated entry so it is
+// stored with its creation date: the date view sections compare published_at with their boundaries.
+func entryPublishedAt(entry *model.Entry) sql.NullTime {
+	return sql.NullTime{Time: entry.Date, Valid: !entry.Date.IsZero()}
+}
+
+// CUSTOM: entryUpdatedAt returns the modification date of the entry, NULL when the feed has none.
+func entryUpdatedAt(entry *model.Entry) sql.NullTime {
+	return sql.NullTime{Time: entry.UpdatedAt, Valid: !entry.UpdatedAt.IsZero()}
+}
+
 // updateEntry updates an entry when a feed is refreshed.
 // Note: we do not update the published date because some feeds do not contains any date,
 // it default to time.Now() which could change the order of items on the history page.
@@ -189,7 +396,14 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			author=$5,
 			reading_time=$6,
 			document_vectors = setweight(to_tsvector($7), 'A') || setweight(to_tsvector($8), 'B'),
-			tags=$12
+			tags=$12,
+			updated_at=COALESCE($13, updated_at),
+			language=$14,
+			scrape_status=$15,
+			display_date=COALESCE(
+				display_date_override,
+				(SELECT ` + ageBasisDateCase("f.age_basis", "entries.created_at", "entries.published_at", "COALESCE($13, entries.updated_at)") + ` FROM feeds f WHERE f.id=entries.feed_id)
+			)
 		WHERE
 			user_id=$9 AND feed_id=$10 AND hash=$11
 		RETURNING
@@ -209,6 +423,9 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(entry.Tags),
+		entryUpdatedAt(entry),
+		entry.Language,
+		entry.ScrapeStatus,
 	).Scan(&entry.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
@@ -261,6 +478,10 @@ func (s *Storage) GetReadTime(feedID int64, entryHash string) int {
 	return result
 }
 
+// CUSTOM: removedEntriesTrashRetention is how long the entries removed by the user keep their content and
+// enclosures, so they can be restored from the trash of the date view.
+const removedEntriesTrashRetention = "7 days"
+
 // cleanupRemovedEntriesNotInFeed deletes from the database entries marked as "removed" and not visible anymore in the feed.
 func (s *Storage) cleanupRemovedEntriesNotInFeed(feedID int64, entryHashes []string) error {
 	query := `
@@ -279,14 +500,15 @@ func (s *Storage) cleanupRemovedEntriesNotInFeed(feedID int64, entryHashes []str
 }
 
 // DeleteRemovedEntriesEnclosures deletes enclosures associated with entries marked as "removed".
+// CUSTOM: Entries removed by the user recently are kept whole in the trash of the date view, to be restored.
 func (s *Storage) DeleteRemovedEntriesEnclosures() (int64, error) {
 	query := `
 		DELETE FROM
 			enclosures
 		WHERE
-		 	enclosures.entry_id IN (SELECT id FROM entries WHERE status=$1)
+		 	enclosures.entry_id IN (SELECT id FROM entries WHERE status=$1 AND (removed_at IS NULL OR removed_at < now() - $2::interval))
 	`
-	result, err := s.db.Exec(query, model.EntryStatusRemoved)
+	result, err := s.db.Exec(query, model.EntryStatusRemoved, removedEntriesTrashRetention)
 	if err != nil {
 		return 0, fmt.Errorf(`store: unable to delete enclosures from removed entries: %v`, err)
 	}
@@ -300,6 +522,7 @@ func (s *Storage) DeleteRemovedEntriesEnclosures() (int64, error) {
 }
 
 // ClearRemovedEntriesContent clears the content fields of entries marked as "removed", keeping only their metadata.
+// CUSTOM: The content of the entries in the trash stays until the end of the retention.
 func (s *Storage) ClearRemovedEntriesContent(limit int) (int64, error) {
 	query := `
 		UPDATE
@@ -314,13 +537,13 @@ func (s *Storage) ClearRemovedEntriesContent(limit int) (int64, error) {
 		WHERE id IN (
 			SELECT id
 			FROM entries
-			WHERE status = $1 AND content IS NOT NULL
+			WHERE status = $1 AND content IS NOT NULL AND (removed_at IS NULL OR removed_at < now() - $3::interval)
 			ORDER BY id ASC
 			LIMIT $2
 		)
 	`
 
-	result, err := s.db.Exec(query, model.EntryStatusRemoved, limit)
+	result, err := s.db.Exec(query, model.EntryStatusRemoved, limit, removedEntriesTrashRetention)
 	if err != nil {
 		return 0, fmt.Errorf(`store: unable to clear content from removed entries: %v`, err)
 	}
@@ -379,6 +602,11 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 		entryHashes = append(entryHashes, entry.Hash)
 	}
 
+	// CUSTOM: new entries, and updated dates of existing ones, change the date view counts
+	if len(newEntries) > 0 || (updateExistingEntries && len(entries) > 0) {
+		s.invalidateDateSectionCounts(userID)
+	}
+
 	go func() {
 		if err := s.cleanupRemovedEntriesNotInFeed(feedID, entryHashes); err != nil {
 			slog.Error("Unable to cleanup removed entries",
@@ -431,9 +659,59 @@ func (s *Storage) ArchiveEntries(status string, interval time.Duration, limit in
 		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
 	}
 
+	// CUSTOM: archived unread entries leave the date view of any user
+	if count > 0 && status == model.EntryStatusUnread {
+		s.invalidateAllDateSectionCounts()
+	}
+
 	return count, nil
 }
 
+// CUSTOM: SetEntryOpenedAt records that the user viewed the entry page.
+func (s *Storage) SetEntryOpenedAt(userID, entryID int64) error {
+	query := `UPDATE entries SET opened_at=now() WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, entryID, userID); err != nil {
+		return fmt.Errorf(`store: unable to update the opening date of entry #%d: %v`, entryID, err)
+	}
+
+	// The opened recently section of the date view depends on the opening date
+	s.invalidateDateSectionCounts(userID)
+	return nil
+}
+
+// CUSTOM: SetEntryReadProgress records how far the user scrolled through the entry content.
+func (s *Storage) SetEntryReadProgress(userID, entryID int64, progress float64) error {
+	query := `UPDATE entries SET read_progress=$1, read_progress_updated_at=now() WHERE id=$2 AND user_id=$3`
+	if _, err := s.db.Exec(query, progress, entryID, userID); err != nil {
+		return fmt.Errorf(`store: unable to update the reading progress of entry #%d: %v`, entryID, err)
+	}
+
+	// The in progress section of the date view depends on the reading progress
+	s.invalidateDateSectionCounts(userID)
+	return nil
+}
+
+// CUSTOM: SetEntryDisplayDateOverride places the entry in the date view sections by the given date instead
+// of its publication date, or by its publication date again when the date is nil.
+func (s *Storage) SetEntryDisplayDateOverride(userID, entryID int64, displayDate *time.Time) error {
+	query := `
+		UPDATE
+			entries
+		SET
+			display_date_override=$1,
+			display_date=COALESCE($1, (SELECT ` + ageBasisDateExpression("entries", "f") + ` FROM feeds f WHERE f.id=entries.feed_id))
+		WHERE
+			id=$2 AND user_id=$3
+	`
+	if _, err := s.db.Exec(query, displayDate, entryID, userID); err != nil {
+		return fmt.Errorf(`store: unable to update the display date of entry #%d: %v`, entryID, err)
+	}
+
+	// The date view sections place entries by their display date
+	s.invalidateDateSectionCounts(userID)
+	return nil
+}
+
 // SetEntriesStatus update the status of the given list of entries.
 func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string) error {
 	// Entries that have the model.EntryStatusRemoved status are immutable.
@@ -442,19 +720,83 @@ func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string
 			entries
 		SET
 			status=$1,
+			removed_at=CASE WHEN $1=$4 THEN now() ELSE removed_at END,
+			read_at=CASE WHEN $1=$5 AND status!=$5 THEN now() ELSE read_at END,
 			changed_at=now()
 		WHERE
 			user_id=$2 AND
 			id=ANY($3) AND
 			status!=$4
 		`
-	if _, err := s.db.Exec(query, status, userID, pq.Array(entryIDs), model.EntryStatusRemoved); err != nil {
+	if _, err := s.db.Exec(query, status, userID, pq.Array(entryIDs), model.EntryStatusRemoved, model.EntryStatusRead); err != nil {
 		return fmt.Errorf(`store: unable to update entries statuses %v: %v`, entryIDs, err)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	return nil
 }
 
+// CUSTOM: RestoreRemovedEntries brings the given removed entries back as unread and returns how many were
+// restored. It is the only way out of the "removed" status, which SetEntriesStatus never changes.
+func (s *Storage) RestoreRemovedEntries(userID int64, entryIDs []int64) (int, error) {
+	query := `
+		UPDATE
+			entries
+		SET
+			status=$1,
+			removed_at=NULL,
+			changed_at=now()
+		WHERE
+			user_id=$2 AND
+			id=ANY($3) AND
+			status=$4
+	`
+	result, err := s.db.Exec(query, model.EntryStatusUnread, userID, pq.Array(entryIDs), model.EntryStatusRemoved)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to restore removed entries %v: %v`, entryIDs, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
+	count, _ := result.RowsAffected()
+	return int(count), nil
+}
+
+// CUSTOM: MarkLinkedEntriesAsRead marks as read the unread entries of the linked user sharing the hash of one of the
+// given read entries of the user, and returns the number of entries marked in the linked account. Both accounts
+// compute the same hash for an entry of a feed they are subscribed to, from its URL or its GUID.
+func (s *Storage) MarkLinkedEntriesAsRead(userID, linkedUserID int64, entryIDs []int64) (int, error) {
+	query := `
+		UPDATE
+			entries
+		SET
+			status=$1,
+			read_at=now(),
+			changed_at=now()
+		WHERE
+			user_id=$2 AND
+			status=$3 AND
+			hash IN (
+				SELECT
+					hash
+				FROM
+					entries
+				WHERE
+					user_id=$4 AND status=$1 AND id=ANY($5)
+			)
+	`
+	result, err := s.db.Exec(query, model.EntryStatusRead, linkedUserID, model.EntryStatusUnread, userID, pq.Array(entryIDs))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to mark the entries of the linked user #%d as read: %v`, linkedUserID, err)
+	}
+
+	s.invalidateDateSectionCounts(linkedUserID)
+
+	count, _ := result.RowsAffected()
+	return int(count), nil
+}
+
 func (s *Storage) SetEntriesStatusCount(userID int64, entryIDs []int64, status string) (int, error) {
 	if err := s.SetEntriesStatus(userID, entryIDs, status); err != nil {
 		return 0, err
@@ -481,7 +823,12 @@ func (s *Storage) SetEntriesStatusCount(userID int64, entryIDs []int64, status s
 
 // SetEntriesStarredState updates the starred state for the given list of entries.
 func (s *Storage) SetEntriesStarredState(userID int64, entryIDs []int64, starred bool) error {
-	query := `UPDATE entries SET starred=$1, changed_at=now() WHERE user_id=$2 AND id=ANY($3)`
+	// CUSTOM: entries starred again keep the date they were first starred
+	query := `
+		UPDATE entries
+		SET starred=$1, starred_at=CASE WHEN $1 THEN coalesce(starred_at, now()) ELSE NULL END, changed_at=now()
+		WHERE user_id=$2 AND id=ANY($3)
+	`
 	result, err := s.db.Exec(query, starred, userID, pq.Array(entryIDs))
 	if err != nil {
 		return fmt.Errorf(`store: unable to update the starred state %v: %v`, entryIDs, err)
@@ -496,12 +843,19 @@ func (s *Storage) SetEntriesStarredState(userID int64, entryIDs []int64, starred
 		return errors.New(`store: nothing has been updated`)
 	}
 
+	// The date view may list starred entries by the date they were starred
+	s.invalidateDateSectionCounts(userID)
+
 	return nil
 }
 
 // ToggleStarred toggles entry starred value.
 func (s *Storage) ToggleStarred(userID int64, entryID int64) error {
-	query := `UPDATE entries SET starred = NOT starred, changed_at=now() WHERE user_id=$1 AND id=$2`
+	query := `
+		UPDATE entries
+		SET starred = NOT starred, starred_at = CASE WHEN starred THEN NULL ELSE now() END, changed_at=now()
+		WHERE user_id=$1 AND id=$2
+	`
 	result, err := s.db.Exec(query, userID, entryID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to toggle starred flag for entry #%d: %v`, entryID, err)
@@ -516,6 +870,8 @@ func (s *Storage) ToggleStarred(userID int64, entryID int64) error {
 		return errors.New(`store: nothing has been updated`)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	return nil
 }
 
@@ -526,6 +882,7 @@ func (s *Storage) FlushHistory(userID int64) error {
 			entries
 		SET
 			status=$1,
+			removed_at=now(),
 			changed_at=now()
 		WHERE
 			user_id=$2 AND status=$3 AND starred is false AND share_code=''
@@ -540,12 +897,14 @@ func (s *Storage) FlushHistory(userID int64) error {
 
 // MarkAllAsRead updates all user entries to the read status.
 func (s *Storage) MarkAllAsRead(userID int64) error {
-	query := `UPDATE entries SET status=$1, changed_at=now() WHERE user_id=$2 AND status=$3`
+	query := `UPDATE entries SET status=$1, read_at=now(), changed_at=now() WHERE user_id=$2 AND status=$3`
 	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread)
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked all entries as read",
 		slog.Int64("user_id", userID),
@@ -562,6 +921,7 @@ func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) error
 			entries
 		SET
 			status=$1,
+			read_at=now(),
 			changed_at=now()
 		WHERE
 			user_id=$2 AND status=$3 AND published_at < $4
@@ -570,6 +930,8 @@ func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) error
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark all entries as read before %s: %v`, before.Format(time.RFC3339), err)
 	}
+
+	s.invalidateDateSectionCounts(userID)
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked all entries as read before date",
 		slog.Int64("user_id", userID),
@@ -586,6 +948,7 @@ func (s *Storage) MarkGloballyVisibleFeedsAsRead(userID int64) error {
 			entries
 		SET
 			status=$1,
+			read_at=now(),
 			changed_at=now()
 		FROM
 			feeds
@@ -600,6 +963,8 @@ func (s *Storage) MarkGloballyVisibleFeedsAsRead(userID int64) error {
 		return fmt.Errorf(`store: unable to mark globally visible feeds as read: %v`, err)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked globally visible feed entries as read",
 		slog.Int64("user_id", userID),
@@ -609,50 +974,271 @@ func (s *Storage) MarkGloballyVisibleFeedsAsRead(userID int64) error {
 	return nil
 }
 
-// CUSTOM: MarkEntriesAsReadInDateRange marks entries as read within a date range for globally visible feeds.
-func (s *Storage) MarkEntriesAsReadInDateRange(userID int64, afterDate, beforeDate *time.Time) error {
+// CUSTOM: MarkEntriesInDateRange changes the status of unread entries within a date range for globally visible
+// feeds and categories. The range bounds are both exclusive, like the date view queries of the EntryQueryBuilder.
+func (s *Storage) MarkEntriesInDateRange(userID int64, update *model.DateRangeStatusUpdate) (int, error) {
+	query, args := dateRangeStatusUpdateQuery(userID, update, []model.DateRange{{After: update.After, Before: update.Before}})
+
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to mark entries as %s in date range: %v`, update.Status, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
+	count, _ := result.RowsAffected()
+	slog.Debug("Marked entries in date range",
+		slog.Int64("user_id", userID),
+		slog.String("status", update.Status),
+		slog.Int64("nb_entries", count),
+		slog.Any("after_date", update.After),
+		slog.Any("before_date", update.Before),
+		slog.Any("created_as_of", update.CreatedAsOf),
+		slog.Bool("keep_starred", update.KeepStarred),
+		slog.Bool("include_disabled", update.IncludeDisabled),
+		slog.Bool("use_latest_date", update.UseLatestDate),
+		slog.Bool("skip_errored_feeds", update.SkipErroredFeeds),
+		slog.String("except_search_query", update.ExceptSearchQuery),
+	)
+
+	return int(count), nil
+}
+
+// CUSTOM: MarkEntriesInDateRanges changes the status of unread entries within any of the date ranges, such as
+// sections that do not follow each other, with a single UPDATE. The bounds of the update are not used, the
+// ranges replace them. It returns the IDs of the entries changed across all the ranges.
+func (s *Storage) MarkEntriesInDateRanges(userID int64, update *model.DateRangeStatusUpdate, ranges []model.DateRange) ([]int64, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	query, args := dateRangeStatusUpdateQuery(userID, update, ranges)
+	rows, err := s.db.Query(query+" RETURNING entries.id", args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to mark entries as %s in date ranges: %v`, update.Status, err)
+	}
+	defer rows.Close()
+
+	var entryIDs []int64
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch the entries marked as %s in date ranges: %v`, update.Status, err)
+		}
+		entryIDs = append(entryIDs, entryID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the entries marked as %s in date ranges: %v`, update.Status, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
+	slog.Debug("Marked entries in date ranges",
+		slog.Int64("user_id", userID),
+		slog.String("status", update.Status),
+		slog.Int("nb_entries", len(entryIDs)),
+		slog.Int("nb_ranges", len(ranges)),
+		slog.Any("created_as_of", update.CreatedAsOf),
+	)
+
+	return entryIDs, nil
+}
+
+// dateRangeStatusUpdateQuery returns the UPDATE changing the status of the unread entries within any of the
+// date ranges, along with its arguments.
+func dateRangeStatusUpdateQuery(userID int64, update *model.DateRangeStatusUpdate, ranges []model.DateRange) (string, []any) {
 	query := `
 		UPDATE
 			entries
 		SET
 			status=$1,
+			removed_at=CASE WHEN $1='removed' THEN now() ELSE NULL END,
+			read_at=CASE WHEN $1='read' THEN now() ELSE read_at END,
 			changed_at=now()
 		FROM
 			feeds
+		JOIN
+			categories ON categories.id = feeds.category_id
 		WHERE
 			entries.feed_id = feeds.id
 			AND entries.user_id=$2
 			AND entries.status=$3
 			AND feeds.hide_globally=$4
+			AND categories.hide_globally=$4
 	`
-	args := []interface{}{model.EntryStatusRead, userID, model.EntryStatusUnread, false}
+	args := []any{update.Status, userID, model.EntryStatusUnread, false}
 	argIndex := 5
 
-	if afterDate != nil {
-		query += fmt.Sprintf(" AND entries.published_at >= $%d", argIndex)
-		args = append(args, *afterDate)
+	if update.KeepStarred {
+		query += " AND entries.starred IS FALSE"
+	}
+
+	if !update.IncludeDisabled {
+		query += " AND feeds.disabled IS FALSE"
+	}
+
+	if update.SkipErroredFeeds {
+		query += " AND feeds.parsing_error_count = 0"
+	}
+
+	dateExpression := "entries.display_date"
+	if update.UseLatestDate {
+		dateExpression = "COALESCE(entries.display_date_override, GREATEST(entries.published_at, entries.updated_at))"
+	}
+
+	// A range open on both sides holds every entry, the other ranges do not matter then
+	if slices.ContainsFunc(ranges, func(dateRange model.DateRange) bool { return dateRange.After == nil && dateRange.Before == nil }) {
+		ranges = nil
+	}
+
+	// (date > after1 AND date < before1) OR (date > after2 AND date < before2) OR ...
+	rangeConditions := make([]string, 0, len(ranges))
+	for _, dateRange := range ranges {
+		var bounds []string
+		if dateRange.After != nil {
+			bounds = append(bounds, fmt.Sprintf("%s > $%d", dateExpression, argIndex))
+			args = append(args, *dateRange.After)
+			argIndex++
+		}
+
+		if dateRange.Before != nil {
+			bounds = append(bounds, fmt.Sprintf("%s < $%d", dateExpression, argIndex))
+			args = append(args, *dateRange.Before)
+			argIndex++
+		}
+		rangeConditions = append(rangeConditions, "("+strings.Join(bounds, " AND ")+")")
+	}
+
+	if len(rangeConditions) > 0 {
+		query += " AND (" + strings.Join(rangeConditions, " OR ") + ")"
+	}
+
+	if update.CreatedAsOf != nil {
+		query += fmt.Sprintf(" AND entries.created_at <= $%d", argIndex)
+		args = append(args, *update.CreatedAsOf)
 		argIndex++
 	}
 
-	if beforeDate != nil {
-		query += fmt.Sprintf(" AND entries.published_at < $%d", argIndex)
-		args = append(args, *beforeDate)
+	// Entries without search vectors match no query, so they are changed
+	if update.ExceptSearchQuery != "" {
+		query += fmt.Sprintf(" AND NOT COALESCE(entries.document_vectors @@ plainto_tsquery($%d), false)", argIndex)
+		args = append(args, update.ExceptSearchQuery)
 	}
 
-	result, err := s.db.Exec(query, args...)
+	return query, args
+}
+
+// CUSTOM: MarkEntriesReadKeepingSample marks the given unread entries as read, except for a random sample of
+// keepPercentage percent of them, rounded up. It returns the number of entries marked as read and kept unread.
+func (s *Storage) MarkEntriesReadKeepingSample(userID int64, entryIDs []int64, keepPercentage int) (marked, kept int, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	// The entries are locked so a concurrent change cannot slip between the sample and the update
+	query := `
+		SELECT
+			id
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND id=ANY($2) AND status=$3
+		FOR UPDATE
+	`
+	rows, err := tx.Query(query, userID, pq.Array(entryIDs), model.EntryStatusUnread)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, fmt.Errorf(`store: unable to lock entries: %v`, err)
+	}
+
+	candidateIDs := make([]int64, 0, len(entryIDs))
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, 0, fmt.Errorf(`store: unable to fetch entry row: %v`, err)
+		}
+		candidateIDs = append(candidateIDs, entryID)
+	}
+	rows.Close()
+
+	// A cursor failing midway would otherwise shrink the candidates, and the sample with them
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return 0, 0, fmt.Errorf(`store: unable to lock entries: %v`, err)
+	}
+
+	keepCount := (len(candidateIDs)*keepPercentage + 99) / 100
+
+	query = `
+		WITH kept AS (
+			SELECT id FROM unnest($2::bigint[]) AS id ORDER BY random() LIMIT $4
+		)
+		UPDATE
+			entries
+		SET
+			status=$3,
+			read_at=now(),
+			changed_at=now()
+		WHERE
+			user_id=$1 AND id=ANY($2) AND id NOT IN (SELECT id FROM kept)
+	`
+	result, err := tx.Exec(query, userID, pq.Array(candidateIDs), model.EntryStatusRead, keepCount)
 	if err != nil {
-		return fmt.Errorf(`store: unable to mark entries as read in date range: %v`, err)
+		tx.Rollback()
+		return 0, 0, fmt.Errorf(`store: unable to mark entries as read: %v`, err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf(`store: unable to commit entries status: %v`, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
 	count, _ := result.RowsAffected()
-	slog.Debug("Marked entries as read in date range",
-		slog.Int64("user_id", userID),
-		slog.Int64("nb_entries", count),
-		slog.Any("after_date", afterDate),
-		slog.Any("before_date", beforeDate),
-	)
+	return int(count), len(candidateIDs) - int(count), nil
+}
 
-	return nil
+// CUSTOM: MarkReadKeepNewestPerFeed marks the unread entries of the globally visible feeds as read, except for the
+// keepPerFeed most recently published entries of each feed. It returns the number of entries marked as read.
+func (s *Storage) MarkReadKeepNewestPerFeed(userID int64, keepPerFeed int) (int, error) {
+	query := `
+		WITH ranked AS (
+			SELECT
+				e.id,
+				ROW_NUMBER() OVER (PARTITION BY e.feed_id ORDER BY e.published_at DESC, e.id DESC) AS position
+			FROM
+				entries e
+			JOIN
+				feeds f ON f.id=e.feed_id
+			JOIN
+				categories c ON c.id=f.category_id
+			WHERE
+				e.user_id=$1 AND
+				e.status=$2 AND
+				f.hide_globally IS FALSE AND
+				c.hide_globally IS FALSE
+		)
+		UPDATE
+			entries
+		SET
+			status=$3,
+			read_at=now(),
+			changed_at=now()
+		WHERE
+			user_id=$1 AND id IN (SELECT id FROM ranked WHERE position > $4)
+	`
+	result, err := s.db.Exec(query, userID, model.EntryStatusUnread, model.EntryStatusRead, keepPerFeed)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to mark entries as read keeping the newest of each feed: %v`, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
+	count, _ := result.RowsAffected()
+	return int(count), nil
 }
 
 // MarkFeedAsRead updates all feed entries to the read status.
@@ -662,6 +1248,7 @@ func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 			entries
 		SET
 			status=$1,
+			read_at=now(),
 			changed_at=now()
 		WHERE
 			user_id=$2 AND feed_id=$3 AND status=$4 AND published_at < $5
@@ -671,6 +1258,8 @@ func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 		return fmt.Errorf(`store: unable to mark feed entries as read: %v`, err)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked feed entries as read",
 		slog.Int64("user_id", userID),
@@ -689,6 +1278,7 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 			entries
 		SET
 			status=$1,
+			read_at=now(),
 			changed_at=now()
 		FROM
 			feeds
@@ -708,6 +1298,8 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 		return fmt.Errorf(`store: unable to mark category entries as read: %v`, err)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked category entries as read",
 		slog.Int64("user_id", userID),
@@ -719,6 +1311,50 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 	return nil
 }
 
+// CUSTOM: MarkCategoryEntriesAsRead marks every unread entry of the category as read, whatever its date.
+// Entries hidden from the global views, by their feed or by the category itself, are left unread.
+func (s *Storage) MarkCategoryEntriesAsRead(userID, categoryID int64) error {
+	query := `
+		UPDATE
+			entries e
+		SET
+			status=$1,
+			read_at=now(),
+			changed_at=now()
+		FROM
+			feeds f
+		JOIN
+			categories c ON c.id = f.category_id
+		WHERE
+			e.feed_id=f.id
+		AND
+			e.user_id=$2
+		AND
+			e.status=$3
+		AND
+			f.category_id=$4
+		AND
+			f.hide_globally IS FALSE
+		AND
+			c.hide_globally IS FALSE
+	`
+	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread, categoryID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to mark category entries as read: %v`, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
+	count, _ := result.RowsAffected()
+	slog.Debug("Marked all category entries as read",
+		slog.Int64("user_id", userID),
+		slog.Int64("category_id", categoryID),
+		slog.Int64("nb_entries", count),
+	)
+
+	return nil
+}
+
 // EntryShareCode returns the share code of the provided entry.
 // It generates a new one if not already defined.
 func (s *Storage) EntryShareCode(userID int64, entryID int64) (shareCode string, err error) {
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// CUSTOM: EntryLabels returns the sorted labels the user gave to at least one entry.
+func (s *Storage) EntryLabels(userID int64) ([]string, error) {
+	query := `
+		SELECT DISTINCT
+			l.label
+		FROM
+			entry_labels l
+		JOIN
+			entries e ON (e.id = l.entry_id)
+		WHERE
+			e.user_id=$1
+		ORDER BY
+			l.label ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entry labels: %v`, err)
+	}
+	defer rows.Close()
+
+	labels := make([]string, 0)
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry label row: %v`, err)
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// CUSTOM: SetEntryLabels replaces the labels of the given entry, an empty list removes them all.
+func (s *Storage) SetEntryLabels(userID, entryID int64, labels []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.New("store: unable to begin transaction")
+	}
+
+	query := `DELETE FROM entry_labels WHERE entry_id IN (SELECT id FROM entries WHERE user_id=$1 AND id=$2)`
+	if _, err := tx.Exec(query, userID, entryID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove labels of entry #%d: %v`, entryID, err)
+	}
+
+	query = `
+		INSERT INTO entry_labels
+			(entry_id, label)
+		SELECT
+			e.id, l.label
+		FROM
+			entries e, unnest($3::text[]) AS l(label)
+		WHERE
+			e.user_id=$1 AND e.id=$2
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(query, userID, entryID, pq.Array(labels)); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to add labels to entry #%d: %v`, entryID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit labels of entry #%d: %v`, entryID, err)
+	}
+
+	// The date view may be filtered by label
+	s.invalidateDateSectionCounts(userID)
+
+	return nil
+}
@@ -6,6 +6,7 @@ package storage // import "miniflux.app/v2/internal/storage"
 import (
 	"database/sql"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +20,7 @@ import (
 // EntryQueryBuilder builds a SQL query to fetch entries.
 type EntryQueryBuilder struct {
 	store           *Storage
+	userID          int64
 	args            []any
 	conditions      []string
 	sortExpressions []string
@@ -49,6 +51,16 @@ func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
 	return e
 }
 
+// CUSTOM: WithoutSearchQuery leaves out the entries matching the full-text search query, the opposite of
+// WithSearchQuery. Entries without search vectors never match, so they are kept.
+func (e *EntryQueryBuilder) WithoutSearchQuery(query string) *EntryQueryBuilder {
+	if query != "" {
+		e.conditions = append(e.conditions, fmt.Sprintf("NOT COALESCE(e.document_vectors @@ plainto_tsquery($%d), false)", len(e.args)+1))
+		e.args = append(e.args, query)
+	}
+	return e
+}
+
 // WithStarred adds starred filter.
 func (e *EntryQueryBuilder) WithStarred(starred bool) *EntryQueryBuilder {
 	if starred {
@@ -73,6 +85,20 @@ func (e *EntryQueryBuilder) AfterChangedDate(date time.Time) *EntryQueryBuilder
 	return e
 }
 
+// AfterCreatedDate adds a condition > created_at
+func (e *EntryQueryBuilder) AfterCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.created_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: NotCreatedAfter adds a condition <= created_at.
+func (e *EntryQueryBuilder) NotCreatedAfter(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.created_at <= $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
 // BeforePublishedDate adds a condition < published_at
 func (e *EntryQueryBuilder) BeforePublishedDate(date time.Time) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, "e.published_at < $"+strconv.Itoa(len(e.args)+1))
@@ -87,6 +113,183 @@ func (e *EntryQueryBuilder) AfterPublishedDate(date time.Time) *EntryQueryBuilde
 	return e
 }
 
+// CUSTOM: WithDateViewVisibility keeps the entries the date view shows whatever its filters: the globally
+// visible ones outside of the blocked domains, of enabled feeds unless includeDisabled is true. The API and the
+// pages of the date view share it so their counts agree.
+func (e *EntryQueryBuilder) WithDateViewVisibility(includeDisabled bool) *EntryQueryBuilder {
+	e.WithGloballyVisible()
+	e.WithoutBlockedDomains()
+	if !includeDisabled {
+		e.WithoutDisabledFeeds()
+	}
+	return e
+}
+
+// CUSTOM: WithoutBlockedDomains leaves out the entries whose URL host is, or is a subdomain of, one of the
+// domains the user blocked in the date view. Entries without a host in their URL are kept.
+func (e *EntryQueryBuilder) WithoutBlockedDomains() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, withoutBlockedDomainsCondition)
+	return e
+}
+
+// CUSTOM: withoutBlockedDomainsCondition is true when the URL host of the entry is not one of the domains,
+// or a subdomain of one, blocked by its user in the date view.
+const withoutBlockedDomainsCondition = `NOT EXISTS (
+		SELECT 1
+		FROM users bu, jsonb_array_elements_text(bu.date_view_blocked_domains) AS blocked(domain)
+		WHERE bu.id = e.user_id AND (` + entryURLHostExpression + ` = blocked.domain OR ` + entryURLHostExpression + ` LIKE '%.' || blocked.domain)
+	)`
+
+// CUSTOM: AfterOpenedDate adds a condition > opened_at, which leaves out entries never opened.
+func (e *EntryQueryBuilder) AfterOpenedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.opened_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: AfterFeedCreatedDate adds a condition > created_at on the feeds, keeping the entries of the feeds subscribed to since then.
+func (e *EntryQueryBuilder) AfterFeedCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "f.created_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: WithOpened keeps the entries the user opened at least once, or the ones never opened.
+func (e *EntryQueryBuilder) WithOpened(opened bool) *EntryQueryBuilder {
+	if opened {
+		e.conditions = append(e.conditions, "e.opened_at IS NOT NULL")
+	} else {
+		e.conditions = append(e.conditions, "e.opened_at IS NULL")
+	}
+	return e
+}
+
+// CUSTOM: AfterStarredDate adds a condition > starred_at, which leaves out entries not starred.
+func (e *EntryQueryBuilder) AfterStarredDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.starred_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: BeforeStarredDate adds a condition < starred_at, which leaves out entries not starred.
+func (e *EntryQueryBuilder) BeforeStarredDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.starred_at < $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: AfterRemovedDate adds a condition > removed_at, which leaves out entries the user did not remove.
+func (e *EntryQueryBuilder) AfterRemovedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.removed_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: BeforeRemovedDate adds a condition < removed_at, which leaves out entries the user did not remove.
+func (e *EntryQueryBuilder) BeforeRemovedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.removed_at < $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: AfterUpdatedDate adds a condition > updated_at, which leaves out entries without a modification date.
+func (e *EntryQueryBuilder) AfterUpdatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.updated_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: BeforeUpdatedDate adds a condition < updated_at, which leaves out entries without a modification date.
+func (e *EntryQueryBuilder) BeforeUpdatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.updated_at < $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: WithReadingInProgress keeps entries the user started reading without reaching the end.
+func (e *EntryQueryBuilder) WithReadingInProgress() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.read_progress > 0 AND e.read_progress < 1")
+	return e
+}
+
+// CUSTOM: entryURLHostExpression is the lowercase host of the entry URL, without its user information
+// and port, or an empty string when the URL has no host.
+const entryURLHostExpression = `COALESCE(lower(substring(e.url from '^[a-zA-Z][a-zA-Z0-9+.-]*://(?:[^/?#@]*@)?([^/?#:]+)')), '')`
+
+// CUSTOM: displayEntryDateExpression is the date placing the entry in the date view sections: the display
+// date override set by the user, or else the date chosen by the age basis of the feed. It is stored in the
+// display_date column, kept up to date by the storage, so the section bounds can use the date view index.
+const displayEntryDateExpression = "e.display_date"
+
+// CUSTOM: ageBasisDateExpression returns the date of the entry selected by the age basis of its feed, given
+// the aliases of the entries and feeds tables.
+func ageBasisDateExpression(entries, feeds string) string {
+	return ageBasisDateCase(feeds+".age_basis", entries+".created_at", entries+".published_at", entries+".updated_at")
+}
+
+// CUSTOM: ageBasisDateCase returns the date selected by the given age basis among the given dates of an entry.
+// GREATEST ignores the NULL modification date of entries whose feed does not provide one.
+func ageBasisDateCase(ageBasis, createdAt, publishedAt, updatedAt string) string {
+	return fmt.Sprintf(
+		"CASE %s WHEN '%s' THEN %s WHEN '%s' THEN GREATEST(%s, %s) ELSE %s END",
+		ageBasis, model.FeedAgeBasisCreated, createdAt, model.FeedAgeBasisUpdated, publishedAt, updatedAt, publishedAt,
+	)
+}
+
+// CUSTOM: BeforeDisplayDate adds a condition < displayEntryDateExpression
+func (e *EntryQueryBuilder) BeforeDisplayDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, displayEntryDateExpression+" < $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: AfterDisplayDate adds a condition > displayEntryDateExpression
+func (e *EntryQueryBuilder) AfterDisplayDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, displayEntryDateExpression+" > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: WithinDateSection restricts the builder to the window of the section, placing entries by their display
+// date, or by latestEntryDateExpression when useLatestDate is true.
+func (e *EntryQueryBuilder) WithinDateSection(section model.DateSection, useLatestDate bool) *EntryQueryBuilder {
+	if section.After != nil {
+		if useLatestDate {
+			e.AfterLatestDate(*section.After)
+		} else {
+			e.AfterDisplayDate(*section.After)
+		}
+	}
+
+	if section.Before != nil {
+		if useLatestDate {
+			e.BeforeLatestDate(*section.Before)
+		} else {
+			e.BeforeDisplayDate(*section.Before)
+		}
+	}
+	return e
+}
+
+// CUSTOM: latestEntryDateExpression is the most recent of the publication and modification dates, unless
+// the user overrode the display date. GREATEST ignores the NULL modification date of entries whose feed
+// does not provide one.
+const latestEntryDateExpression = "COALESCE(e.display_date_override, GREATEST(e.published_at, e.updated_at))"
+
+// CUSTOM: BeforeLatestDate adds a condition < latestEntryDateExpression
+func (e *EntryQueryBuilder) BeforeLatestDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, latestEntryDateExpression+" < $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// CUSTOM: AfterLatestDate adds a condition > latestEntryDateExpression
+func (e *EntryQueryBuilder) AfterLatestDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, latestEntryDateExpression+" > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
 // BeforeEntryID adds a condition < entryID.
 func (e *EntryQueryBuilder) BeforeEntryID(entryID int64) *EntryQueryBuilder {
 	if entryID != 0 {
@@ -112,6 +315,13 @@ func (e *EntryQueryBuilder) WithEntryIDs(entryIDs []int64) *EntryQueryBuilder {
 	return e
 }
 
+// CUSTOM: WithoutEntryIDs excludes the given entries.
+func (e *EntryQueryBuilder) WithoutEntryIDs(entryIDs []int64) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.id <> ALL($%d)", len(e.args)+1))
+	e.args = append(e.args, pq.Int64Array(entryIDs))
+	return e
+}
+
 // WithEntryID filter by entry ID.
 func (e *EntryQueryBuilder) WithEntryID(entryID int64) *EntryQueryBuilder {
 	if entryID != 0 {
@@ -130,6 +340,13 @@ func (e *EntryQueryBuilder) WithFeedID(feedID int64) *EntryQueryBuilder {
 	return e
 }
 
+// CUSTOM: WithFeedIDs filter by a list of feed IDs.
+func (e *EntryQueryBuilder) WithFeedIDs(feedIDs []int64) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.feed_id = ANY($%d)", len(e.args)+1))
+	e.args = append(e.args, pq.Int64Array(feedIDs))
+	return e
+}
+
 // WithCategoryID filter by category ID.
 func (e *EntryQueryBuilder) WithCategoryID(categoryID int64) *EntryQueryBuilder {
 	if categoryID > 0 {
@@ -139,6 +356,20 @@ func (e *EntryQueryBuilder) WithCategoryID(categoryID int64) *EntryQueryBuilder
 	return e
 }
 
+// CUSTOM: WithCategoryIDs keeps entries of the feeds in any of the given categories.
+func (e *EntryQueryBuilder) WithCategoryIDs(categoryIDs []int64) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("f.category_id = ANY($%d)", len(e.args)+1))
+	e.args = append(e.args, pq.Int64Array(categoryIDs))
+	return e
+}
+
+// CUSTOM: WithoutCategoryIDs leaves out entries of the feeds in any of the given categories.
+func (e *EntryQueryBuilder) WithoutCategoryIDs(categoryIDs []int64) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("NOT (f.category_id = ANY($%d))", len(e.args)+1))
+	e.args = append(e.args, pq.Int64Array(categoryIDs))
+	return e
+}
+
 // WithStatus filter by entry status.
 func (e *EntryQueryBuilder) WithStatus(status string) *EntryQueryBuilder {
 	if status != "" {
@@ -190,12 +421,49 @@ func (e *EntryQueryBuilder) WithShareCodeNotEmpty() *EntryQueryBuilder {
 	return e
 }
 
+// CUSTOM: WithoutShareCode excludes the entries that are currently shared.
+func (e *EntryQueryBuilder) WithoutShareCode() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.share_code = ''")
+	return e
+}
+
+// CUSTOM: WithoutModificationsSinceFetch excludes the entries the feed announced as modified after they were
+// fetched. A minute of leeway keeps the entries whose modification date merely duplicates the fetch time.
+func (e *EntryQueryBuilder) WithoutModificationsSinceFetch() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "(e.updated_at IS NULL OR e.updated_at <= e.created_at + interval '1 minute')")
+	return e
+}
+
 // WithSorting add a sort expression.
 func (e *EntryQueryBuilder) WithSorting(column, direction string) *EntryQueryBuilder {
 	e.sortExpressions = append(e.sortExpressions, column+" "+direction)
 	return e
 }
 
+// WithStableSorting adds a sort expression followed by the entry ID in the same direction.
+// Rows sharing the same sort value keep a deterministic order across paginated queries.
+func (e *EntryQueryBuilder) WithStableSorting(column, direction string) *EntryQueryBuilder {
+	e.WithSorting(column, direction)
+	if column != "id" {
+		e.WithSorting("id", direction)
+	}
+	return e
+}
+
+// CUSTOM: WithStableTiebreakSorting works like WithStableSorting, but the rows sharing the same sort value are
+// sorted alphabetically by the given tiebreak order before the entry ID. An unknown tiebreak leaves the ties to the ID.
+func (e *EntryQueryBuilder) WithStableTiebreakSorting(column, tiebreak, direction string) *EntryQueryBuilder {
+	tiebreakColumn, found := entryTiebreakColumns[tiebreak]
+	if !found || column == "id" {
+		return e.WithStableSorting(column, direction)
+	}
+
+	e.WithSorting(column, direction)
+	e.WithSorting(tiebreakColumn, "ASC")
+	e.WithSorting("id", direction)
+	return e
+}
+
 // WithLimit set the limit.
 func (e *EntryQueryBuilder) WithLimit(limit int) *EntryQueryBuilder {
 	if limit > 0 {
@@ -218,6 +486,199 @@ func (e *EntryQueryBuilder) WithGloballyVisible() *EntryQueryBuilder {
 	return e
 }
 
+// CUSTOM: WithPriorityFeeds keeps entries from the feeds the user marked as priority.
+func (e *EntryQueryBuilder) WithPriorityFeeds() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "f.priority IS TRUE")
+	return e
+}
+
+// CUSTOM: WithPinnedFeedsFirst sorts entries from the feeds the user pinned ahead of the others,
+// it must be called before the other sorting methods.
+func (e *EntryQueryBuilder) WithPinnedFeedsFirst() *EntryQueryBuilder {
+	e.WithSorting("f.pinned", "DESC")
+	return e
+}
+
+// CUSTOM: WithManualFeedsFirst sorts entries from the feeds the user subscribed to by hand ahead of the others,
+// it must be called after WithPinnedFeedsFirst and before the other sorting methods.
+func (e *EntryQueryBuilder) WithManualFeedsFirst() *EntryQueryBuilder {
+	e.WithSorting(manualFeedExpression, "DESC")
+	return e
+}
+
+// CUSTOM: WithFeedSource keeps entries of the feeds subscribed to from the given source.
+func (e *EntryQueryBuilder) WithFeedSource(source string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "f.source = $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, source)
+	return e
+}
+
+// CUSTOM: manualFeedExpression tells whether the entry feed was subscribed to by hand.
+const manualFeedExpression = "(f.source = '" + model.FeedSourceManual + "')"
+
+// CUSTOM: entrySortColumns maps the entry sorting orders to the columns they sort by, as conditions refer to them.
+var entrySortColumns = map[string]string{
+	"id":             "e.id",
+	"status":         "e.status",
+	"changed_at":     "e.changed_at",
+	"published_at":   "e.published_at",
+	"created_at":     "e.created_at",
+	"category_title": "c.title",
+	"category_id":    "f.category_id",
+	"title":          "e.title",
+	"author":         "e.author",
+}
+
+// CUSTOM: entryTiebreakColumns maps the tiebreak orders to the columns they sort by.
+var entryTiebreakColumns = map[string]string{
+	model.EntryTiebreakTitle:     "e.title",
+	model.EntryTiebreakFeedTitle: "f.title",
+}
+
+// CUSTOM: AfterCursor adds the keyset pagination condition keeping the entries sorted after the cursor.
+// The entries must be sorted with WithStableSorting, or WithStableTiebreakSorting when the cursor holds a tiebreak,
+// by the same order and direction, after WithPinnedFeedsFirst
+// and WithManualFeedsFirst when the cursor holds the pinned and manual flags. Unlike an offset, the condition stays cheap deep into the listing.
+func (e *EntryQueryBuilder) AfterCursor(cursor *model.EntryCursor, order, direction string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, e.cursorCondition(cursor, order, direction))
+	return e
+}
+
+// CUSTOM: UpToCursor keeps the entries listed up to, and including, the entry at the cursor in the given sorting
+// order, the entries AfterCursor leaves out.
+func (e *EntryQueryBuilder) UpToCursor(cursor *model.EntryCursor, order, direction string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "NOT ("+e.cursorCondition(cursor, order, direction)+")")
+	return e
+}
+
+// cursorCondition returns the condition keeping the entries listed after the cursor, and adds its arguments.
+func (e *EntryQueryBuilder) cursorCondition(cursor *model.EntryCursor, order, direction string) string {
+	type sortKey struct {
+		column    string
+		direction string
+		value     any
+	}
+
+	var keys []sortKey
+	if cursor.Pinned != nil {
+		keys = append(keys, sortKey{"f.pinned", "desc", *cursor.Pinned})
+	}
+	if cursor.Manual != nil {
+		keys = append(keys, sortKey{manualFeedExpression, "desc", *cursor.Manual})
+	}
+	if column, found := entrySortColumns[order]; found && order != "id" {
+		keys = append(keys, sortKey{column, direction, cursor.SortValue})
+		if tiebreakColumn, found := entryTiebreakColumns[cursor.Tiebreak]; found {
+			keys = append(keys, sortKey{tiebreakColumn, "asc", cursor.TiebreakValue})
+		}
+	}
+	keys = append(keys, sortKey{"e.id", direction, cursor.EntryID})
+
+	// (k1 > v1 OR (k1 = v1 AND (k2 > v2 OR (k2 = v2 AND ...)))), with < for descending keys
+	condition := ""
+	for i := len(keys) - 1; i >= 0; i-- {
+		e.args = append(e.args, keys[i].value)
+		placeholder := "$" + strconv.Itoa(len(e.args))
+
+		operator := ">"
+		if strings.EqualFold(keys[i].direction, "desc") {
+			operator = "<"
+		}
+
+		if condition == "" {
+			condition = keys[i].column + " " + operator + " " + placeholder
+		} else {
+			condition = "(" + keys[i].column + " " + operator + " " + placeholder + " OR (" + keys[i].column + " = " + placeholder + " AND " + condition + "))"
+		}
+	}
+
+	return condition
+}
+
+// CUSTOM: WithoutDisabledFeeds excludes entries from disabled feeds.
+func (e *EntryQueryBuilder) WithoutDisabledFeeds() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "f.disabled IS FALSE")
+	return e
+}
+
+// CUSTOM: WithoutErroredFeeds excludes entries from the feeds that failed to be parsed.
+func (e *EntryQueryBuilder) WithoutErroredFeeds() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "f.parsing_error_count = 0")
+	return e
+}
+
+// CUSTOM: WithScrapedContent keeps the entries whose content was replaced by the scraper.
+func (e *EntryQueryBuilder) WithScrapedContent() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.scrape_status = $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, model.EntryScrapeStatusSucceeded)
+	return e
+}
+
+// CUSTOM: WithLanguage keeps entries in the given language, including its regional variants: "de" matches "de-at".
+func (e *EntryQueryBuilder) WithLanguage(language string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("(e.language = $%d OR e.language LIKE $%d || '-%%')", len(e.args)+1, len(e.args)+1))
+	e.args = append(e.args, language)
+	return e
+}
+
+// CUSTOM: WithEntryLabel keeps entries the user gave the given label.
+func (e *EntryQueryBuilder) WithEntryLabel(label string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.id IN (SELECT entry_id FROM entry_labels WHERE label = $"+strconv.Itoa(len(e.args)+1)+")")
+	e.args = append(e.args, label)
+	return e
+}
+
+// CUSTOM: WithFeedTag keeps entries of feeds carrying the given tag.
+func (e *EntryQueryBuilder) WithFeedTag(tag string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.feed_id IN (SELECT feed_id FROM feed_tags WHERE tag = $"+strconv.Itoa(len(e.args)+1)+")")
+	e.args = append(e.args, tag)
+	return e
+}
+
+// CUSTOM: WithMinReadingTime keeps entries whose reading time is at least the given number of minutes.
+func (e *EntryQueryBuilder) WithMinReadingTime(minutes int) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.reading_time >= $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, minutes)
+	return e
+}
+
+// CUSTOM: WithMaxReadingTime keeps entries whose reading time is at most the given number of minutes.
+func (e *EntryQueryBuilder) WithMaxReadingTime(minutes int) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.reading_time <= $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, minutes)
+	return e
+}
+
+// CUSTOM: WithEnclosureMimeTypePrefix keeps entries having an enclosure whose MIME type starts with the prefix.
+func (e *EntryQueryBuilder) WithEnclosureMimeTypePrefix(prefix string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id = e.id AND en.mime_type LIKE $%d || '%%')", len(e.args)+1))
+	e.args = append(e.args, prefix)
+	return e
+}
+
+// CUSTOM: WithEnclosureDuration keeps entries having an enclosure of a known duration within the given range in
+// seconds, both inclusive. A zero bound leaves that side of the range open.
+func (e *EntryQueryBuilder) WithEnclosureDuration(minSeconds, maxSeconds int) *EntryQueryBuilder {
+	condition := "en.duration > 0"
+	if minSeconds > 0 {
+		e.args = append(e.args, minSeconds)
+		condition += " AND en.duration >= $" + strconv.Itoa(len(e.args))
+	}
+	if maxSeconds > 0 {
+		e.args = append(e.args, maxSeconds)
+		condition += " AND en.duration <= $" + strconv.Itoa(len(e.args))
+	}
+	e.conditions = append(e.conditions, "EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id = e.id AND "+condition+")")
+	return e
+}
+
+// CUSTOM: WithoutEnclosureMimeTypePrefix excludes entries having an enclosure whose MIME type starts with the prefix.
+func (e *EntryQueryBuilder) WithoutEnclosureMimeTypePrefix(prefix string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id = e.id AND en.mime_type LIKE $%d || '%%')", len(e.args)+1))
+	e.args = append(e.args, prefix)
+	return e
+}
+
 // CountEntries count the number of entries that match the condition.
 func (e *EntryQueryBuilder) CountEntries() (count int, err error) {
 	query := `
@@ -235,6 +696,193 @@ func (e *EntryQueryBuilder) CountEntries() (count int, err error) {
 	return count, nil
 }
 
+// CUSTOM: AverageEntryAge returns the average age at the given date of the publication dates of the entries that
+// match the condition, zero when none does.
+func (e *EntryQueryBuilder) AverageEntryAge(now time.Time) (time.Duration, error) {
+	query := `
+		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM ($` + strconv.Itoa(len(e.args)+1) + `::timestamptz - e.published_at))), 0)
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE ` + e.buildCondition()
+
+	var seconds float64
+	if err := e.store.db.QueryRow(query, append(slices.Clip(e.args), now)...).Scan(&seconds); err != nil {
+		return 0, fmt.Errorf("store: unable to compute the average age of entries: %v", err)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// CUSTOM: TotalReadingTime returns the sum of the reading times, in minutes, of the entries that match the condition.
+func (e *EntryQueryBuilder) TotalReadingTime() (int, error) {
+	query := `
+		SELECT COALESCE(SUM(e.reading_time), 0)
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE ` + e.buildCondition()
+
+	var minutes int
+	if err := e.store.db.QueryRow(query, e.args...).Scan(&minutes); err != nil {
+		return 0, fmt.Errorf("store: unable to sum the reading time of entries: %v", err)
+	}
+
+	return minutes, nil
+}
+
+// CUSTOM: UpdateStatus changes the status of the entries that match the condition with a single UPDATE and
+// returns the number of entries changed. Removed entries keep their status, like with SetEntriesStatus.
+func (e *EntryQueryBuilder) UpdateStatus(status string) (int, error) {
+	statusPlaceholder := "$" + strconv.Itoa(len(e.args)+1)
+	removedPlaceholder := "$" + strconv.Itoa(len(e.args)+2)
+	readPlaceholder := "$" + strconv.Itoa(len(e.args)+3)
+	query := `
+		UPDATE
+			entries
+		SET
+			status=` + statusPlaceholder + `,
+			removed_at=CASE WHEN ` + statusPlaceholder + `=` + removedPlaceholder + ` THEN now() ELSE removed_at END,
+			read_at=CASE WHEN ` + statusPlaceholder + `=` + readPlaceholder + ` AND status!=` + readPlaceholder + ` THEN now() ELSE read_at END,
+			changed_at=now()
+		WHERE
+			status!=` + removedPlaceholder + ` AND
+			id IN (
+				SELECT e.id
+				FROM entries e
+					JOIN feeds f ON f.id = e.feed_id
+					JOIN categories c ON c.id = f.category_id
+				WHERE ` + e.buildCondition() + `
+			)`
+
+	result, err := e.store.db.Exec(query, append(slices.Clip(e.args), status, model.EntryStatusRemoved, model.EntryStatusRead)...)
+	if err != nil {
+		return 0, fmt.Errorf("store: unable to update the status of entries: %v", err)
+	}
+
+	e.store.invalidateDateSectionCounts(e.userID)
+
+	count, _ := result.RowsAffected()
+	return int(count), nil
+}
+
+// CUSTOM: CountEntriesByMonth counts the entries that match the condition for each calendar month
+// of their display date in the given timezone, newest month first. The most recent of the
+// publication and modification dates is used instead when useLatestDate is true.
+func (e *EntryQueryBuilder) CountEntriesByMonth(tz string, useLatestDate bool) ([]model.MonthlyEntryCount, error) {
+	dateExpression := displayEntryDateExpression
+	if useLatestDate {
+		dateExpression = latestEntryDateExpression
+	}
+
+	query := fmt.Sprintf(`
+		SELECT to_char(date_trunc('month', %s AT TIME ZONE $%d), 'YYYY-MM') AS month, count(*)
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE %s
+		GROUP BY month
+		ORDER BY month DESC
+	`, dateExpression, len(e.args)+1, e.buildCondition())
+
+	rows, err := e.store.db.Query(query, append(slices.Clip(e.args), tz)...)
+	if err != nil {
+		return nil, fmt.Errorf("store: unable to count entries by month: %v", err)
+	}
+	defer rows.Close()
+
+	location := timezone.Now(tz).Location()
+	var counts []model.MonthlyEntryCount
+	for rows.Next() {
+		var month string
+		var count int
+		if err := rows.Scan(&month, &count); err != nil {
+			return nil, fmt.Errorf("store: unable to fetch entry count by month: %v", err)
+		}
+
+		start, err := time.ParseInLocation("2006-01", month, location)
+		if err != nil {
+			return nil, fmt.Errorf("store: unable to parse entry month %q: %v", month, err)
+		}
+
+		counts = append(counts, model.MonthlyEntryCount{Month: start, Count: count})
+	}
+
+	return counts, nil
+}
+
+// CUSTOM: CountEntriesByDay counts the entries that match the condition and were published since the given
+// date for each calendar day of their publication date in the given timezone, oldest day first.
+// Days without entries are left out.
+func (e *EntryQueryBuilder) CountEntriesByDay(tz string, since time.Time) ([]model.DailyEntryCount, error) {
+	query := fmt.Sprintf(`
+		SELECT to_char(date_trunc('day', e.published_at AT TIME ZONE $%d), 'YYYY-MM-DD') AS day, count(*)
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE %s AND e.published_at >= $%d
+		GROUP BY day
+		ORDER BY day ASC
+	`, len(e.args)+1, e.buildCondition(), len(e.args)+2)
+
+	rows, err := e.store.db.Query(query, append(slices.Clip(e.args), tz, since)...)
+	if err != nil {
+		return nil, fmt.Errorf("store: unable to count entries by day: %v", err)
+	}
+	defer rows.Close()
+
+	location := timezone.Now(tz).Location()
+	var counts []model.DailyEntryCount
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("store: unable to fetch entry count by day: %v", err)
+		}
+
+		start, err := time.ParseInLocation("2006-01-02", day, location)
+		if err != nil {
+			return nil, fmt.Errorf("store: unable to parse entry day %q: %v", day, err)
+		}
+
+		counts = append(counts, model.DailyEntryCount{Day: start, Count: count})
+	}
+
+	return counts, nil
+}
+
+// CUSTOM: CountEntriesByFeed counts the entries that match the condition for each feed having at least
+// minCount of them, most entries first.
+func (e *EntryQueryBuilder) CountEntriesByFeed(minCount int) ([]*model.FeedUnreadCount, error) {
+	query := fmt.Sprintf(`
+		SELECT f.id, f.title, count(*) AS entry_count
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE %s
+		GROUP BY f.id, f.title
+		HAVING count(*) >= $%d
+		ORDER BY entry_count DESC, f.id ASC
+	`, e.buildCondition(), len(e.args)+1)
+
+	rows, err := e.store.db.Query(query, append(slices.Clip(e.args), minCount)...)
+	if err != nil {
+		return nil, fmt.Errorf("store: unable to count entries by feed: %v", err)
+	}
+	defer rows.Close()
+
+	counts := make([]*model.FeedUnreadCount, 0)
+	for rows.Next() {
+		var count model.FeedUnreadCount
+		if err := rows.Scan(&count.FeedID, &count.FeedTitle, &count.UnreadCount); err != nil {
+			return nil, fmt.Errorf("store: unable to fetch entry count by feed: %v", err)
+		}
+		counts = append(counts, &count)
+	}
+
+	return counts, nil
+}
+
 // GetEntry returns a single entry that match the condition.
 func (e *EntryQueryBuilder) GetEntry() (*model.Entry, error) {
 	e.limit = 1
@@ -276,6 +924,13 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			e.created_at,
 			e.changed_at,
 			e.tags,
+			e.language,
+			e.opened_at,
+			e.read_progress,
+			e.display_date_override,
+			ARRAY(SELECT l.label FROM entry_labels l WHERE l.entry_id=e.id ORDER BY l.label) AS labels,
+			e.starred_at,
+			e.scrape_status,
 			f.title as feed_title,
 			f.feed_url,
 			f.site_url,
@@ -292,6 +947,8 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			f.hide_globally,
 			f.no_media_player,
 			f.webhook_url,
+			f.pinned,
+			f.source,
 			fi.icon_id,
 			i.external_id AS icon_external_id,
 			u.timezone
@@ -322,6 +979,9 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 	for rows.Next() {
 		var iconID sql.NullInt64
 		var externalIconID sql.NullString
+		var openedAt sql.NullTime
+		var displayDateOverride sql.NullTime
+		var starredAt sql.NullTime
 		var tz string
 
 		entry := model.NewEntry()
@@ -344,6 +1004,13 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.CreatedAt,
 			&entry.ChangedAt,
 			pq.Array(&entry.Tags),
+			&entry.Language,
+			&openedAt,
+			&entry.ReadProgress,
+			&displayDateOverride,
+			pq.Array(&entry.Labels),
+			&starredAt,
+			&entry.ScrapeStatus,
 			&entry.Feed.Title,
 			&entry.Feed.FeedURL,
 			&entry.Feed.SiteURL,
@@ -360,6 +1027,8 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.Feed.HideGlobally,
 			&entry.Feed.NoMediaPlayer,
 			&entry.Feed.WebhookURL,
+			&entry.Feed.Pinned,
+			&entry.Feed.Source,
 			&iconID,
 			&externalIconID,
 			&tz,
@@ -383,6 +1052,21 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 		entry.ChangedAt = timezone.Convert(tz, entry.ChangedAt)
 		entry.Feed.CheckedAt = timezone.Convert(tz, entry.Feed.CheckedAt)
 
+		if openedAt.Valid {
+			entryOpenedAt := timezone.Convert(tz, openedAt.Time)
+			entry.OpenedAt = &entryOpenedAt
+		}
+
+		if displayDateOverride.Valid {
+			entryDisplayDateOverride := timezone.Convert(tz, displayDateOverride.Time)
+			entry.DisplayDateOverride = &entryDisplayDateOverride
+		}
+
+		if starredAt.Valid {
+			entryStarredAt := timezone.Convert(tz, starredAt.Time)
+			entry.StarredAt = &entryStarredAt
+		}
+
 		entry.Feed.ID = entry.FeedID
 		entry.Feed.UserID = entry.UserID
 		entry.Feed.Icon.FeedID = entry.FeedID
@@ -420,6 +1104,10 @@ func (e *EntryQueryBuilder) GetEntryIDs() ([]int64, error) {
 			feeds f
 		ON
 			f.id=e.feed_id
+		LEFT JOIN
+			categories c
+		ON
+			c.id=f.category_id
 		WHERE ` + e.buildCondition() + " " + e.buildSorting()
 
 	rows, err := e.store.db.Query(query, e.args...)
@@ -469,6 +1157,7 @@ func (e *EntryQueryBuilder) buildSorting() string {
 func NewEntryQueryBuilder(store *Storage, userID int64) *EntryQueryBuilder {
 	return &EntryQueryBuilder{
 		store:      store,
+		userID:     userID,
 		args:       []any{userID},
 		conditions: []string{"e.user_id = $1"},
 	}
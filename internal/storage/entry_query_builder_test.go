@@ -0,0 +1,757 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestEntryQueryBuilderStableSortingEndsWithTiebreaker(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithStableSorting("status", "asc")
+	builder.WithLimit(10)
+	builder.WithOffset(20)
+
+	expected := " ORDER BY status asc, id asc LIMIT 10 OFFSET 20"
+	if result := builder.buildSorting(); result != expected {
+		t.Errorf(`Unexpected sorting clause, got %q instead of %q`, result, expected)
+	}
+
+	builder = NewEntryQueryBuilder(nil, 1)
+	builder.WithStableSorting("id", "desc")
+
+	expected = " ORDER BY id desc"
+	if result := builder.buildSorting(); result != expected {
+		t.Errorf(`Unexpected sorting clause when sorting by ID, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderEnclosureMimeTypePrefixConditions(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithEnclosureMimeTypePrefix("audio/")
+	builder.WithoutEnclosureMimeTypePrefix("video/")
+
+	expected := "e.user_id = $1 AND EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id = e.id AND en.mime_type LIKE $2 || '%') AND NOT EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id = e.id AND en.mime_type LIKE $3 || '%')"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if len(builder.args) != 3 || builder.args[1] != "audio/" || builder.args[2] != "video/" {
+		t.Errorf(`Unexpected arguments: %v`, builder.args)
+	}
+}
+
+func TestEntryQueryBuilderLanguageCondition(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithLanguage("de")
+
+	expected := "e.user_id = $1 AND (e.language = $2 OR e.language LIKE $2 || '-%')"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if len(builder.args) != 2 || builder.args[1] != "de" {
+		t.Errorf(`Unexpected arguments: %v`, builder.args)
+	}
+}
+
+func TestEntryQueryBuilderWithFeedTag(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithFeedTag("news")
+
+	expected := "e.user_id = $1 AND e.feed_id IN (SELECT feed_id FROM feed_tags WHERE tag = $2)"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if len(builder.args) != 2 || builder.args[1] != "news" {
+		t.Errorf(`Unexpected arguments: %v`, builder.args)
+	}
+}
+
+func TestEntryQueryBuilderStarredDateConditions(t *testing.T) {
+	after := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, 0, 7)
+
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.AfterStarredDate(after)
+	builder.BeforeStarredDate(before)
+
+	expected := "e.user_id = $1 AND e.starred_at > $2 AND e.starred_at < $3"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderReadingTimeConditions(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithMinReadingTime(5)
+	builder.WithMaxReadingTime(15)
+
+	expected := "e.user_id = $1 AND e.reading_time >= $2 AND e.reading_time <= $3"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if len(builder.args) != 3 || builder.args[1] != 5 || builder.args[2] != 15 {
+		t.Errorf(`Unexpected arguments: %v`, builder.args)
+	}
+}
+
+func TestEntryQueryBuilderAfterOpenedDateCondition(t *testing.T) {
+	openedAfter := time.Date(2025, time.March, 9, 12, 0, 0, 0, time.UTC)
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.AfterOpenedDate(openedAfter)
+
+	expected := "e.user_id = $1 AND e.status = $2 AND e.opened_at > $3"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderAfterCursorCondition(t *testing.T) {
+	pinned := true
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.AfterCursor(&model.EntryCursor{Pinned: &pinned, SortValue: "2025-03-10T12:00:00Z", EntryID: 42}, "published_at", "asc")
+
+	expected := "e.user_id = $1 AND (f.pinned < $4 OR (f.pinned = $4 AND (e.published_at > $3 OR (e.published_at = $3 AND e.id > $2))))"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if len(builder.args) != 4 || builder.args[1] != int64(42) || builder.args[2] != "2025-03-10T12:00:00Z" || builder.args[3] != true {
+		t.Errorf(`Unexpected arguments: %v`, builder.args)
+	}
+
+	// Sorting by ID leaves the entry ID as the only key
+	builder = NewEntryQueryBuilder(nil, 1)
+	builder.AfterCursor(&model.EntryCursor{SortValue: "42", EntryID: 42}, "id", "desc")
+
+	expected = "e.user_id = $1 AND e.id < $2"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition when sorting by ID, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderWithEnclosureDuration(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithEnclosureDuration(0, 1800)
+
+	expected := "e.user_id = $1 AND EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id = e.id AND en.duration > 0 AND en.duration <= $2)"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderAfterCursorConditionWithTiebreak(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithStableTiebreakSorting("published_at", model.EntryTiebreakFeedTitle, "desc")
+	builder.AfterCursor(&model.EntryCursor{SortValue: "2025-03-10T12:00:00Z", Tiebreak: model.EntryTiebreakFeedTitle, TiebreakValue: "News", EntryID: 42}, "published_at", "desc")
+
+	expected := "e.user_id = $1 AND (e.published_at < $4 OR (e.published_at = $4 AND (f.title > $3 OR (f.title = $3 AND e.id < $2))))"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if expected := " ORDER BY published_at desc, f.title ASC, id desc"; builder.buildSorting() != expected {
+		t.Errorf(`Unexpected sorting, got %q instead of %q`, builder.buildSorting(), expected)
+	}
+}
+
+func TestEntryQueryBuilderUpToCursorCondition(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.UpToCursor(&model.EntryCursor{SortValue: "2025-03-10T12:00:00Z", EntryID: 42}, "published_at", "desc")
+
+	expected := "e.user_id = $1 AND NOT ((e.published_at < $3 OR (e.published_at = $3 AND e.id < $2)))"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+
+	if !slices.Equal(builder.args, []any{int64(1), int64(42), "2025-03-10T12:00:00Z"}) {
+		t.Errorf(`Unexpected arguments, got %v`, builder.args)
+	}
+}
+
+func TestEntryQueryBuilderAfterCursorConditionWithManualFeedsFirst(t *testing.T) {
+	pinned, manual := false, true
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.AfterCursor(&model.EntryCursor{Pinned: &pinned, Manual: &manual, SortValue: "42", EntryID: 42}, "id", "asc")
+
+	expected := "e.user_id = $1 AND (f.pinned < $4 OR (f.pinned = $4 AND ((f.source = 'manual') < $3 OR ((f.source = 'manual') = $3 AND e.id > $2))))"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderWithFeedSource(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithFeedSource(model.FeedSourceManual)
+
+	expected := "e.user_id = $1 AND f.source = $2"
+	if result := builder.buildCondition(); result != expected {
+		t.Errorf(`Unexpected condition, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestEntryQueryBuilderPaginationIsStableWithIdenticalSortValues(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	// All entries share the same published date, so only the ID tiebreaker orders them.
+	publishedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var entries model.Entries
+	for i := range 25 {
+		entries = append(entries, newIntegrationTestEntry(fmt.Sprintf("Entry %d", i), publishedAt))
+	}
+	createIntegrationTestFeed(t, store, user.ID, entries)
+
+	for _, direction := range []string{"asc", "desc"} {
+		fetchPage := func(limit, offset int) model.Entries {
+			builder := store.NewEntryQueryBuilder(user.ID)
+			builder.WithStatus(model.EntryStatusUnread)
+			builder.WithStableSorting("published_at", direction)
+			builder.WithLimit(limit)
+			builder.WithOffset(offset)
+			page, err := builder.GetEntries()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return page
+		}
+
+		all := fetchPage(0, 0)
+		if len(all) != len(entries) {
+			t.Fatalf(`Expected %d entries, got %d`, len(entries), len(all))
+		}
+
+		// Two overlapping pages must agree on the entries they share.
+		first := fetchPage(10, 0)
+		second := fetchPage(10, 5)
+		for i := 5; i < 10; i++ {
+			if first[i].ID != second[i-5].ID {
+				t.Errorf(`Overlapping pages disagree at position %d (%s): %d != %d`, i, direction, first[i].ID, second[i-5].ID)
+			}
+		}
+
+		// Consecutive pages must cover every entry exactly once, in the order of the full fetch.
+		seen := make(map[int64]bool)
+		var paged model.Entries
+		for offset := 0; offset < len(all); offset += 7 {
+			for _, entry := range fetchPage(7, offset) {
+				if seen[entry.ID] {
+					t.Errorf(`Entry #%d returned twice (%s)`, entry.ID, direction)
+				}
+				seen[entry.ID] = true
+				paged = append(paged, entry)
+			}
+		}
+
+		if len(paged) != len(all) {
+			t.Fatalf(`Expected %d paginated entries, got %d (%s)`, len(all), len(paged), direction)
+		}
+
+		for i := range all {
+			if all[i].ID != paged[i].ID {
+				t.Errorf(`Paginated order differs at position %d (%s): %d != %d`, i, direction, paged[i].ID, all[i].ID)
+			}
+		}
+	}
+}
+
+func TestEntryQueryBuilderDateSectionCountsCoverSeededEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+	feed := createIntegrationTestFeed(t, store, user.ID, nil)
+
+	// Spread entries over twice the span of the default sections so every section, including earlier, gets some.
+	now := time.Now()
+	seedIntegrationTestEntries(t, store, user.ID, feed.ID, 600, now.Add(-60*24*time.Hour), now)
+
+	total := 0
+	for _, section := range model.NewDateSections(model.DateSectionSchemeDefault, now) {
+		count := countIntegrationTestDateSectionEntries(t, store, user.ID, section)
+		if count == 0 {
+			t.Errorf(`Expected seeded entries in the %q section`, section.Name)
+		}
+		total += count
+	}
+
+	// Entries published exactly on a boundary are excluded by the strict comparisons, so allow a small gap.
+	if total > 600 || total < 600-len(model.NewDateSections(model.DateSectionSchemeDefault, now)) {
+		t.Errorf(`Expected the sections to cover the 600 seeded entries, got %d`, total)
+	}
+}
+
+func TestEntryQueryBuilderCountEntriesByPublishedMonth(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+	feed := createIntegrationTestFeed(t, store, user.ID, nil)
+
+	// 10 entries on each of the 30 days of November 2024, in UTC (the default user timezone).
+	from := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	seedIntegrationTestEntries(t, store, user.ID, feed.ID, 300, from, from.AddDate(0, 1, 0))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("December", time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC)),
+	})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	counts, err := builder.CountEntriesByMonth(user.Timezone, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counts) != 2 {
+		t.Fatalf(`Expected 2 months, got %d`, len(counts))
+	}
+
+	if counts[0].Month.Month() != time.December || counts[0].Count != 1 {
+		t.Errorf(`Unexpected newest month count: %v`, counts[0])
+	}
+
+	if counts[1].Month.Month() != time.November || counts[1].Count != 300 {
+		t.Errorf(`Unexpected oldest month count: %v`, counts[1])
+	}
+}
+
+func TestEntryQueryBuilderGetEntryIDsWithGloballyVisible(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	entry := newIntegrationTestEntry("Visible", time.Now().Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{entry})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithGloballyVisible()
+	builder.WithEntryIDs([]int64{entry.ID})
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryIDs) != 1 || entryIDs[0] != entry.ID {
+		t.Fatalf(`Expected only the entry #%d, got %v`, entry.ID, entryIDs)
+	}
+}
+
+func TestEntryQueryBuilderLatestDateUsesModificationDate(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	edited := newIntegrationTestEntry("Edited", now.Add(-10*24*time.Hour))
+	edited.UpdatedAt = now.Add(-time.Hour)
+	untouched := newIntegrationTestEntry("Untouched", now.Add(-10*24*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{edited, untouched})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.AfterLatestDate(now.Add(-24 * time.Hour))
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryIDs) != 1 || entryIDs[0] != edited.ID {
+		t.Fatalf(`Expected only the edited entry #%d, got %v`, edited.ID, entryIDs)
+	}
+
+	builder = store.NewEntryQueryBuilder(user.ID)
+	builder.AfterPublishedDate(now.Add(-24 * time.Hour))
+	count, err := builder.CountEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Errorf(`Expected no entry published in the last day, got %d`, count)
+	}
+}
+
+func TestEntryQueryBuilderWithPriorityFeeds(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	publishedAt := time.Now().Add(-time.Hour)
+	priorityEntry := newIntegrationTestEntry("Priority", publishedAt)
+	priorityFeed := createIntegrationTestFeed(t, store, user.ID, model.Entries{priorityEntry})
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Regular", publishedAt)})
+
+	priorityFeed.Priority = true
+	if err := store.UpdateFeed(priorityFeed); err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := store.FeedByID(user.ID, priorityFeed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !feed.Priority {
+		t.Fatal(`Expected the feed to be stored as a priority feed`)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithPriorityFeeds()
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryIDs) != 1 || entryIDs[0] != priorityEntry.ID {
+		t.Fatalf(`Expected only the entry #%d of the priority feed, got %v`, priorityEntry.ID, entryIDs)
+	}
+}
+
+func TestEntryQueryBuilderAfterOpenedDateKeepsOpenedEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	publishedAt := time.Now().Add(-10 * 24 * time.Hour)
+	opened := newIntegrationTestEntry("Opened", publishedAt)
+	unopened := newIntegrationTestEntry("Unopened", publishedAt)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{opened, unopened})
+
+	if err := store.SetEntryOpenedAt(user.ID, opened.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.AfterOpenedDate(time.Now().Add(-24 * time.Hour))
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != opened.ID {
+		t.Fatalf(`Expected only the opened entry #%d, got %d entries`, opened.ID, len(entries))
+	}
+
+	if entries[0].OpenedAt == nil {
+		t.Error(`Expected the opening date of the entry to be fetched`)
+	}
+}
+
+func TestEntryQueryBuilderCountEntriesByFeed(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	publishedAt := time.Now().Add(-time.Hour)
+	noisy := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("First", publishedAt),
+		newIntegrationTestEntry("Second", publishedAt),
+		newIntegrationTestEntry("Third", publishedAt),
+	})
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Only", publishedAt)})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	counts, err := builder.CountEntriesByFeed(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counts) != 1 || counts[0].FeedID != noisy.ID || counts[0].UnreadCount != 3 {
+		t.Fatalf(`Expected only the noisy feed #%d with 3 entries, got %d feeds`, noisy.ID, len(counts))
+	}
+}
+
+func TestEntryQueryBuilderWithPinnedFeedsFirst(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	regularEntry := newIntegrationTestEntry("Regular", now.Add(-time.Hour))
+	pinnedEntry := newIntegrationTestEntry("Pinned", now.Add(-2*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{regularEntry})
+	pinnedFeed := createIntegrationTestFeed(t, store, user.ID, model.Entries{pinnedEntry})
+
+	pinnedFeed.Pinned = true
+	if err := store.UpdateFeed(pinnedFeed); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithPinnedFeedsFirst()
+	builder.WithStableSorting("published_at", "desc")
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The older entry of the pinned feed comes first despite the newest first order
+	if len(entryIDs) != 2 || entryIDs[0] != pinnedEntry.ID || entryIDs[1] != regularEntry.ID {
+		t.Fatalf(`Expected the entry #%d of the pinned feed before #%d, got %v`, pinnedEntry.ID, regularEntry.ID, entryIDs)
+	}
+}
+
+func TestEntryQueryBuilderWithReadingInProgress(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	publishedAt := time.Now().Add(-time.Hour)
+	startedFirst := newIntegrationTestEntry("Started first", publishedAt)
+	startedLast := newIntegrationTestEntry("Started last", publishedAt)
+	finished := newIntegrationTestEntry("Finished", publishedAt)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		startedFirst,
+		startedLast,
+		finished,
+		newIntegrationTestEntry("Not started", publishedAt),
+	})
+
+	for _, progress := range []struct {
+		entry    *model.Entry
+		progress float64
+	}{{startedFirst, 0.3}, {finished, 1}, {startedLast, 0.6}} {
+		if err := store.SetEntryReadProgress(user.ID, progress.entry.ID, progress.progress); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithReadingInProgress()
+	builder.WithStableSorting("e.read_progress_updated_at", "desc")
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The entry whose progress was saved last comes first, finished and unstarted entries are left out
+	if len(entries) != 2 || entries[0].ID != startedLast.ID || entries[1].ID != startedFirst.ID {
+		t.Fatalf(`Expected the entries #%d and #%d in progress, got %v`, startedLast.ID, startedFirst.ID, entries)
+	}
+
+	if entries[0].ReadProgress < 0.59 || entries[0].ReadProgress > 0.61 {
+		t.Errorf(`Expected the stored reading progress 0.6, got %v`, entries[0].ReadProgress)
+	}
+}
+
+func TestEntryQueryBuilderCursorPaginationCoversEveryEntry(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	// Pairs of entries share the same published date, so the ID tiebreaker matters across pages
+	now := time.Now().Truncate(time.Second)
+	var regularEntries, pinnedEntries model.Entries
+	for i := range 10 {
+		regularEntries = append(regularEntries, newIntegrationTestEntry(fmt.Sprintf("Regular %d", i), now.Add(-time.Duration(i/2)*time.Hour)))
+		pinnedEntries = append(pinnedEntries, newIntegrationTestEntry(fmt.Sprintf("Pinned %d", i), now.Add(-time.Duration(i/2)*time.Hour)))
+	}
+	createIntegrationTestFeed(t, store, user.ID, regularEntries)
+	pinnedFeed := createIntegrationTestFeed(t, store, user.ID, pinnedEntries)
+
+	pinnedFeed.Pinned = true
+	if err := store.UpdateFeed(pinnedFeed); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, direction := range []string{"asc", "desc"} {
+		fetchPage := func(limit int, cursor *model.EntryCursor) model.Entries {
+			builder := store.NewEntryQueryBuilder(user.ID)
+			builder.WithStatus(model.EntryStatusUnread)
+			builder.WithPinnedFeedsFirst()
+			builder.WithStableSorting("published_at", direction)
+			if cursor != nil {
+				builder.AfterCursor(cursor, "published_at", direction)
+			}
+			builder.WithLimit(limit)
+			page, err := builder.GetEntries()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return page
+		}
+
+		all := fetchPage(0, nil)
+		if len(all) != 20 {
+			t.Fatalf(`Expected 20 entries, got %d`, len(all))
+		}
+
+		var paged model.Entries
+		var cursor *model.EntryCursor
+		for {
+			page := fetchPage(3, cursor)
+			if len(page) == 0 {
+				break
+			}
+			paged = append(paged, page...)
+
+			// The cursor goes through its token like it does between two page loads
+			token := model.NewEntryCursor(page[len(page)-1], "published_at", true, false).Token()
+			var err error
+			if cursor, err = model.ParseEntryCursor(token); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if len(paged) != len(all) {
+			t.Fatalf(`Expected %d paginated entries, got %d (%s)`, len(all), len(paged), direction)
+		}
+
+		for i := range all {
+			if all[i].ID != paged[i].ID {
+				t.Errorf(`Paginated order differs at position %d (%s): %d != %d`, i, direction, paged[i].ID, all[i].ID)
+			}
+		}
+	}
+}
+
+func TestEntryQueryBuilderDisplayDateFollowsOverride(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	floated := newIntegrationTestEntry("Floated", now.Add(-10*24*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		floated,
+		newIntegrationTestEntry("Old", now.Add(-10*24*time.Hour)),
+	})
+
+	countToday := func() int {
+		t.Helper()
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.AfterDisplayDate(now.Add(-24 * time.Hour))
+		count, err := builder.CountEntries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if count := countToday(); count != 0 {
+		t.Fatalf(`Expected no entry displayed today, got %d`, count)
+	}
+
+	if err := store.SetEntryDisplayDateOverride(user.ID, floated.ID, &now); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 1 {
+		t.Errorf(`Expected the floated entry to be displayed today, got %d entries`, count)
+	}
+
+	// Clearing the override places the entry by its publication date again
+	if err := store.SetEntryDisplayDateOverride(user.ID, floated.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 0 {
+		t.Errorf(`Expected the entry back in its publication section, got %d entries today`, count)
+	}
+}
+
+func TestEntryQueryBuilderDisplayDateFollowsFeedAgeBasis(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	// Entries published long ago but fetched just now, as with a feed republishing its archive
+	now := time.Now()
+	feed := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("First", now.Add(-10*24*time.Hour)),
+		newIntegrationTestEntry("Second", now.Add(-10*24*time.Hour)),
+	})
+
+	countToday := func() int {
+		t.Helper()
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.AfterDisplayDate(now.Add(-24 * time.Hour))
+		count, err := builder.CountEntries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if count := countToday(); count != 0 {
+		t.Fatalf(`Expected no entry placed today by its publication date, got %d`, count)
+	}
+
+	feed.AgeBasis = model.FeedAgeBasisCreated
+	if err := store.UpdateFeed(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 2 {
+		t.Errorf(`Expected both entries placed today by their fetch date, got %d`, count)
+	}
+
+	feed.AgeBasis = model.FeedAgeBasisPublished
+	if err := store.UpdateFeed(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countToday(); count != 0 {
+		t.Errorf(`Expected both entries back in their publication section, got %d entries today`, count)
+	}
+}
+
+func TestEntryQueryBuilderAverageEntryAge(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now().Truncate(time.Second)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Hour", now.Add(-time.Hour)),
+		newIntegrationTestEntry("Three hours", now.Add(-3*time.Hour)),
+	})
+
+	age, err := store.NewEntryQueryBuilder(user.ID).AverageEntryAge(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 2*time.Hour {
+		t.Errorf(`Expected an average age of 2h, got %v`, age)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.AfterPublishedDate(now)
+	age, err = builder.AverageEntryAge(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 0 {
+		t.Errorf(`Expected no average age without entries, got %v`, age)
+	}
+}
+
+func TestEntryQueryBuilderDateViewVisibilityLeavesOutDisabledFeedsAndBlockedDomains(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Enabled", now.Add(-time.Hour))})
+	disabled := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Disabled", now.Add(-time.Hour))})
+	disabled.Disabled = true
+	if err := store.UpdateFeed(disabled); err != nil {
+		t.Fatal(err)
+	}
+
+	blocked := newIntegrationTestEntry("Blocked", now.Add(-time.Hour))
+	blocked.URL = "https://news.blocked.example/entry"
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{blocked})
+	if err := store.AddDateViewBlockedDomain(user.ID, "blocked.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	for includeDisabled, expected := range map[bool]int{false: 1, true: 2} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.WithDateViewVisibility(includeDisabled)
+		if count, err := builder.CountEntries(); err != nil || count != expected {
+			t.Errorf(`Expected %d visible entries with includeDisabled=%v, got %d (%v)`, expected, includeDisabled, count, err)
+		}
+	}
+}
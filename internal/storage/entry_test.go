@@ -4,8 +4,14 @@
 package storage
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
 )
 
 func TestTruncateStringForTSVectorField(t *testing.T) {
@@ -95,3 +101,1186 @@ func TestTruncateStringForTSVectorField(t *testing.T) {
 		t.Errorf("Invalid UTF-8 continuation bytes should return empty string, got %d bytes", len(result))
 	}
 }
+
+func TestMarkEntriesInDateRangeKeepsStarredEntriesUnread(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	starred := newIntegrationTestEntry("Starred", now.Add(-time.Hour))
+	regular := newIntegrationTestEntry("Regular", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{starred, regular})
+
+	if err := store.SetEntriesStarredState(user.ID, []int64{starred.ID}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	after := now.Add(-24 * time.Hour)
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{
+		Status:      model.EntryStatusRead,
+		After:       &after,
+		KeepStarred: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != starred.ID {
+		t.Fatalf(`Expected only the starred entry to remain unread, got %d entries`, len(entries))
+	}
+}
+
+func TestMarkEntriesInDateRangeKeepsEntriesMatchingTheExceptQueryUnread(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	important := newIntegrationTestEntry("Important release", now.Add(-time.Hour))
+	regular := newIntegrationTestEntry("Regular", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{important, regular})
+
+	// The builder selects the same entries as the range update
+	entryIDs, err := store.NewEntryQueryBuilder(user.ID).WithoutSearchQuery("important").GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(entryIDs, []int64{regular.ID}) {
+		t.Fatalf(`Expected only the regular entry outside of the query, got %v`, entryIDs)
+	}
+
+	after := now.Add(-24 * time.Hour)
+	count, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{
+		Status:            model.EntryStatusRead,
+		After:             &after,
+		ExceptSearchQuery: "important",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf(`Expected a single entry marked as read, got %d`, count)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != important.ID {
+		t.Fatalf(`Expected only the entry matching the query to remain unread, got %d entries`, len(entries))
+	}
+}
+
+func TestMarkEntriesInDateRangeWithRemovedStatus(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	recent := newIntegrationTestEntry("Recent", now.Add(-time.Hour))
+	older := newIntegrationTestEntry("Older", now.Add(-72*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{recent, older})
+
+	after := now.Add(-24 * time.Hour)
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{
+		Status: model.EntryStatusRemoved,
+		After:  &after,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []struct {
+		entry  *model.Entry
+		status string
+	}{
+		{recent, model.EntryStatusRemoved},
+		{older, model.EntryStatusUnread},
+	} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithEntryID(expected.entry.ID)
+		entry, err := builder.GetEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if entry == nil || entry.Status != expected.status {
+			t.Errorf(`Expected entry %q to have the status %q, got %v`, expected.entry.Title, expected.status, entry)
+		}
+	}
+}
+
+func TestMarkEntriesInDateRangeSkipsDisabledFeeds(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	disabled := newIntegrationTestEntry("Disabled", now.Add(-time.Hour))
+	feed := createIntegrationTestFeed(t, store, user.ID, model.Entries{disabled})
+	feed.Disabled = true
+	if err := store.UpdateFeed(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	countUnread := func() int {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithStatus(model.EntryStatusUnread)
+		count, err := builder.CountEntries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{Status: model.EntryStatusRead}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countUnread(); count != 1 {
+		t.Fatalf(`Expected the entry of the disabled feed to remain unread, got %d unread entries`, count)
+	}
+
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{Status: model.EntryStatusRead, IncludeDisabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countUnread(); count != 0 {
+		t.Fatalf(`Expected the entry of the disabled feed to be marked as read, got %d unread entries`, count)
+	}
+}
+
+func TestMarkEntriesInDateRangeSkipsErroredFeeds(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	healthy := newIntegrationTestEntry("Healthy", time.Now().Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{healthy})
+
+	errored := newIntegrationTestEntry("Errored", time.Now().Add(-time.Hour))
+	feed := createIntegrationTestFeed(t, store, user.ID, model.Entries{errored})
+	feed.ParsingErrorCount = 1
+	feed.ParsingErrorMsg = "unable to parse the feed"
+	if err := store.UpdateFeedError(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{Status: model.EntryStatusRead, SkipErroredFeeds: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	for entry, expectedStatus := range map[*model.Entry]string{healthy: model.EntryStatusRead, errored: model.EntryStatusUnread} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithEntryID(entry.ID)
+		result, err := builder.GetEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Status != expectedStatus {
+			t.Errorf(`Expected the entry %q to be %s, got %s`, entry.Title, expectedStatus, result.Status)
+		}
+	}
+}
+
+func TestMarkEntriesInDateRangeOnlyMarksEntriesShownInTheDateSection(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	after := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	inside := newIntegrationTestEntry("Inside", after.Add(time.Hour))
+	onBoundary := newIntegrationTestEntry("On boundary", after)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{inside, onBoundary})
+
+	hidden := newIntegrationTestEntry("Hidden category", after.Add(time.Hour))
+	hiddenCategory, err := store.CreateCategory(user.ID, &model.CategoryCreationRequest{Title: "Hidden", HideGlobally: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hiddenFeed := createIntegrationTestFeed(t, store, user.ID, nil)
+	hiddenFeed.Category = hiddenCategory
+	if err := store.UpdateFeed(hiddenFeed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.RefreshFeedEntries(user.ID, hiddenFeed.ID, model.Entries{hidden}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The section query of the date view, which the status change must agree with
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithGloballyVisible()
+	builder.AfterPublishedDate(after)
+	shown, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(shown) != 1 || shown[0] != inside.ID {
+		t.Fatalf(`Expected the section to only show the entry #%d, got %v`, inside.ID, shown)
+	}
+
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{
+		Status: model.EntryStatusRead,
+		After:  &after,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []struct {
+		entry  *model.Entry
+		status string
+	}{
+		{inside, model.EntryStatusRead},
+		{onBoundary, model.EntryStatusUnread},
+		{hidden, model.EntryStatusUnread},
+	} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithEntryID(expected.entry.ID)
+		entry, err := builder.GetEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if entry == nil || entry.Status != expected.status {
+			t.Errorf(`Expected entry %q to have the status %q, got %v`, expected.entry.Title, expected.status, entry)
+		}
+	}
+}
+
+func TestCountEntriesMarkedReadSince(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	read := newIntegrationTestEntry("Read", now.Add(-time.Hour))
+	unread := newIntegrationTestEntry("Unread", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{read, unread})
+
+	since := now.Add(-time.Minute)
+	if err := store.SetEntriesStatus(user.ID, []int64{read.ID}, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := store.CountEntriesMarkedReadSince(user.ID, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Errorf(`Expected 1 entry marked as read, got %d`, count)
+	}
+
+	count, err = store.CountEntriesMarkedReadSince(user.ID, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Errorf(`Expected no entry marked as read in the future, got %d`, count)
+	}
+}
+
+func TestCountEntriesMarkedReadSinceIgnoresStarringOldReadEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	entry := newIntegrationTestEntry("Read last week", now.Add(-8*24*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{entry})
+
+	if err := store.SetEntriesStatus(user.ID, []int64{entry.ID}, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.db.Exec(`UPDATE entries SET read_at = now() - interval '7 days' WHERE id=$1`, entry.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Starring, or marking as read again, changes the entry without reading it again
+	if err := store.SetEntriesStarredState(user.ID, []int64{entry.ID}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetEntriesStatus(user.ID, []int64{entry.ID}, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := store.CountEntriesMarkedReadSince(user.ID, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf(`Expected no entry marked as read since an hour ago, got %d`, count)
+	}
+}
+
+func TestReadingTimeMarkedReadSince(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	read := newIntegrationTestEntry("Read", now.Add(-time.Hour))
+	read.ReadingTime = 12
+	unread := newIntegrationTestEntry("Unread", now.Add(-time.Hour))
+	unread.ReadingTime = 30
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{read, unread})
+
+	if err := store.SetEntriesStatus(user.ID, []int64{read.ID}, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	minutes, err := store.ReadingTimeMarkedReadSince(user.ID, now.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minutes != 12 {
+		t.Errorf(`Expected 12 minutes read, got %d`, minutes)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	minutes, err = builder.TotalReadingTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minutes != 30 {
+		t.Errorf(`Expected 30 minutes left to read, got %d`, minutes)
+	}
+}
+
+func TestCreateEntryWithoutPublishedDateUsesCreationDate(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	undated := newIntegrationTestEntry("Undated", time.Time{})
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{undated})
+
+	if !undated.Date.Equal(undated.CreatedAt) {
+		t.Errorf(`Expected the publication date %v to be the creation date %v`, undated.Date, undated.CreatedAt)
+	}
+
+	// The entry belongs to the newest date section rather than to the oldest one
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.AfterPublishedDate(time.Now().Add(-24 * time.Hour))
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryIDs) != 1 || entryIDs[0] != undated.ID {
+		t.Errorf(`Expected the undated entry #%d in the last day, got %v`, undated.ID, entryIDs)
+	}
+}
+
+func TestSidebarAndDateViewUnreadTotalsAgree(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Today", now.Add(-time.Hour)),
+		newIntegrationTestEntry("Yesterday", now.Add(-30*time.Hour)),
+		newIntegrationTestEntry("Last week", now.Add(-5*24*time.Hour)),
+		newIntegrationTestEntry("Earlier", now.Add(-90*24*time.Hour)),
+	})
+
+	hidden := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Hidden", now.Add(-time.Hour))})
+	hidden.HideGlobally = true
+	if err := store.UpdateFeed(hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	disabled := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Disabled", now.Add(-time.Hour))})
+	disabled.Disabled = true
+	if err := store.UpdateFeed(disabled); err != nil {
+		t.Fatal(err)
+	}
+
+	// The date view leaves out the disabled feeds by default
+	total, err := store.CountGloballyVisibleUnreadEntries(user.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sidebar := store.CountUnreadEntries(user.ID); sidebar != total || total != 4 {
+		t.Fatalf(`Expected the sidebar count %d to be the total %d of 4 entries`, sidebar, total)
+	}
+
+	// The sections of the date view hold the same entries as the total
+	sum := 0
+	for _, section := range model.NewDateSections(model.DateSectionSchemeDefault, now) {
+		sum += countIntegrationTestDateSectionEntries(t, store, user.ID, section)
+	}
+
+	if sum != total {
+		t.Errorf(`Expected the date sections to sum up to %d, got %d`, total, sum)
+	}
+}
+
+func TestMarkEntriesReadKeepingSample(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	entries := make(model.Entries, 0, 20)
+	for i := range 20 {
+		entries = append(entries, newIntegrationTestEntry(fmt.Sprintf("Entry %d", i), now.Add(-time.Hour)))
+	}
+	createIntegrationTestFeed(t, store, user.ID, entries)
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marked, kept, err := store.MarkEntriesReadKeepingSample(user.ID, entryIDs, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if marked != 18 || kept != 2 {
+		t.Errorf(`Expected 18 marked and 2 kept entries, got %d and %d`, marked, kept)
+	}
+
+	builder = store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	if count, err := builder.CountEntries(); err != nil || count != 2 {
+		t.Errorf(`Expected 2 unread entries, got %d (%v)`, count, err)
+	}
+}
+
+func TestDailyEntryCountsHasOneCountPerDay(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Today", today),
+		newIntegrationTestEntry("Also today", now),
+		newIntegrationTestEntry("Two days ago", today.AddDate(0, 0, -2).Add(time.Hour)),
+		newIntegrationTestEntry("Too old", today.AddDate(0, 0, -30)),
+	})
+
+	hidden := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Hidden", now)})
+	hidden.HideGlobally = true
+	if err := store.UpdateFeed(hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := store.DailyEntryCounts(user.ID, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counts) != 30 || !counts[29].Day.Equal(today) {
+		t.Fatalf(`Expected 30 days up to today, got %d days`, len(counts))
+	}
+
+	if counts[29].Count != 2 || counts[28].Count != 0 || counts[27].Count != 1 || counts[0].Count != 0 {
+		t.Errorf(`Unexpected daily counts: today %d, yesterday %d, two days ago %d, oldest %d`, counts[29].Count, counts[28].Count, counts[27].Count, counts[0].Count)
+	}
+}
+
+func TestEntryCountsByHourOfDayUsesThePublicationHour(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Morning", today.AddDate(0, 0, -1).Add(8*time.Hour)),
+		newIntegrationTestEntry("Another morning", today.AddDate(0, 0, -2).Add(8*time.Hour+30*time.Minute)),
+		newIntegrationTestEntry("Evening", today.AddDate(0, 0, -1).Add(21*time.Hour)),
+		newIntegrationTestEntry("Too old", today.AddDate(0, 0, -40).Add(8*time.Hour)),
+	})
+
+	counts, err := store.EntryCountsByHourOfDay(user.ID, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if counts[8] != 2 || counts[21] != 1 || counts[0] != 0 {
+		t.Errorf(`Unexpected hourly counts: 8 AM %d, 9 PM %d, midnight %d`, counts[8], counts[21], counts[0])
+	}
+}
+
+func TestMarkCategoryEntriesAsReadSkipsHiddenFeeds(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	old := newIntegrationTestEntry("Old", time.Now().AddDate(-1, 0, 0))
+	recent := newIntegrationTestEntry("Recent", time.Now().Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{old, recent})
+
+	hiddenEntry := newIntegrationTestEntry("Hidden", time.Now().Add(-time.Hour))
+	hidden := createIntegrationTestFeed(t, store, user.ID, model.Entries{hiddenEntry})
+	hidden.HideGlobally = true
+	if err := store.UpdateFeed(hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.MarkCategoryEntriesAsRead(user.ID, hidden.Category.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for entry, expectedStatus := range map[*model.Entry]string{old: model.EntryStatusRead, recent: model.EntryStatusRead, hiddenEntry: model.EntryStatusUnread} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithEntryID(entry.ID)
+		result, err := builder.GetEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Status != expectedStatus {
+			t.Errorf(`Expected the entry %q to be %s, got %s`, entry.Title, expectedStatus, result.Status)
+		}
+	}
+}
+
+func TestSetEntryLabelsLoadsAndFiltersEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	labeled := newIntegrationTestEntry("Labeled", now)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{labeled, newIntegrationTestEntry("Unlabeled", now)})
+
+	if err := store.SetEntryLabels(user.ID, labeled.ID, []string{"work", "later"}); err != nil {
+		t.Fatal(err)
+	}
+
+	labels, err := store.EntryLabels(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(labels, []string{"later", "work"}) {
+		t.Errorf(`Unexpected labels of the user, got %v`, labels)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithEntryLabel("work")
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != labeled.ID || !slices.Equal(entries[0].Labels, []string{"later", "work"}) {
+		t.Fatalf(`Expected only the labeled entry with its labels, got %d entries`, len(entries))
+	}
+
+	// An empty list removes the labels
+	if err := store.SetEntryLabels(user.ID, labeled.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if labels, _ := store.EntryLabels(user.ID); len(labels) != 0 {
+		t.Errorf(`Expected no label left, got %v`, labels)
+	}
+}
+
+func TestStarredEntriesAreBucketedByStarredDate(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	entry := newIntegrationTestEntry("Old but starred", time.Now().AddDate(-1, 0, 0))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{entry})
+
+	if err := store.ToggleStarred(user.ID, entry.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.AfterStarredDate(time.Now().Add(-time.Hour))
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].StarredAt == nil {
+		t.Fatalf(`Expected the entry starred during the last hour, got %d entries`, len(entries))
+	}
+
+	// Unstarring clears the date
+	if err := store.ToggleStarred(user.ID, entry.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	builder = store.NewEntryQueryBuilder(user.ID)
+	builder.WithEntryID(entry.ID)
+	result, err := builder.GetEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.StarredAt != nil {
+		t.Errorf(`Expected no starred date once unstarred, got %v`, result.StarredAt)
+	}
+}
+
+func TestMarkEntriesInDateRangeLeavesEntriesCreatedAfterAsOf(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	before := newIntegrationTestEntry("Before the page", time.Now().Add(-time.Hour))
+	feed := createIntegrationTestFeed(t, store, user.ID, model.Entries{before})
+
+	// The page is rendered, then another entry is fetched
+	asOf := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	after := newIntegrationTestEntry("After the page", time.Now().Add(-time.Hour))
+	if _, err := store.RefreshFeedEntries(user.ID, feed.ID, model.Entries{before, after}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.MarkEntriesInDateRange(user.ID, &model.DateRangeStatusUpdate{
+		Status:      model.EntryStatusRead,
+		CreatedAsOf: &asOf,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Title != after.Title {
+		t.Errorf(`Expected only the entry fetched after the page to stay unread, got %d entries`, len(entries))
+	}
+}
+
+func TestWithoutShareCodeExcludesSharedEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	shared := newIntegrationTestEntry("Shared", time.Now().Add(-time.Hour))
+	unshared := newIntegrationTestEntry("Unshared", time.Now().Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{shared, unshared})
+
+	if _, err := store.EntryShareCode(user.ID, shared.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithoutShareCode()
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryIDs) != 1 || entryIDs[0] != unshared.ID {
+		t.Errorf(`Expected only the unshared entry, got %v`, entryIDs)
+	}
+
+	// Entries are listed again once their share link is revoked
+	if err := store.UnshareEntry(user.ID, shared.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	builder = store.NewEntryQueryBuilder(user.ID)
+	builder.WithoutShareCode()
+	if count, err := builder.CountEntries(); err != nil || count != 2 {
+		t.Errorf(`Expected 2 entries once unshared, got %d (%v)`, count, err)
+	}
+}
+
+func TestEarlierEntryCountsByUserSortsTheLargestFirst(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	light := createIntegrationTestUser(t, store)
+	heavy := createIntegrationTestUser(t, store)
+
+	old := time.Now().AddDate(0, 0, -45)
+	createIntegrationTestFeed(t, store, light.ID, model.Entries{
+		newIntegrationTestEntry("Old", old),
+		newIntegrationTestEntry("Recent", time.Now().Add(-time.Hour)),
+	})
+	createIntegrationTestFeed(t, store, heavy.ID, model.Entries{
+		newIntegrationTestEntry("First", old),
+		newIntegrationTestEntry("Second", old),
+	})
+
+	hidden := createIntegrationTestFeed(t, store, light.ID, model.Entries{newIntegrationTestEntry("Hidden", old)})
+	hidden.HideGlobally = true
+	if err := store.UpdateFeed(hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	// An old entry moved to a recent display date is not earlier, and neither is one of a blocked domain
+	moved := newIntegrationTestEntry("Moved", old)
+	blocked := newIntegrationTestEntry("Blocked", old)
+	blocked.URL = "https://blocked.example.com/old"
+	createIntegrationTestFeed(t, store, light.ID, model.Entries{moved, blocked})
+	recent := time.Now().Add(-time.Hour)
+	if err := store.SetEntryDisplayDateOverride(light.ID, moved.ID, &recent); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDateViewBlockedDomain(light.ID, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := store.EarlierEntryCountsByUser(time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	positions := make(map[int64]int)
+	for i, count := range counts {
+		positions[count.UserID] = i
+		switch count.UserID {
+		case light.ID:
+			if count.Count != 1 || count.Username != light.Username {
+				t.Errorf(`Expected 1 earlier entry for %s, got %d`, light.Username, count.Count)
+			}
+		case heavy.ID:
+			if count.Count != 2 {
+				t.Errorf(`Expected 2 earlier entries for %s, got %d`, heavy.Username, count.Count)
+			}
+		}
+	}
+
+	lightPosition, foundLight := positions[light.ID]
+	heavyPosition, foundHeavy := positions[heavy.ID]
+	if !foundLight || !foundHeavy || heavyPosition > lightPosition {
+		t.Errorf(`Expected both users with the largest count first, got %+v`, positions)
+	}
+}
+
+func TestMarkReadKeepNewestPerFeedKeepsTheNewestOfEachFeed(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	newest := newIntegrationTestEntry("Newest", now.Add(-time.Hour))
+	older := newIntegrationTestEntry("Older", now.Add(-2*time.Hour))
+	oldest := newIntegrationTestEntry("Oldest", now.Add(-3*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newest, older, oldest})
+
+	single := newIntegrationTestEntry("Single", now.AddDate(0, 0, -90))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{single})
+
+	hiddenEntry := newIntegrationTestEntry("Hidden", now.AddDate(0, 0, -90))
+	hidden := createIntegrationTestFeed(t, store, user.ID, model.Entries{hiddenEntry, newIntegrationTestEntry("Hidden newest", now)})
+	hidden.HideGlobally = true
+	if err := store.UpdateFeed(hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	marked, err := store.MarkReadKeepNewestPerFeed(user.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if marked != 1 {
+		t.Errorf(`Expected 1 entry marked as read, got %d`, marked)
+	}
+
+	for entry, expectedStatus := range map[*model.Entry]string{newest: model.EntryStatusUnread, older: model.EntryStatusUnread, oldest: model.EntryStatusRead, single: model.EntryStatusUnread, hiddenEntry: model.EntryStatusUnread} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithEntryID(entry.ID)
+		result, err := builder.GetEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Status != expectedStatus {
+			t.Errorf(`Expected the entry %q to be %s, got %s`, entry.Title, expectedStatus, result.Status)
+		}
+	}
+}
+
+func TestWithoutModificationsSinceFetchExcludesRewrittenEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	stable := newIntegrationTestEntry("Stable", now.Add(-time.Hour))
+	stable.UpdatedAt = now.Add(-30 * time.Minute)
+	rewritten := newIntegrationTestEntry("Rewritten", now.Add(-time.Hour))
+	rewritten.UpdatedAt = now.Add(time.Hour)
+	unannounced := newIntegrationTestEntry("Unannounced", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{stable, rewritten, unannounced})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithoutModificationsSinceFetch()
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryIDs) != 2 || slices.Contains(entryIDs, rewritten.ID) {
+		t.Errorf(`Expected every entry but the rewritten one, got %v`, entryIDs)
+	}
+}
+
+func TestWithScrapedContentKeepsScrapedEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	scraped := newIntegrationTestEntry("Scraped", now.Add(-time.Hour))
+	scraped.ScrapeStatus = model.EntryScrapeStatusSucceeded
+	failed := newIntegrationTestEntry("Failed", now.Add(-time.Hour))
+	failed.ScrapeStatus = model.EntryScrapeStatusFailed
+	notScraped := newIntegrationTestEntry("Not scraped", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{scraped, failed, notScraped})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithScrapedContent()
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != scraped.ID {
+		t.Fatalf(`Expected only the scraped entry, got %d entries`, len(entries))
+	}
+
+	if entries[0].ScrapeStatus != model.EntryScrapeStatusSucceeded {
+		t.Errorf(`Unexpected scrape status, got %q`, entries[0].ScrapeStatus)
+	}
+}
+
+func TestUpdatedDateBoundsLeaveOutEntriesNeverModified(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	edited := newIntegrationTestEntry("Edited", now.Add(-90*24*time.Hour))
+	edited.UpdatedAt = now.Add(-time.Hour)
+	editedLongAgo := newIntegrationTestEntry("Edited long ago", now.Add(-90*24*time.Hour))
+	editedLongAgo.UpdatedAt = now.Add(-60 * 24 * time.Hour)
+	neverModified := newIntegrationTestEntry("Never modified", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{edited, editedLongAgo, neverModified})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.AfterUpdatedDate(now.Add(-24 * time.Hour))
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(entryIDs, []int64{edited.ID}) {
+		t.Errorf(`Expected only the recently edited entry, got %v`, entryIDs)
+	}
+
+	builder = store.NewEntryQueryBuilder(user.ID)
+	builder.BeforeUpdatedDate(now.Add(-24 * time.Hour))
+	entryIDs, err = builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(entryIDs, []int64{editedLongAgo.ID}) {
+		t.Errorf(`Expected only the entry edited long ago, got %v`, entryIDs)
+	}
+}
+
+func TestWithoutCategoryIDsLeavesOutExcludedCategories(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	kept := newIntegrationTestEntry("Kept", now)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{kept})
+
+	noise := newIntegrationTestEntry("Noise", now)
+	noiseCategory, err := store.CreateCategory(user.ID, &model.CategoryCreationRequest{Title: "Noise"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	noiseFeed := createIntegrationTestFeed(t, store, user.ID, nil)
+	noiseFeed.Category = noiseCategory
+	if err := store.UpdateFeed(noiseFeed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.RefreshFeedEntries(user.ID, noiseFeed.ID, model.Entries{noise}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithoutCategoryIDs([]int64{noiseCategory.ID})
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(entryIDs, []int64{kept.ID}) {
+		t.Errorf(`Expected only the entry outside the excluded category, got %v`, entryIDs)
+	}
+}
+
+func TestAfterFeedCreatedDateKeepsEntriesOfNewFeeds(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	backfilled := newIntegrationTestEntry("Backfilled", now.Add(-365*24*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{backfilled})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.AfterFeedCreatedDate(now.Add(-time.Hour))
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(entryIDs, []int64{backfilled.ID}) {
+		t.Errorf(`Expected the old entry of the new feed, got %v`, entryIDs)
+	}
+
+	builder = store.NewEntryQueryBuilder(user.ID)
+	builder.AfterFeedCreatedDate(now.Add(time.Hour))
+	if count, err := builder.CountEntries(); err != nil || count != 0 {
+		t.Errorf(`Expected no entry of a feed subscribed to later, got %d (%v)`, count, err)
+	}
+}
+
+func TestRemovedEntriesAreDatedAndRestored(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	removed := newIntegrationTestEntry("Removed", now.Add(-90*24*time.Hour))
+	kept := newIntegrationTestEntry("Kept", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{removed, kept})
+
+	if err := store.SetEntriesStatus(user.ID, []int64{removed.ID}, model.EntryStatusRemoved); err != nil {
+		t.Fatal(err)
+	}
+
+	// The trash places the entry by the date it was removed, not by its publication date
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusRemoved)
+	builder.AfterRemovedDate(now.Add(-time.Hour))
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(entryIDs, []int64{removed.ID}) {
+		t.Fatalf(`Expected only the removed entry in the trash, got %v`, entryIDs)
+	}
+
+	count, err := store.RestoreRemovedEntries(user.ID, []int64{removed.ID, kept.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf(`Expected 1 restored entry, got %d`, count)
+	}
+
+	entry, err := store.NewEntryQueryBuilder(user.ID).WithEntryID(removed.ID).GetEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != model.EntryStatusUnread {
+		t.Errorf(`Unexpected status of the restored entry, got %q`, entry.Status)
+	}
+}
+
+func TestUpdateStatusUpToCursorMarksTheNewerEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now().Truncate(time.Second)
+	newest := newIntegrationTestEntry("Newest", now.Add(-time.Hour))
+	atCursor := newIntegrationTestEntry("At cursor", now.Add(-2*time.Hour))
+	older := newIntegrationTestEntry("Older", now.Add(-3*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newest, atCursor, older})
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.UpToCursor(&model.EntryCursor{SortValue: atCursor.Date.Format(time.RFC3339Nano), EntryID: atCursor.ID}, "published_at", "desc")
+	count, err := builder.UpdateStatus(model.EntryStatusRead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf(`Expected 2 entries marked as read, got %d`, count)
+	}
+
+	unreadBuilder := store.NewEntryQueryBuilder(user.ID)
+	unreadBuilder.WithStatus(model.EntryStatusUnread)
+	entryIDs, err := unreadBuilder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(entryIDs, []int64{older.ID}) {
+		t.Errorf(`Expected only the entry listed after the cursor to stay unread, got %v`, entryIDs)
+	}
+}
+
+func TestWithOpenedSplitsOpenedAndNeverOpenedEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	opened := newIntegrationTestEntry("Opened", now.Add(-time.Hour))
+	neverOpened := newIntegrationTestEntry("Never opened", now.Add(-time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{opened, neverOpened})
+
+	if err := store.SetEntryOpenedAt(user.ID, opened.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		opened   bool
+		expected int64
+	}{{true, opened.ID}, {false, neverOpened.ID}} {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithOpened(test.opened)
+		entryIDs, err := builder.GetEntryIDs()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(entryIDs, []int64{test.expected}) {
+			t.Errorf(`Unexpected entries with opened=%v, got %v instead of [%d]`, test.opened, entryIDs, test.expected)
+		}
+	}
+}
+
+func TestMarkEntriesInDateRangesSkipsTheGapBetweenRanges(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now().Truncate(time.Second)
+	today := newIntegrationTestEntry("Today", now.Add(-time.Hour))
+	yesterday := newIntegrationTestEntry("Yesterday", now.Add(-36*time.Hour))
+	lastWeek := newIntegrationTestEntry("Last week", now.Add(-5*24*time.Hour))
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{today, yesterday, lastWeek})
+
+	dayAgo := now.Add(-24 * time.Hour)
+	twoDaysAgo := now.Add(-48 * time.Hour)
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	markedIDs, err := store.MarkEntriesInDateRanges(user.ID, &model.DateRangeStatusUpdate{Status: model.EntryStatusRead}, []model.DateRange{
+		{After: &dayAgo},
+		{After: &weekAgo, Before: &twoDaysAgo},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(markedIDs)
+	if expected := []int64{min(today.ID, lastWeek.ID), max(today.ID, lastWeek.ID)}; !slices.Equal(markedIDs, expected) {
+		t.Errorf(`Expected the entries %v to be marked as read, got %v`, expected, markedIDs)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(entryIDs, []int64{yesterday.ID}) {
+		t.Errorf(`Expected only the entry between the ranges to stay unread, got %v`, entryIDs)
+	}
+}
+
+func TestEntryPublishedAtKeepsTheInstantOfTheFeed(t *testing.T) {
+	// The timestamptz column stores the instant, whatever the offset of the publisher
+	entry := model.NewEntry()
+	entry.Date = time.Date(2025, time.March, 10, 8, 30, 0, 0, time.FixedZone("", 9*60*60))
+
+	publishedAt := entryPublishedAt(entry)
+	if !publishedAt.Valid || !publishedAt.Time.Equal(entry.Date) {
+		t.Errorf(`Expected the publication date to stay %v, got %v`, entry.Date, publishedAt)
+	}
+
+	entry.Date = time.Time{}
+	if publishedAt := entryPublishedAt(entry); publishedAt.Valid {
+		t.Errorf(`Expected no publication date for an undated entry, got %v`, publishedAt.Time)
+	}
+}
+
+func TestEntryWithPositiveOffsetBucketsInTheTimezoneOfTheUser(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	// 23:30 on March 9th in UTC, 19:30 on March 9th in New York, while the local date of the feed is March 10th
+	publishedAt, err := date.Parse("2025-03-10T08:30:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Tokyo", publishedAt)})
+
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	counts, err := builder.CountEntriesByDay(location.String(), publishedAt.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts[0].Day.Format(time.DateOnly) != "2025-03-09" {
+		t.Fatalf(`Expected the entry to be counted on March 9th in New York, got %v`, counts)
+	}
+
+	now := time.Date(2025, time.March, 9, 21, 0, 0, 0, location)
+	sections := model.NewCalendarDateSections(model.DateSectionSchemeDefault, now, 0)
+	for _, section := range sections[:2] {
+		count := countIntegrationTestDateSectionEntries(t, store, user.ID, section)
+
+		expected := 0
+		if section.Name == "today" {
+			expected = 1
+		}
+		if count != expected {
+			t.Errorf(`Expected %d entries in the %q section, got %d`, expected, section.Name, count)
+		}
+	}
+
+	entries, err := store.NewEntryQueryBuilder(user.ID).GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !entries[0].Date.Equal(publishedAt) {
+		t.Errorf(`Expected the publication date to be stored as %v, got %v`, publishedAt.UTC(), entries)
+	}
+}
+
+func TestMarkLinkedEntriesAsRead(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+	linkedUser := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	shared := newIntegrationTestEntry("Shared", now)
+	kept := newIntegrationTestEntry("Kept", now)
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{shared, kept})
+
+	linkedShared := newIntegrationTestEntry("Shared", now)
+	linkedShared.Hash = shared.Hash
+	linkedKept := newIntegrationTestEntry("Kept", now)
+	linkedKept.Hash = kept.Hash
+	createIntegrationTestFeed(t, store, linkedUser.ID, model.Entries{linkedShared, linkedKept})
+
+	// Only the given entries are mirrored, not every entry the user read
+	if err := store.SetEntriesStatus(user.ID, []int64{shared.ID, kept.ID}, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := store.MarkLinkedEntriesAsRead(user.ID, linkedUser.ID, []int64{shared.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf(`Expected 1 entry marked as read in the linked account, got %d`, count)
+	}
+
+	builder := store.NewEntryQueryBuilder(linkedUser.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(entryIDs, []int64{linkedKept.ID}) {
+		t.Errorf(`Expected only the entry not read by the user to stay unread, got %v`, entryIDs)
+	}
+}
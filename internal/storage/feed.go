@@ -13,6 +13,8 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type byStateAndName struct{ f model.Feeds }
@@ -125,6 +127,27 @@ func (s *Storage) CountUserFeedsWithErrors(userID int64) int {
 	return result
 }
 
+// CUSTOM: CountUserFeedsWithErrorsInCategories returns the number of feeds with parsing errors of the user
+// within the given categories, every category of the user when there is none.
+func (s *Storage) CountUserFeedsWithErrorsInCategories(userID int64, categoryIDs []int64) int {
+	if len(categoryIDs) == 0 {
+		return s.CountUserFeedsWithErrors(userID)
+	}
+
+	pollingParsingErrorLimit := config.Opts.PollingParsingErrorLimit()
+	if pollingParsingErrorLimit <= 0 {
+		pollingParsingErrorLimit = 1
+	}
+	query := `SELECT count(*) FROM feeds WHERE user_id=$1 AND parsing_error_count >= $2 AND category_id = ANY($3)`
+	var result int
+	err := s.db.QueryRow(query, userID, pollingParsingErrorLimit, pq.Array(categoryIDs)).Scan(&result)
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
+
 // CountAllFeedsWithErrors returns the number of feeds with parsing errors.
 func (s *Storage) CountAllFeedsWithErrors() int {
 	pollingParsingErrorLimit := config.Opts.PollingParsingErrorLimit()
@@ -263,10 +286,11 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			webhook_url,
 			disable_http2,
 			description,
-			proxy_url
+			proxy_url,
+			source
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
 		RETURNING
 			id
 	`
@@ -302,6 +326,7 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.DisableHTTP2,
 		feed.Description,
 		feed.ProxyURL,
+		feed.Source,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -338,11 +363,23 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		}
 	}
 
+	// CUSTOM: entries of the new feed show up in the date view
+	if len(feed.Entries) > 0 {
+		s.invalidateDateSectionCounts(feed.UserID)
+	}
+
 	return nil
 }
 
 // UpdateFeed updates an existing feed.
 func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
+	// CUSTOM: the display dates of the entries are only computed again when the age basis changes
+	var previousAgeBasis string
+	err = s.db.QueryRow(`SELECT age_basis FROM feeds WHERE id=$1 AND user_id=$2`, feed.ID, feed.UserID).Scan(&previousAgeBasis)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf(`store: unable to fetch the age basis of feed #%d: %v`, feed.ID, err)
+	}
+
 	query := `
 		UPDATE
 			feeds
@@ -384,9 +421,12 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			ntfy_topic=$35,
 			pushover_enabled=$36,
 			pushover_priority=$37,
-			proxy_url=$38
+			proxy_url=$38,
+			priority=$39,
+			pinned=$40,
+			age_basis=$41
 		WHERE
-			id=$39 AND user_id=$40
+			id=$42 AND user_id=$43
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -427,6 +467,9 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.PushoverEnabled,
 		feed.PushoverPriority,
 		feed.ProxyURL,
+		feed.Priority,
+		feed.Pinned,
+		feed.AgeBasis,
 		feed.ID,
 		feed.UserID,
 	)
@@ -435,6 +478,35 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		return fmt.Errorf(`store: unable to update feed #%d (%s): %v`, feed.ID, feed.FeedURL, err)
 	}
 
+	if previousAgeBasis != "" && previousAgeBasis != feed.AgeBasis {
+		if err := s.updateFeedEntryDisplayDates(feed.UserID, feed.ID); err != nil {
+			return err
+		}
+	}
+
+	// CUSTOM: disabling or hiding the feed changes which entries the date view counts
+	s.invalidateDateSectionCounts(feed.UserID)
+
+	return nil
+}
+
+// CUSTOM: updateFeedEntryDisplayDates places the entries of the feed by the date of its age basis, unless
+// their display date is overridden.
+func (s *Storage) updateFeedEntryDisplayDates(userID, feedID int64) error {
+	query := `
+		UPDATE
+			entries e
+		SET
+			display_date=COALESCE(e.display_date_override, ` + ageBasisDateExpression("e", "f") + `)
+		FROM
+			feeds f
+		WHERE
+			f.id=e.feed_id AND e.user_id=$1 AND e.feed_id=$2
+	`
+	if _, err := s.db.Exec(query, userID, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update the display dates of the entries of feed #%d: %v`, feedID, err)
+	}
+
 	return nil
 }
 
@@ -467,6 +539,49 @@ func (s *Storage) UpdateFeedError(feed *model.Feed) (err error) {
 	return nil
 }
 
+// CUSTOM: SetFeedLastOpenedAt records that the user opened the feed or one of its entries.
+func (s *Storage) SetFeedLastOpenedAt(userID, feedID int64) error {
+	query := `UPDATE feeds SET last_opened_at=now() WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, feedID, userID); err != nil {
+		return fmt.Errorf(`store: unable to update the last opened date of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// CUSTOM: FeedsWithUnreadNotOpenedSince returns the IDs of the feeds having unread entries that the user
+// did not open since the given date.
+func (s *Storage) FeedsWithUnreadNotOpenedSince(userID int64, since time.Time) ([]int64, error) {
+	query := `
+		SELECT
+			f.id
+		FROM
+			feeds f
+		WHERE
+			f.user_id=$1
+			AND (f.last_opened_at IS NULL OR f.last_opened_at < $2)
+			AND EXISTS (SELECT 1 FROM entries e WHERE e.feed_id=f.id AND e.status=$3)
+		ORDER BY
+			f.id
+	`
+	rows, err := s.db.Query(query, userID, since, model.EntryStatusUnread)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feeds not opened since %v: %v`, since, err)
+	}
+	defer rows.Close()
+
+	var feedIDs []int64
+	for rows.Next() {
+		var feedID int64
+		if err := rows.Scan(&feedID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed row: %v`, err)
+		}
+		feedIDs = append(feedIDs, feedID)
+	}
+
+	return feedIDs, nil
+}
+
 // RemoveFeed removes a feed and all entries.
 // This operation can takes time if the feed has lot of entries.
 func (s *Storage) RemoveFeed(userID, feedID int64) error {
@@ -497,6 +612,8 @@ func (s *Storage) RemoveFeed(userID, feedID int64) error {
 		return fmt.Errorf(`store: unable to delete feed #%d: %v`, feedID, err)
 	}
 
+	s.invalidateDateSectionCounts(userID)
+
 	return nil
 }
 
@@ -510,3 +627,121 @@ func (s *Storage) ResetNextCheckAt() error {
 	_, err := s.db.Exec(`UPDATE feeds SET next_check_at=now()`)
 	return err
 }
+
+// CUSTOM: FeedReadRatios returns the read ratio of the feeds having at least minEntries entries no longer unread,
+// the least read first. Feeds with a shorter history are left out, their ratio says little yet. Only the entries read
+// or removed since the openings of the user are tracked count: the others were never opened as far as we know.
+func (s *Storage) FeedReadRatios(userID int64, minEntries int) ([]*model.FeedReadRatio, error) {
+	query := `
+		SELECT
+			f.id,
+			f.title,
+			count(e.opened_at) AS opened_count,
+			count(*) AS settled_count
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		JOIN
+			users u ON u.id=e.user_id
+		WHERE
+			e.user_id=$1 AND e.status<>$2 AND
+			COALESCE(e.read_at, e.removed_at) >= u.entry_opened_tracked_since
+		GROUP BY
+			f.id, f.title
+		HAVING
+			count(*) >= $3
+		ORDER BY
+			count(e.opened_at)::float / count(*) ASC, f.id ASC
+	`
+	rows, err := s.db.Query(query, userID, model.EntryStatusUnread, minEntries)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the feed read ratios: %v`, err)
+	}
+	defer rows.Close()
+
+	ratios := make([]*model.FeedReadRatio, 0)
+	for rows.Next() {
+		var ratio model.FeedReadRatio
+		if err := rows.Scan(&ratio.FeedID, &ratio.FeedTitle, &ratio.OpenedCount, &ratio.SettledCount); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed read ratio row: %v`, err)
+		}
+		ratio.ReadRatio = float64(ratio.OpenedCount) / float64(ratio.SettledCount)
+		ratios = append(ratios, &ratio)
+	}
+
+	return ratios, nil
+}
+
+// CUSTOM: TopFeedsByUnreadOlderThan returns the feeds having the most unread entries displayed before
+// the cutoff date, by their display date override or else the date chosen by the feed age basis, most unread first.
+func (s *Storage) TopFeedsByUnreadOlderThan(userID int64, cutoff time.Time, limit int) ([]*model.FeedUnreadCount, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			f.id,
+			f.title,
+			count(*) AS unread_count
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		WHERE
+			e.user_id=$1 AND e.status=$2 AND %s < $3
+		GROUP BY
+			f.id, f.title
+		ORDER BY
+			unread_count DESC, f.id ASC
+		LIMIT $4
+	`, displayEntryDateExpression)
+	rows, err := s.db.Query(query, userID, model.EntryStatusUnread, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the feeds with the most unread entries before %v: %v`, cutoff, err)
+	}
+	defer rows.Close()
+
+	counts := make([]*model.FeedUnreadCount, 0, limit)
+	for rows.Next() {
+		var count model.FeedUnreadCount
+		if err := rows.Scan(&count.FeedID, &count.FeedTitle, &count.UnreadCount); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed unread count row: %v`, err)
+		}
+		counts = append(counts, &count)
+	}
+
+	return counts, nil
+}
+
+// CUSTOM: TopFeedRefreshSince returns the feed refresh that created the most entries of the user since the given
+// date, or nil when no entry was created since then. The entries of a refresh share their creation date since
+// they are inserted in the same transaction.
+func (s *Storage) TopFeedRefreshSince(userID int64, since time.Time) (*model.FeedRefreshEntryCount, error) {
+	query := `
+		SELECT
+			f.id,
+			f.title,
+			e.created_at,
+			count(*) AS entry_count
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		WHERE
+			e.user_id=$1 AND e.created_at >= $2
+		GROUP BY
+			f.id, f.title, e.created_at
+		ORDER BY
+			entry_count DESC, e.created_at DESC
+		LIMIT 1
+	`
+
+	var count model.FeedRefreshEntryCount
+	err := s.db.QueryRow(query, userID, since).Scan(&count.FeedID, &count.FeedTitle, &count.CreatedAt, &count.EntryCount)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch the feed refresh with the most entries since %v: %v`, since, err)
+	}
+
+	return &count, nil
+}
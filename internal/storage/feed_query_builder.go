@@ -176,7 +176,11 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.ntfy_topic,
 			f.pushover_enabled,
 			f.pushover_priority,
-			f.proxy_url
+			f.proxy_url,
+			f.priority,
+			f.pinned,
+			f.source,
+			f.age_basis
 		FROM
 			feeds f
 		LEFT JOIN
@@ -258,6 +262,10 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.PushoverEnabled,
 			&feed.PushoverPriority,
 			&feed.ProxyURL,
+			&feed.Priority,
+			&feed.Pinned,
+			&feed.Source,
+			&feed.AgeBasis,
 		)
 
 		if err != nil {
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// CUSTOM: FeedTags returns the sorted tags of the given feed.
+func (s *Storage) FeedTags(userID, feedID int64) ([]string, error) {
+	query := `
+		SELECT
+			t.tag
+		FROM
+			feed_tags t
+		JOIN
+			feeds f ON (f.id = t.feed_id)
+		WHERE
+			f.user_id=$1 AND f.id=$2
+		ORDER BY
+			t.tag ASC
+	`
+	rows, err := s.db.Query(query, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch tags of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed tag row: %v`, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// CUSTOM: SetFeedTags replaces the tags of the given feed.
+func (s *Storage) SetFeedTags(userID, feedID int64, tags []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.New("store: unable to begin transaction")
+	}
+
+	query := `DELETE FROM feed_tags WHERE feed_id IN (SELECT id FROM feeds WHERE user_id=$1 AND id=$2)`
+	if _, err := tx.Exec(query, userID, feedID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove tags of feed #%d: %v`, feedID, err)
+	}
+
+	query = `
+		INSERT INTO feed_tags
+			(feed_id, tag)
+		SELECT
+			f.id, t.tag
+		FROM
+			feeds f, unnest($3::text[]) AS t(tag)
+		WHERE
+			f.user_id=$1 AND f.id=$2
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(query, userID, feedID, pq.Array(tags)); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to add tags to feed #%d: %v`, feedID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit tags of feed #%d: %v`, feedID, err)
+	}
+
+	// The date view may be filtered by tag
+	s.invalidateDateSectionCounts(userID)
+
+	return nil
+}
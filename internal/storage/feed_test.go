@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestFeedsWithUnreadNotOpenedSince(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	opened := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Opened", now)})
+	notOpened := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Not opened", now)})
+	createIntegrationTestFeed(t, store, user.ID, nil)
+
+	if err := store.SetFeedLastOpenedAt(user.ID, opened.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	feedIDs, err := store.FeedsWithUnreadNotOpenedSince(user.ID, now.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(feedIDs, []int64{notOpened.ID}) {
+		t.Errorf(`Expected only the feed #%d, got %v`, notOpened.ID, feedIDs)
+	}
+}
+
+func TestTopFeedsByUnreadOlderThan(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	old := now.Add(-10 * 24 * time.Hour)
+	busy := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Old 1", old),
+		newIntegrationTestEntry("Old 2", old),
+		newIntegrationTestEntry("Recent", now),
+	})
+	quiet := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Old", old),
+		newIntegrationTestEntry("Recent 1", now),
+		newIntegrationTestEntry("Recent 2", now),
+	})
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Recent", now)})
+
+	counts, err := store.TopFeedsByUnreadOlderThan(user.ID, now.Add(-7*24*time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counts) != 2 {
+		t.Fatalf(`Expected 2 feeds with old unread entries, got %d`, len(counts))
+	}
+
+	if counts[0].FeedID != busy.ID || counts[0].UnreadCount != 2 || counts[1].FeedID != quiet.ID || counts[1].UnreadCount != 1 {
+		t.Errorf(`Unexpected feed counts: %+v, %+v`, counts[0], counts[1])
+	}
+
+	counts, err = store.TopFeedsByUnreadOlderThan(user.ID, now.Add(-7*24*time.Hour), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counts) != 1 || counts[0].FeedID != busy.ID {
+		t.Errorf(`Expected only the busiest feed with a limit of 1, got %d feeds`, len(counts))
+	}
+}
+
+func TestTopFeedRefreshSince(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Fresh", now)})
+	backfilled := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Archive 1", now.Add(-300*24*time.Hour)),
+		newIntegrationTestEntry("Archive 2", now.Add(-200*24*time.Hour)),
+		newIntegrationTestEntry("Archive 3", now.Add(-100*24*time.Hour)),
+	})
+
+	refresh, err := store.TopFeedRefreshSince(user.ID, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refresh == nil || refresh.FeedID != backfilled.ID || refresh.EntryCount != 3 {
+		t.Errorf(`Expected the refresh of the feed #%d creating 3 entries, got %+v`, backfilled.ID, refresh)
+	}
+
+	refresh, err = store.TopFeedRefreshSince(user.ID, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refresh != nil {
+		t.Errorf(`Expected no refresh without entries created since then, got %+v`, refresh)
+	}
+}
+
+func TestFeedReadRatios(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	engaging := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Opened", now),
+		newIntegrationTestEntry("Skimmed", now),
+		newIntegrationTestEntry("Unread", now),
+	})
+	ignored := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Bulk read 1", now),
+		newIntegrationTestEntry("Bulk read 2", now),
+	})
+	young := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Bulk read", now)})
+
+	// Only the entries no longer unread count, whether they were opened or marked as read in bulk
+	readIDs := []int64{engaging.Entries[0].ID, engaging.Entries[1].ID, ignored.Entries[0].ID, ignored.Entries[1].ID, young.Entries[0].ID}
+	if err := store.SetEntriesStatus(user.ID, readIDs, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SetEntryOpenedAt(user.ID, engaging.Entries[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ratios, err := store.FeedReadRatios(user.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The feed with a single settled entry has too short a history
+	if len(ratios) != 2 {
+		t.Fatalf(`Expected 2 feeds, got %d`, len(ratios))
+	}
+
+	if ratios[0].FeedID != ignored.ID || ratios[0].OpenedCount != 0 || ratios[0].SettledCount != 2 || ratios[0].ReadRatio != 0 {
+		t.Errorf(`Unexpected ratio of the least read feed: %+v`, ratios[0])
+	}
+
+	if ratios[1].FeedID != engaging.ID || ratios[1].OpenedCount != 1 || ratios[1].SettledCount != 2 || ratios[1].ReadRatio != 0.5 {
+		t.Errorf(`Unexpected ratio of the most read feed: %+v`, ratios[1])
+	}
+}
+
+func TestFeedReadRatiosLeavesOutEntriesReadBeforeTracking(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	// The entries of the feed were read before their openings were tracked, none of them was opened since
+	now := time.Now()
+	read := createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Read 1", now),
+		newIntegrationTestEntry("Read 2", now),
+	})
+	readIDs := []int64{read.Entries[0].ID, read.Entries[1].ID}
+	if err := store.SetEntriesStatus(user.ID, readIDs, model.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.db.Exec(`UPDATE users SET entry_opened_tracked_since = now() + interval '1 second' WHERE id=$1`, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ratios, err := store.FeedReadRatios(user.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ratios) != 0 {
+		t.Errorf(`Expected the feed read before the tracking to be left alone, got %+v`, ratios[0])
+	}
+}
+
+func TestSetFeedTagsFiltersEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	tagged := createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Tagged", now)})
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{newIntegrationTestEntry("Untagged", now)})
+
+	if err := store.SetFeedTags(user.ID, tagged.ID, []string{"news", "tech"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tags are replaced as a whole
+	if err := store.SetFeedTags(user.ID, tagged.ID, []string{"news"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := store.FeedTags(user.ID, tagged.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(tags, []string{"news"}) {
+		t.Errorf(`Unexpected tags, got %v`, tags)
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithFeedTag("news")
+	entries, err := builder.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].FeedID != tagged.ID {
+		t.Errorf(`Expected only the entry of the tagged feed, got %d entries`, len(entries))
+	}
+}
+
+func TestCountUserFeedsWithErrorsInCategories(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	errored := createIntegrationTestFeed(t, store, user.ID, nil)
+	errored.WithTranslatedErrorMessage("unable to parse feed")
+	errored.ParsingErrorCount = 10
+	if err := store.UpdateFeedError(errored); err != nil {
+		t.Fatal(err)
+	}
+	createIntegrationTestFeed(t, store, user.ID, nil)
+
+	otherCategory, err := store.CreateCategory(user.ID, &model.CategoryCreationRequest{Title: "Other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := store.CountUserFeedsWithErrorsInCategories(user.ID, nil); count != 1 {
+		t.Errorf(`Expected 1 feed with errors without categories, got %d`, count)
+	}
+
+	if count := store.CountUserFeedsWithErrorsInCategories(user.ID, []int64{errored.Category.ID}); count != 1 {
+		t.Errorf(`Expected 1 feed with errors in the feed category, got %d`, count)
+	}
+
+	if count := store.CountUserFeedsWithErrorsInCategories(user.ID, []int64{otherCategory.ID}); count != 0 {
+		t.Errorf(`Expected no feed with errors in the other category, got %d`, count)
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// inboxZeroStreakMaxDays bounds the days read back to compute an inbox zero streak.
+const inboxZeroStreakMaxDays = 3660
+
+// CUSTOM: RecordInboxZeroDay records that the user had no globally visible unread entry left at some point of
+// the given day, in the timezone of the user. Recording the same day again does nothing.
+func (s *Storage) RecordInboxZeroDay(userID int64, day time.Time) error {
+	query := `INSERT INTO inbox_zero_days (user_id, day) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := s.db.Exec(query, userID, day.Format(time.DateOnly)); err != nil {
+		return fmt.Errorf(`store: unable to record the inbox zero day of user #%d: %v`, userID, err)
+	}
+
+	return nil
+}
+
+// CUSTOM: InboxZeroStreak returns the number of consecutive days, up to the given day, the user reached inbox zero.
+func (s *Storage) InboxZeroStreak(userID int64, today time.Time) (int, error) {
+	query := `
+		SELECT
+			to_char(day, 'YYYY-MM-DD')
+		FROM
+			inbox_zero_days
+		WHERE
+			user_id=$1 AND day <= $2
+		ORDER BY
+			day DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, userID, today.Format(time.DateOnly), inboxZeroStreakMaxDays)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to fetch the inbox zero days of user #%d: %v`, userID, err)
+	}
+	defer rows.Close()
+
+	var days []string
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return 0, fmt.Errorf(`store: unable to fetch inbox zero day row: %v`, err)
+		}
+		days = append(days, day)
+	}
+
+	return model.InboxZeroStreak(days, today), nil
+}
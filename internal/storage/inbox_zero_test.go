@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInboxZeroStreak(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	today := time.Date(2025, time.March, 10, 21, 0, 0, 0, time.UTC)
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1), today.AddDate(0, 0, -3)} {
+		if err := store.RecordInboxZeroDay(user.ID, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Recording a day twice keeps a single day
+	if err := store.RecordInboxZeroDay(user.ID, today.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	streak, err := store.InboxZeroStreak(user.ID, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if streak != 2 {
+		t.Errorf(`Expected a streak of 2 days, got %d`, streak)
+	}
+
+	// Days after the given day are ignored
+	if streak, err := store.InboxZeroStreak(user.ID, today.AddDate(0, 0, -2)); err != nil || streak != 0 {
+		t.Errorf(`Expected no streak before the last cleared days, got %d (%v)`, streak, err)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/database"
+	"miniflux.app/v2/internal/model"
+)
+
+const skipIntegrationTestsMessage = `Set TEST_MINIFLUX_DATABASE_URL to run the storage integration tests`
+
+// newIntegrationTestStorage returns a storage connected to the database given by
+// TEST_MINIFLUX_DATABASE_URL, with all migrations applied. The test is skipped
+// when the variable is not set.
+func newIntegrationTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_MINIFLUX_DATABASE_URL")
+	if dsn == "" {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	db, err := database.NewConnectionPool(dsn, 1, 5, time.Minute)
+	if err != nil {
+		t.Fatalf(`Unable to connect to the database: %v`, err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf(`Unable to run database migrations: %v`, err)
+	}
+
+	return NewStorage(db)
+}
+
+// createIntegrationTestUser creates a user with a random username, removed at the end of the test.
+func createIntegrationTestUser(t *testing.T, store *Storage) *model.User {
+	t.Helper()
+
+	user, err := store.CreateUser(&model.UserCreationRequest{
+		Username: fmt.Sprintf("storage_test_%d", rand.IntN(1_000_000_000)),
+		Password: "test123456",
+	})
+	if err != nil {
+		t.Fatalf(`Unable to create user: %v`, err)
+	}
+	t.Cleanup(func() { store.RemoveUser(user.ID) })
+
+	return user
+}
+
+// createIntegrationTestFeed creates a feed in the first category of the user with the given entries.
+func createIntegrationTestFeed(t *testing.T, store *Storage, userID int64, entries model.Entries) *model.Feed {
+	t.Helper()
+
+	category, err := store.FirstCategory(userID)
+	if err != nil {
+		t.Fatalf(`Unable to fetch the user category: %v`, err)
+	}
+
+	feedURL := fmt.Sprintf("https://example.org/feed-%d.xml", rand.IntN(1_000_000_000))
+	feed := &model.Feed{
+		UserID:   userID,
+		FeedURL:  feedURL,
+		SiteURL:  "https://example.org/",
+		Title:    feedURL,
+		Category: category,
+		Entries:  entries,
+	}
+
+	if err := store.CreateFeed(feed); err != nil {
+		t.Fatalf(`Unable to create feed: %v`, err)
+	}
+
+	return feed
+}
+
+// countIntegrationTestDateSectionEntries returns the number of unread entries the date view shows in the section,
+// placed by their display date like on the page.
+func countIntegrationTestDateSectionEntries(t *testing.T, store *Storage, userID int64, section model.DateSection) int {
+	t.Helper()
+
+	builder := store.NewEntryQueryBuilder(userID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithDateViewVisibility(false)
+	builder.WithinDateSection(section, false)
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		t.Fatalf(`Unable to count the entries of the %q section: %v`, section.Name, err)
+	}
+	return count
+}
+
+// newIntegrationTestEntry returns an entry with a unique hash published at the given date.
+func newIntegrationTestEntry(title string, publishedAt time.Time) *model.Entry {
+	entry := model.NewEntry()
+	entry.Title = title
+	entry.Hash = fmt.Sprintf("%s-%d", title, rand.Int64())
+	entry.URL = "https://example.org/" + entry.Hash
+	entry.Date = publishedAt
+	return entry
+}
+
+// seedIntegrationTestEntries inserts count unread entries in the given feed with published dates spread
+// evenly from the from date (included) to the to date (excluded). Rows are generated by PostgreSQL in a
+// single statement, so large data sets can be seeded quickly for date view tests.
+func seedIntegrationTestEntries(t *testing.T, store *Storage, userID, feedID int64, count int, from, to time.Time) {
+	t.Helper()
+
+	query := `
+		INSERT INTO entries
+			(title, hash, url, published_at, display_date, content, author, user_id, feed_id, changed_at)
+		SELECT
+			'Seeded entry ' || n,
+			md5($1 || '-' || n),
+			'https://example.org/seed/' || md5($1 || '-' || n),
+			$2::timestamptz + (($3::timestamptz - $2::timestamptz) * n / $4::int),
+			$2::timestamptz + (($3::timestamptz - $2::timestamptz) * n / $4::int),
+			'',
+			'',
+			$5,
+			$6,
+			now()
+		FROM
+			generate_series(0, $4::int - 1) AS n
+	`
+	seed := fmt.Sprintf("seed-%d", rand.Int64())
+	result, err := store.db.Exec(query, seed, from, to, count, userID, feedID)
+	if err != nil {
+		t.Fatalf(`Unable to seed entries: %v`, err)
+	}
+
+	if inserted, _ := result.RowsAffected(); inserted != int64(count) {
+		t.Fatalf(`Expected %d seeded entries, got %d`, count, inserted)
+	}
+}
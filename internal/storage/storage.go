@@ -12,11 +12,14 @@ import (
 // Storage handles all operations related to the database.
 type Storage struct {
 	db *sql.DB
+
+	// CUSTOM: dateSectionCounts is nil unless EnableDateSectionCountCache is called.
+	dateSectionCounts *dateSectionCountCache
 }
 
 // NewStorage returns a new Storage.
 func NewStorage(db *sql.DB) *Storage {
-	return &Storage{db}
+	return &Storage{db: db}
 }
 
 // DatabaseVersion returns the version of the database which is in use.
@@ -5,9 +5,11 @@ package storage // import "miniflux.app/v2/internal/storage"
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"runtime"
+	"slices"
 	"strings"
 
 	"miniflux.app/v2/internal/crypto"
@@ -98,7 +100,11 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			date_view_use_latest_date,
+			date_view_exclude_seen,
+			date_view_day_reset_hour,
+			date_view_section_definitions
 	`
 
 	tx, err := s.db.Begin()
@@ -144,6 +150,10 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.KeepFilterEntryRules,
 		&user.AlwaysOpenExternalLinks,
 		&user.OpenExternalLinksInNewTab,
+		&user.DateViewUseLatestDate,
+		&user.DateViewExcludeSeen,
+		&user.DateViewDayResetHour,
+		&user.DateViewSectionDefinitions,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -210,9 +220,12 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				block_filter_entry_rules=$27,
 				keep_filter_entry_rules=$28,
 				always_open_external_links=$29,
-				open_external_links_in_new_tab=$30
+				open_external_links_in_new_tab=$30,
+				date_view_use_latest_date=$31,
+				date_view_exclude_seen=$32,
+				date_view_day_reset_hour=$33
 			WHERE
-				id=$31
+				id=$34
 		`
 
 		_, err = s.db.Exec(
@@ -247,6 +260,9 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.KeepFilterEntryRules,
 			user.AlwaysOpenExternalLinks,
 			user.OpenExternalLinksInNewTab,
+			user.DateViewUseLatestDate,
+			user.DateViewExcludeSeen,
+			user.DateViewDayResetHour,
 			user.ID,
 		)
 		if err != nil {
@@ -283,9 +299,12 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				block_filter_entry_rules=$26,
 				keep_filter_entry_rules=$27,
 				always_open_external_links=$28,
-				open_external_links_in_new_tab=$29
+				open_external_links_in_new_tab=$29,
+				date_view_use_latest_date=$30,
+				date_view_exclude_seen=$31,
+				date_view_day_reset_hour=$32
 			WHERE
-				id=$30
+				id=$33
 		`
 
 		_, err := s.db.Exec(
@@ -319,6 +338,9 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.KeepFilterEntryRules,
 			user.AlwaysOpenExternalLinks,
 			user.OpenExternalLinksInNewTab,
+			user.DateViewUseLatestDate,
+			user.DateViewExcludeSeen,
+			user.DateViewDayResetHour,
 			user.ID,
 		)
 
@@ -340,6 +362,131 @@ func (s *Storage) UserLanguage(userID int64) (language string) {
 	return language
 }
 
+// CUSTOM: DateViewCollapsedSections returns the names of the date view sections collapsed by the user.
+func (s *Storage) DateViewCollapsedSections(userID int64) ([]string, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT date_view_collapsed_sections FROM users WHERE id=$1`, userID).Scan(&data)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the collapsed date sections of user #%d: %v`, userID, err)
+	}
+
+	var sections []string
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, fmt.Errorf(`store: unable to decode the collapsed date sections of user #%d: %v`, userID, err)
+	}
+
+	return sections, nil
+}
+
+// CUSTOM: SetDateViewSectionCollapsed adds the section to the collapsed date view sections of the user, or removes it.
+func (s *Storage) SetDateViewSectionCollapsed(userID int64, section string, collapsed bool) error {
+	// Removing the name first keeps each section at most once in the list
+	query := `UPDATE users SET date_view_collapsed_sections = date_view_collapsed_sections - $2 WHERE id=$1`
+	if collapsed {
+		query = `UPDATE users SET date_view_collapsed_sections = (date_view_collapsed_sections - $2) || jsonb_build_array($2::text) WHERE id=$1`
+	}
+
+	if _, err := s.db.Exec(query, userID, section); err != nil {
+		return fmt.Errorf(`store: unable to update the collapsed date section %q of user #%d: %v`, section, userID, err)
+	}
+
+	return nil
+}
+
+// CUSTOM: DateViewBlockedDomains returns the domains whose entries the date view of the user leaves out,
+// in the order they were added.
+func (s *Storage) DateViewBlockedDomains(userID int64) ([]string, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT date_view_blocked_domains FROM users WHERE id=$1`, userID).Scan(&data)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the blocked domains of user #%d: %v`, userID, err)
+	}
+
+	var domains []string
+	if err := json.Unmarshal(data, &domains); err != nil {
+		return nil, fmt.Errorf(`store: unable to decode the blocked domains of user #%d: %v`, userID, err)
+	}
+
+	return domains, nil
+}
+
+// CUSTOM: AddDateViewBlockedDomain adds the domain to the domains blocked in the date view of the user,
+// unless it is already blocked.
+func (s *Storage) AddDateViewBlockedDomain(userID int64, domain string) error {
+	query := `
+		UPDATE users SET
+			date_view_blocked_domains = date_view_blocked_domains || jsonb_build_array($2::text)
+		WHERE
+			id=$1 AND NOT date_view_blocked_domains ? $2
+	`
+	if _, err := s.db.Exec(query, userID, domain); err != nil {
+		return fmt.Errorf(`store: unable to block the domain %q for user #%d: %v`, domain, userID, err)
+	}
+
+	// Blocked domains change which entries each date section counts
+	s.invalidateDateSectionCounts(userID)
+
+	return nil
+}
+
+// CUSTOM: RemoveDateViewBlockedDomain removes the domain from the domains blocked in the date view of the user.
+func (s *Storage) RemoveDateViewBlockedDomain(userID int64, domain string) error {
+	query := `UPDATE users SET date_view_blocked_domains = date_view_blocked_domains - $2 WHERE id=$1`
+	if _, err := s.db.Exec(query, userID, domain); err != nil {
+		return fmt.Errorf(`store: unable to unblock the domain %q for user #%d: %v`, domain, userID, err)
+	}
+
+	s.invalidateDateSectionCounts(userID)
+
+	return nil
+}
+
+// CUSTOM: UpdateDateViewSectionDefinitions replaces the date sections defined by the user, in their display order.
+func (s *Storage) UpdateDateViewSectionDefinitions(userID int64, definitions model.DateSectionDefinitions) error {
+	if _, err := s.db.Exec(`UPDATE users SET date_view_section_definitions=$1 WHERE id=$2`, definitions, userID); err != nil {
+		return fmt.Errorf(`store: unable to update the date section definitions of user #%d: %v`, userID, err)
+	}
+
+	// The cached counts are keyed by section name, which a redefined section keeps
+	s.invalidateDateSectionCounts(userID)
+
+	return nil
+}
+
+// CUSTOM: UpdateDateViewSettings replaces every date view preference of the user in a single statement.
+func (s *Storage) UpdateDateViewSettings(userID int64, settings *model.DateViewSettings) error {
+	// Each section is stored once, an empty list staying a JSON array
+	sections := append(make([]string, 0, len(settings.CollapsedSections)), settings.CollapsedSections...)
+	slices.Sort(sections)
+	collapsedSections, err := json.Marshal(slices.Compact(sections))
+	if err != nil {
+		return fmt.Errorf(`store: unable to encode the collapsed date sections of user #%d: %v`, userID, err)
+	}
+
+	var dayResetHour *int
+	if settings.DayResetHour >= 0 {
+		dayResetHour = &settings.DayResetHour
+	}
+
+	query := `
+		UPDATE users SET
+			date_view_use_latest_date=$1,
+			date_view_exclude_seen=$2,
+			date_view_day_reset_hour=$3,
+			date_view_collapsed_sections=$4
+		WHERE
+			id=$5
+	`
+	if _, err := s.db.Exec(query, settings.UseLatestDate, settings.ExcludeSeen, dayResetHour, collapsedSections, userID); err != nil {
+		return fmt.Errorf(`store: unable to update the date view settings of user #%d: %v`, userID, err)
+	}
+
+	// The preferences change which entries each date section counts
+	s.invalidateDateSectionCounts(userID)
+
+	return nil
+}
+
 // UserByID finds a user by the ID.
 func (s *Storage) UserByID(userID int64) (*model.User, error) {
 	query := `
@@ -374,7 +521,11 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			date_view_use_latest_date,
+			date_view_exclude_seen,
+			date_view_day_reset_hour,
+			date_view_section_definitions
 		FROM
 			users
 		WHERE
@@ -417,7 +568,11 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			date_view_use_latest_date,
+			date_view_exclude_seen,
+			date_view_day_reset_hour,
+			date_view_section_definitions
 		FROM
 			users
 		WHERE
@@ -460,7 +615,11 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			date_view_use_latest_date,
+			date_view_exclude_seen,
+			date_view_day_reset_hour,
+			date_view_section_definitions
 		FROM
 			users
 		WHERE
@@ -510,7 +669,11 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.block_filter_entry_rules,
 			u.keep_filter_entry_rules,
 			u.always_open_external_links,
-			u.open_external_links_in_new_tab
+			u.open_external_links_in_new_tab,
+			u.date_view_use_latest_date,
+			u.date_view_exclude_seen,
+			u.date_view_day_reset_hour,
+			u.date_view_section_definitions
 		FROM
 			users u
 		LEFT JOIN
@@ -555,6 +718,10 @@ func (s *Storage) fetchUser(query string, args ...any) (*model.User, error) {
 		&user.KeepFilterEntryRules,
 		&user.AlwaysOpenExternalLinks,
 		&user.OpenExternalLinksInNewTab,
+		&user.DateViewUseLatestDate,
+		&user.DateViewExcludeSeen,
+		&user.DateViewDayResetHour,
+		&user.DateViewSectionDefinitions,
 	)
 
 	if err == sql.ErrNoRows {
@@ -670,7 +837,11 @@ func (s *Storage) Users() (model.Users, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			date_view_use_latest_date,
+			date_view_exclude_seen,
+			date_view_day_reset_hour,
+			date_view_section_definitions
 		FROM
 			users
 		ORDER BY username ASC
@@ -716,6 +887,10 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.KeepFilterEntryRules,
 			&user.AlwaysOpenExternalLinks,
 			&user.OpenExternalLinksInNewTab,
+			&user.DateViewUseLatestDate,
+			&user.DateViewExcludeSeen,
+			&user.DateViewDayResetHour,
+			&user.DateViewSectionDefinitions,
 		)
 
 		if err != nil {
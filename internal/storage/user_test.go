@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestSetDateViewSectionCollapsed(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	collapsedSections := func() []string {
+		sections, err := store.DateViewCollapsedSections(user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sections
+	}
+
+	if sections := collapsedSections(); len(sections) != 0 {
+		t.Fatalf(`Expected no collapsed section for a new user, got %v`, sections)
+	}
+
+	// Collapsing a section twice keeps a single occurrence
+	for _, section := range []string{"today", "last7d", "today"} {
+		if err := store.SetDateViewSectionCollapsed(user.ID, section, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if sections := collapsedSections(); !slices.Equal(sections, []string{"last7d", "today"}) {
+		t.Fatalf(`Unexpected collapsed sections: %v`, sections)
+	}
+
+	if err := store.SetDateViewSectionCollapsed(user.ID, "last7d", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if sections := collapsedSections(); !slices.Equal(sections, []string{"today"}) {
+		t.Fatalf(`Unexpected collapsed sections after expanding one: %v`, sections)
+	}
+}
+
+func TestUpdateDateViewSettingsReplacesEveryPreference(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	if err := store.SetDateViewSectionCollapsed(user.ID, "today", true); err != nil {
+		t.Fatal(err)
+	}
+
+	err := store.UpdateDateViewSettings(user.ID, &model.DateViewSettings{
+		UseLatestDate:     true,
+		ExcludeSeen:       true,
+		DayResetHour:      4,
+		CollapsedSections: []string{"earlier", "last7d", "earlier"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := store.UserByID(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !updated.DateViewUseLatestDate || !updated.DateViewExcludeSeen || updated.DateViewDayResetHourOrRolling() != 4 {
+		t.Errorf(`Unexpected date view preferences %v, %v, %d`, updated.DateViewUseLatestDate, updated.DateViewExcludeSeen, updated.DateViewDayResetHourOrRolling())
+	}
+
+	sections, err := store.DateViewCollapsedSections(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(sections, []string{"earlier", "last7d"}) {
+		t.Errorf(`Expected the collapsed sections to be replaced, got %v`, sections)
+	}
+
+	// Rolling days and no collapsed section are stored as such
+	if err := store.UpdateDateViewSettings(user.ID, &model.DateViewSettings{DayResetHour: -1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if sections, err := store.DateViewCollapsedSections(user.ID); err != nil || len(sections) != 0 || sections == nil {
+		t.Errorf(`Expected an empty list of collapsed sections, got %v (%v)`, sections, err)
+	}
+}
+
+func TestDateViewBlockedDomainsLeaveOutMatchingEntries(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	newEntry := func(title, url string) *model.Entry {
+		entry := newIntegrationTestEntry(title, now.Add(-time.Hour))
+		entry.URL = url
+		return entry
+	}
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newEntry("blocked", "https://aggregator.example/story"),
+		newEntry("subdomain", "https://News.Aggregator.example:8443/story"),
+		newEntry("lookalike", "https://notaggregator.example/story"),
+		newEntry("relative", "/story"),
+	})
+
+	// Blocking a domain twice keeps a single occurrence
+	for _, domain := range []string{"aggregator.example", "other.example", "aggregator.example"} {
+		if err := store.AddDateViewBlockedDomain(user.ID, domain); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.RemoveDateViewBlockedDomain(user.ID, "other.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	domains, err := store.DateViewBlockedDomains(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(domains, []string{"aggregator.example"}) {
+		t.Fatalf(`Unexpected blocked domains: %v`, domains)
+	}
+
+	entries, err := NewEntryQueryBuilder(store, user.ID).WithoutBlockedDomains().WithSorting("e.title", "ASC").GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var titles []string
+	for _, entry := range entries {
+		titles = append(titles, entry.Title)
+	}
+	if !slices.Equal(titles, []string{"lookalike", "relative"}) {
+		t.Errorf(`Unexpected entries outside of the blocked domains: %v`, titles)
+	}
+}
+
+func TestUpdateDateViewSectionDefinitionsInvalidatesTheSectionCounts(t *testing.T) {
+	store := newIntegrationTestStorage(t)
+	store.EnableDateSectionCountCache()
+	user := createIntegrationTestUser(t, store)
+
+	now := time.Now()
+	createIntegrationTestFeed(t, store, user.ID, model.Entries{
+		newIntegrationTestEntry("Today", now.Add(-time.Hour)),
+		newIntegrationTestEntry("This week", now.Add(-3*24*time.Hour)),
+	})
+
+	countFirstSection := func() int {
+		t.Helper()
+
+		user, err := store.UserByID(user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		section := user.DateSections(model.DateSectionSchemeDefault, now)[0]
+		count, err := store.DateSectionCount(user.ID, now, section.Name, func() (int, error) {
+			builder := store.NewEntryQueryBuilder(user.ID)
+			builder.WithStatus(model.EntryStatusUnread)
+			builder.AfterDisplayDate(*section.After)
+			return builder.CountEntries()
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if err := store.UpdateDateViewSectionDefinitions(user.ID, model.DateSectionDefinitions{{Label: "Recent", Days: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if count := countFirstSection(); count != 1 {
+		t.Fatalf(`Expected 1 entry in the last day, got %d`, count)
+	}
+
+	// The first section keeps its name but covers a whole week now
+	if err := store.UpdateDateViewSectionDefinitions(user.ID, model.DateSectionDefinitions{{Label: "Recent", Days: 7}}); err != nil {
+		t.Fatal(err)
+	}
+	if count := countFirstSection(); count != 2 {
+		t.Errorf(`Expected 2 entries in the last week after redefining the section, got %d`, count)
+	}
+}
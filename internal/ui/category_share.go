@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+// CUSTOM: createSharedCategory shares the date digest of the category with anyone having its link.
+func (h *handler) createSharedCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID := request.RouteInt64Param(r, "categoryID")
+	shareCode, err := h.store.CategoryShareCode(request.UserID(r), categoryID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "sharedCategoryDigest", "shareCode", shareCode))
+}
+
+// CUSTOM: unshareCategory revokes the link to the date digest of the category.
+func (h *handler) unshareCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID := request.RouteInt64Param(r, "categoryID")
+	if err := h.store.UnshareCategory(request.UserID(r), categoryID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "editCategory", "categoryID", categoryID))
+}
+
+// CUSTOM: sharedCategoryDigest renders the date digest of a shared category without authentication.
+// It is read-only: the mark as read actions of the date view stay behind the user session.
+func (h *handler) sharedCategoryDigest(w http.ResponseWriter, r *http.Request) {
+	shareCode := request.RouteStringParam(r, "shareCode")
+	if shareCode == "" {
+		html.NotFound(w, r)
+		return
+	}
+
+	category, err := h.store.CategoryByShareCode(shareCode)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if category == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(category.UserID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	sectionNames := []string{"today"}
+	if request.QueryBoolParam(r, "last2d", false) {
+		sectionNames = append(sectionNames, "last2d")
+	}
+
+	// The sections follow the timezone of the category owner, the reader cannot move them
+	now := timezone.Now(user.Timezone)
+	digestSections, err := h.dateDigestSections(r, user, now, sectionNames, category)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("sections", digestSections)
+	view.Set("generatedAt", now)
+
+	html.OK(w, r, view.Render("date_digest"))
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/template"
+
+	"github.com/gorilla/mux"
+)
+
+func TestSharedCategoryDigestIgnoresTheDateViewFilters(t *testing.T) {
+	store := newQueryCountingTestStorage(t)
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	user, err := store.CreateUser(&model.UserCreationRequest{
+		Username: fmt.Sprintf("ui_test_%d", rand.IntN(1_000_000_000)),
+		Password: "test123456",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.RemoveUser(user.ID) })
+
+	// A category hidden from the global views is still the whole scope of its digest
+	category, err := store.CreateCategory(user.ID, &model.CategoryCreationRequest{Title: "Shared", HideGlobally: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry := func(title string) *model.Entry {
+		entry := model.NewEntry()
+		entry.Title = title
+		entry.Hash = crypto.SHA256(fmt.Sprint(rand.Int64()))
+		entry.URL = "https://example.org/" + entry.Hash
+		entry.Date = time.Now().Add(-time.Minute)
+		return entry
+	}
+	unread := newEntry("Unread entry")
+	removed := newEntry("Removed entry")
+	if err := store.CreateFeed(&model.Feed{
+		UserID:   user.ID,
+		FeedURL:  fmt.Sprintf("https://example.org/feed-%d.xml", rand.IntN(1_000_000_000)),
+		SiteURL:  "https://example.org/",
+		Title:    "Feed",
+		Category: category,
+		Entries:  model.Entries{unread, removed},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetEntriesStatus(user.ID, []int64{removed.ID}, model.EntryStatusRemoved); err != nil {
+		t.Fatal(err)
+	}
+
+	shareCode, err := store.CategoryShareCode(user.ID, category.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	Serve(router, store, nil)
+	templateEngine := template.NewEngine(router)
+	templateEngine.ParseTemplates()
+	h := &handler{router: router, store: store, tpl: templateEngine}
+
+	// The anonymous reader cannot list the trash of the owner, nor narrow the digest down
+	r := httptest.NewRequest(http.MethodGet, "/share/category/"+shareCode+"?trash=1&starred=1&include_disabled=1&opened=1&label=work&lang=fr", nil)
+	r = mux.SetURLVars(r, map[string]string{"shareCode": shareCode})
+	w := httptest.NewRecorder()
+	h.sharedCategoryDigest(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusOK)
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, unread.Title) {
+		t.Errorf(`The unread entry of the shared category is missing from the digest`)
+	}
+	if strings.Contains(body, removed.Title) {
+		t.Errorf(`The removed entry of the shared category is listed by the digest`)
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// dateViewBlockedDomainsMaxCount bounds the number of domains a user can block in the date view.
+const dateViewBlockedDomainsMaxCount = 200
+
+// dateViewBlockedDomainRequest is the body of the requests blocking or unblocking a domain.
+type dateViewBlockedDomainRequest struct {
+	Domain string `json:"domain"`
+}
+
+// CUSTOM: showDateViewBlockedDomains returns the domains whose entries the date view leaves out.
+func (h *handler) showDateViewBlockedDomains(w http.ResponseWriter, r *http.Request) {
+	domains, err := h.store.DateViewBlockedDomains(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, domains)
+}
+
+// CUSTOM: blockDateViewDomain leaves the entries of the domain of the request, and of its subdomains, out of the
+// date view counts and entries. The domain may be given as a URL, only its host is kept.
+func (h *handler) blockDateViewDomain(w http.ResponseWriter, r *http.Request) {
+	domain, err := decodeDateViewBlockedDomain(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDomain, err)
+		return
+	}
+
+	userID := request.UserID(r)
+
+	domains, err := h.store.DateViewBlockedDomains(userID)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	if len(domains) >= dateViewBlockedDomainsMaxCount {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDomain, fmt.Errorf("more than %d blocked domains", dateViewBlockedDomainsMaxCount))
+		return
+	}
+
+	if err := h.store.AddDateViewBlockedDomain(userID, domain); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	domains, err = h.store.DateViewBlockedDomains(userID)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.Created(w, r, domains)
+}
+
+// CUSTOM: unblockDateViewDomain brings the entries of the domain of the request back into the date view.
+func (h *handler) unblockDateViewDomain(w http.ResponseWriter, r *http.Request) {
+	domain, err := decodeDateViewBlockedDomain(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDomain, err)
+		return
+	}
+
+	if err := h.store.RemoveDateViewBlockedDomain(request.UserID(r), domain); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+// decodeDateViewBlockedDomain returns the normalized domain of the request body.
+func decodeDateViewBlockedDomain(r *http.Request) (string, error) {
+	var domainRequest dateViewBlockedDomainRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&domainRequest); err != nil {
+		return "", err
+	}
+	return normalizeDateViewBlockedDomain(domainRequest.Domain)
+}
+
+// normalizeDateViewBlockedDomain returns the lowercase domain name of the value, the host of the value when it is
+// a URL, without its trailing dot. Only ASCII domain names are accepted, as entry URLs are matched on their host.
+func normalizeDateViewBlockedDomain(value string) (string, error) {
+	domain := strings.ToLower(strings.TrimSpace(value))
+	if strings.Contains(domain, "://") {
+		u, err := url.Parse(domain)
+		if err != nil {
+			return "", fmt.Errorf("invalid domain %q: %v", value, err)
+		}
+		domain = u.Hostname()
+	}
+	domain = strings.TrimSuffix(domain, ".")
+
+	if domain == "" {
+		return "", errors.New("empty domain")
+	}
+
+	if len(domain) > 253 {
+		return "", fmt.Errorf("invalid domain %q: longer than 253 characters", value)
+	}
+
+	for label := range strings.SplitSeq(domain, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return "", fmt.Errorf("invalid domain %q", value)
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return "", fmt.Errorf("invalid domain %q", value)
+			}
+		}
+	}
+
+	return domain, nil
+}
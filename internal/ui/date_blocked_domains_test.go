@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDateViewBlockedDomain(t *testing.T) {
+	scenarios := map[string]string{
+		"example.org":                        "example.org",
+		"  News.Example.ORG. ":               "news.example.org",
+		"https://user@www.example.org:8080/": "www.example.org",
+		"localhost":                          "localhost",
+	}
+	for value, expected := range scenarios {
+		domain, err := normalizeDateViewBlockedDomain(value)
+		if err != nil {
+			t.Errorf(`Unexpected error for %q: %v`, value, err)
+			continue
+		}
+		if domain != expected {
+			t.Errorf(`Unexpected domain for %q, got %q instead of %q`, value, domain, expected)
+		}
+	}
+
+	for _, value := range []string{"", " ", "https:///path", "example..org", "-example.org", "exa_mple.org", "%.org", "éxample.org", strings.Repeat("a.", 127) + "org"} {
+		if domain, err := normalizeDateViewBlockedDomain(value); err == nil {
+			t.Errorf(`Expected an error for %q, got %q`, value, domain)
+		}
+	}
+}
+
+func TestBlockDateViewDomainRejectsInvalidDomains(t *testing.T) {
+	for _, body := range []string{`{"domain": 42}`, `{"domain": ""}`, `{"domain": "not a domain"}`} {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/blocked-domains", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		// The domain is validated before any database access.
+		h := &handler{}
+		h.blockDateViewDomain(w, r)
+
+		resp := w.Result()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf(`Unexpected status code for %s, got %d instead of %d`, body, resp.StatusCode, http.StatusBadRequest)
+		}
+
+		var result struct {
+			ErrorCode string `json:"error_code"`
+		}
+		if err := json_parser.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatal(err)
+		}
+
+		if result.ErrorCode != dateViewErrorInvalidDomain {
+			t.Errorf(`Unexpected error code for %s, got %q instead of %q`, body, result.ErrorCode, dateViewErrorInvalidDomain)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+const (
+	// dateDigestMaxEntries caps the entries listed for each section, the others are only counted.
+	dateDigestMaxEntries = 25
+
+	// dateDigestSnippetLength is the number of characters of the entry content shown below the title.
+	dateDigestSnippetLength = 280
+)
+
+// dateDigestSection holds what the digest template renders for one date section.
+type dateDigestSection struct {
+	Label   string
+	URL     string
+	Count   int
+	Entries []*dateDigestEntry
+}
+
+// dateDigestEntry holds a digest entry. Links are absolute so they keep working from an email client.
+type dateDigestEntry struct {
+	Title     string
+	URL       string
+	FeedTitle string
+	Snippet   string
+}
+
+// CUSTOM: showDateDigestPage renders the today section, and the last2d section with "last2d=1", as a
+// standalone page with inline styles that can be sent by email.
+func (h *handler) showDateDigestPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	sectionNames := []string{"today"}
+	if request.QueryBoolParam(r, "last2d", false) {
+		sectionNames = append(sectionNames, "last2d")
+	}
+
+	digestSections, err := h.dateDigestSections(r, user, now, sectionNames, nil)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("sections", digestSections)
+	view.Set("user", user)
+	view.Set("generatedAt", now)
+
+	html.OK(w, r, view.Render("date_digest"))
+}
+
+// dateDigestSections returns the digest of the named date sections. The digest of a shared category only holds
+// the entries of the category, and links them to their website since its reader has no account.
+func (h *handler) dateDigestSections(r *http.Request, user *model.User, now time.Time, sectionNames []string, sharedCategory *model.Category) ([]*dateDigestSection, error) {
+	rootURL := config.Opts.RootURL()
+	printer := locale.NewPrinter(user.Language)
+	sections := user.DateSections(model.DateSectionSchemeDefault, now)
+
+	// The reader of a shared category is anonymous, the filters of the query string only apply to the owner
+	newBuilder := func(s model.DateSection) *storage.EntryQueryBuilder {
+		if sharedCategory != nil {
+			return h.newSharedCategoryDigestQueryBuilder(sharedCategory, s)
+		}
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, s)
+		return builder
+	}
+
+	// The sections of a shared category link back to its digest, which is all its reader can access
+	sectionURL := func(s model.DateSection) string {
+		if sharedCategory != nil {
+			return rootURL + route.Path(h.router, "sharedCategoryDigest", "shareCode", sharedCategory.ShareCode)
+		}
+		return rootURL + route.Path(h.router, "dateEntries") + "?section=" + s.Name
+	}
+
+	countKey := func(s model.DateSection) string {
+		if sharedCategory != nil {
+			return "share?category=" + strconv.FormatInt(sharedCategory.ID, 10) + "&section=" + s.Name
+		}
+		return dateViewCountKey(r, user, s.Name)
+	}
+
+	digestSections := make([]*dateDigestSection, 0, len(sectionNames))
+	for _, name := range sectionNames {
+		s, _ := model.FindDateSection(sections, name)
+
+		count, err := h.store.DateSectionCount(user.ID, now, countKey(s), func() (int, error) {
+			return newBuilder(s).CountEntries()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		builder := newBuilder(s)
+		builder.WithPinnedFeedsFirst()
+		builder.WithStableSorting(user.EntryOrder, user.EntryDirection)
+		builder.WithLimit(dateDigestMaxEntries)
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return nil, err
+		}
+
+		digestSection := &dateDigestSection{
+			Label:   s.DisplayLabel(printer.Printf),
+			URL:     sectionURL(s),
+			Count:   count,
+			Entries: make([]*dateDigestEntry, 0, len(entries)),
+		}
+
+		for _, entry := range entries {
+			entryURL := rootURL + route.Path(h.router, "unreadEntry", "entryID", entry.ID)
+			if sharedCategory != nil {
+				entryURL = entry.URL
+			}
+
+			digestSection.Entries = append(digestSection.Entries, &dateDigestEntry{
+				Title:     entry.Title,
+				URL:       entryURL,
+				FeedTitle: entry.Feed.Title,
+				Snippet:   sanitizer.TruncateHTML(entry.Content, dateDigestSnippetLength),
+			})
+		}
+
+		digestSections = append(digestSections, digestSection)
+	}
+
+	return digestSections, nil
+}
+
+// newSharedCategoryDigestQueryBuilder returns a query builder for the unread entries of the shared category within
+// the display date bounds of the section. It ignores every filter of the date view: the category is the whole
+// scope of the digest, so the category being hidden from the global views does not empty it.
+func (h *handler) newSharedCategoryDigestQueryBuilder(category *model.Category, section model.DateSection) *storage.EntryQueryBuilder {
+	builder := h.store.NewEntryQueryBuilder(category.UserID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithCategoryID(category.ID)
+	if section.After != nil {
+		builder.AfterDisplayDate(*section.After)
+	}
+	if section.Before != nil {
+		builder.BeforeDisplayDate(*section.Before)
+	}
+	return builder
+}
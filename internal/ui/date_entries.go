@@ -4,187 +4,692 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/timezone"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
 )
 
+// dateViewBacklogFeedsLimit is the number of feeds listed with the most unread entries of the oldest section.
+const dateViewBacklogFeedsLimit = 10
+
+// dateViewSeenEntriesLimit is the number of entries of the newest section remembered as seen by a session.
+const dateViewSeenEntriesLimit = 1000
+
+// dateSectionView holds what the date entries template renders for one section.
+type dateSectionView struct {
+	Name    string
+	Label   string
+	Count   int
+	Entries model.Entries
+	URL     string
+
+	// NextURL lists the next page of the section, empty on its last page.
+	NextURL string
+
+	// Lazy is set for month sections, whose entries are only fetched when the month is selected.
+	Lazy bool
+
+	// Collapsed is set for sections the user collapsed on the section=all view.
+	Collapsed bool
+
+	// SeenCount is the number of entries left out of Count because a previous load of the session showed them.
+	SeenCount int
+
+	// HasPriority is set when some of the unread entries of the section come from priority feeds.
+	HasPriority bool
+
+	// DaysAgo holds the calendar days since the date of each listed entry, in the timezone of the sections.
+	DaysAgo map[int64]int
+
+	// After and Before are the exclusive bounds of the section, nil when that side is open.
+	// The timeline layout spaces the entries between them by their publication date.
+	After  *time.Time
+	Before *time.Time
+
+	// AvgAge is the average age of the publication dates of the entries of the section, zero when it is not counted.
+	AvgAge time.Duration
+
+	// CatchUp is the time reading the entries of the section takes at the recent reading pace of the user,
+	// zero without an estimate.
+	CatchUp time.Duration
+}
+
+// AvgAgeHours returns the average age of the entries of the section in whole hours.
+func (s *dateSectionView) AvgAgeHours() int {
+	return int(s.AvgAge / time.Hour)
+}
+
+// AvgAgeDays returns the average age of the entries of the section in whole days.
+func (s *dateSectionView) AvgAgeDays() int {
+	return int(s.AvgAge / (24 * time.Hour))
+}
+
+// CatchUpDays returns the catch-up estimate of the section in days, rounded up.
+func (s *dateSectionView) CatchUpDays() int {
+	return int((s.CatchUp + 24*time.Hour - 1) / (24 * time.Hour))
+}
+
 func (h *handler) showDateEntriesPage(w http.ResponseWriter, r *http.Request) {
+	// Each page load runs a query per section, a user may only have a few of them in flight
+	if !h.dateViewLoads.acquire(request.UserID(r)) {
+		html.TooManyRequests(w, r, dateViewLoadRetryAfter)
+		return
+	}
+	defer h.dateViewLoads.release(request.UserID(r))
+
 	user, err := h.store.UserByID(request.UserID(r))
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
-	// Get section filter from query parameter (default: "today")
-	section := request.QueryStringParam(r, "section", "today")
-
-	// Get current time in user's timezone
-	now := timezone.Now(user.Timezone)
+	// Get current time in user's timezone, or the client-supplied reference time
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
 
-	// Calculate date boundaries using rolling time windows to align with elapsed time display
-	// These match the elapsedTime function logic in internal/template/functions.go:
-	// - < 24h: "X hours ago" → Today
-	// - 24-48h: "yesterday" → Last 2 days
-	// - 2-21 days: "X days ago" → Last 7 days / Last 30 days
-	todayStart := now.Add(-24 * time.Hour)        // Last 24 hours (< 86400 seconds)
-	last2dStart := now.Add(-48 * time.Hour)       // 24-48 hours ago (matches "yesterday")
-	last7dStart := now.Add(-7 * 24 * time.Hour)   // 2-7 days ago
-	last30dStart := now.Add(-30 * 24 * time.Hour) // 7-30 days ago
-	// Earlier: anything before last30dStart (>30 days ago)
+	// The sections are still shown in UTC when the stored timezone is broken, with a warning
+	invalidTimezone := ""
+	if !dateViewTimezoneIsValid(user.Timezone) {
+		invalidTimezone = user.Timezone
+		slog.Warn("Invalid user timezone, the date view falls back to UTC",
+			slog.Int64("user_id", user.ID),
+			slog.String("timezone", user.Timezone),
+		)
+	}
 
-	// Helper function to count entries for a date range
-	countForDateRange := func(afterDate, beforeDate *time.Time) (int, error) {
-		builder := h.store.NewEntryQueryBuilder(user.ID)
-		builder.WithStatus(model.EntryStatusUnread)
-		builder.WithGloballyVisible()
-		if afterDate != nil {
-			builder.AfterPublishedDate(*afterDate)
-		}
-		if beforeDate != nil {
-			builder.BeforePublishedDate(*beforeDate)
-		}
-		return builder.CountEntries()
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
 	}
 
-	// Helper function to fetch entries for a date range
-	fetchForDateRange := func(afterDate, beforeDate *time.Time) ([]*model.Entry, error) {
-		builder := h.store.NewEntryQueryBuilder(user.ID)
-		builder.WithStatus(model.EntryStatusUnread)
-		builder.WithGloballyVisible()
-		builder.WithSorting(user.EntryOrder, user.EntryDirection)
-		builder.WithSorting("id", user.EntryDirection)
-		if afterDate != nil {
-			builder.AfterPublishedDate(*afterDate)
-		}
-		if beforeDate != nil {
-			builder.BeforePublishedDate(*beforeDate)
-		}
-		return builder.GetEntries()
+	if _, err := dateViewMedia(r); err != nil {
+		html.BadRequest(w, r, err)
+		return
 	}
 
-	// Get counts for all sections (for navigation)
-	countToday, err := countForDateRange(&todayStart, nil)
-	if err != nil {
-		html.ServerError(w, r, err)
+	if _, err := dateViewLanguage(r); err != nil {
+		html.BadRequest(w, r, err)
 		return
 	}
 
-	countLast2d, err := countForDateRange(&last2dStart, &todayStart)
-	if err != nil {
-		html.ServerError(w, r, err)
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		html.BadRequest(w, r, err)
 		return
 	}
 
-	countLast7d, err := countForDateRange(&last7dStart, &last2dStart)
-	if err != nil {
-		html.ServerError(w, r, err)
+	if _, _, err := dateViewDuration(r); err != nil {
+		html.BadRequest(w, r, err)
 		return
 	}
 
-	countLast30d, err := countForDateRange(&last30dStart, &last7dStart)
+	cursor, err := dateViewCursor(r)
 	if err != nil {
-		html.ServerError(w, r, err)
+		html.BadRequest(w, r, err)
 		return
 	}
 
-	countEarlier, err := countForDateRange(nil, &last30dStart)
+	// The entries are sorted by the order of the user unless the visit asks for another one
+	order, err := dateViewOrder(r, user.EntryOrder)
 	if err != nil {
-		html.ServerError(w, r, err)
+		html.BadRequest(w, r, err)
 		return
 	}
 
-	// Initialize empty entry slices
-	var todayEntries, last2dEntries, last7dEntries, last30dEntries, earlierEntries []*model.Entry
+	// The category filters only apply to categories of the user, a single one can be marked as read at once
+	categoryIDs := dateViewCategoryIDs(r)
+	if !h.dateViewCategoriesExist(user.ID, categoryIDs) || !h.dateViewCategoriesExist(user.ID, dateViewExcludedCategoryIDs(r)) {
+		html.NotFound(w, r)
+		return
+	}
 
-	// Fetch entries only for the selected section
-	switch section {
-	case "today":
-		todayEntries, err = fetchForDateRange(&todayStart, nil)
+	var category *model.Category
+	if len(categoryIDs) == 1 {
+		category, err = h.store.Category(user.ID, categoryIDs[0])
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-	case "last2d":
-		last2dEntries, err = fetchForDateRange(&last2dStart, &todayStart)
+	}
+
+	// The opened recently, in progress, new feeds and future sections come last, they hold entries of the other sections again
+	sections := user.DateSections(dateViewScheme(r), now)
+	publicationSections := model.PublicationDateSections(sections)
+	oldest := publicationSections[len(publicationSections)-1]
+
+	// An explicit range of days replaces the rolling sections by a single custom one
+	customSection, err := dateViewCustomSection(r, now.Location())
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if customSection != nil {
+		sections = []model.DateSection{*customSection}
+	}
+
+	// Optionally split the oldest section into calendar months, counted with a single grouped query
+	monthCounts := make(map[string]int)
+	if dateViewEarlierByMonth(r) && customSection == nil {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, oldest)
+		counts, err := builder.CountEntriesByMonth(userTimezone, user.DateViewUseLatestDate)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-	case "last7d":
-		last7dEntries, err = fetchForDateRange(&last7dStart, &last2dStart)
+
+		months := make([]time.Time, 0, len(counts))
+		for _, count := range counts {
+			months = append(months, count.Month)
+		}
+
+		for i, monthSection := range model.NewMonthDateSections(oldest, months) {
+			monthCounts[monthSection.Name] = counts[i].Count
+		}
+		sections = dateViewWithMonthSections(sections, months)
+	}
+
+	// Get section filter from query parameter (default: the newest section)
+	section := request.QueryStringParam(r, "section", sections[0].Name)
+	if _, found := model.FindDateSection(sections, section); !found {
+		section = "all"
+	}
+	if customSection != nil {
+		section = customSection.Name
+	}
+
+	// The focus mode only lists the newest section
+	focusUnvisited := dateViewFocusUnvisited(r)
+	if focusUnvisited {
+		section = sections[0].Name
+	}
+
+	// List the feeds contributing most to the backlog when the oldest section, or one of its months, is selected.
+	// The trash has no backlog.
+	var backlogFeeds []*model.FeedUnreadCount
+	if selectedSection, found := model.FindDateSection(sections, section); found && !dateViewTrash(r) && (selectedSection.Name == oldest.Name || !selectedSection.Month.IsZero()) {
+		backlogFeeds, err = h.store.TopFeedsByUnreadOlderThan(user.ID, *oldest.Before, dateViewBacklogFeedsLimit)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-	case "last30d":
-		last30dEntries, err = fetchForDateRange(&last30dStart, &last7dStart)
+	}
+
+	// Embedded widgets that do not render the navigation skip the counts of the other sections with "nav=0"
+	showNavigation := dateViewShowNavigation(r)
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Helper function to count entries for a date section
+	// The counts are polled often, so they go through the date section count cache when it is enabled
+	countForDateSection := func(s model.DateSection) (int, error) {
+		return h.store.DateSectionCount(user.ID, now, dateViewCountKey(r, user, s.Name), func() (int, error) {
+			builder := h.newDateViewQueryBuilder(r, user.ID)
+			withDateSectionBounds(r, builder, user, s)
+			return builder.CountEntries()
+		})
+	}
+
+	// Helper function to check whether a date section has unread entries from priority feeds
+	hasPriorityForDateSection := func(s model.DateSection) (bool, error) {
+		count, err := h.store.DateSectionCount(user.ID, now, dateViewCountKey(r, user, s.Name)+"&priority=true", func() (int, error) {
+			builder := h.newDateViewQueryBuilder(r, user.ID)
+			builder.WithPriorityFeeds()
+			withDateSectionBounds(r, builder, user, s)
+			return builder.CountEntries()
+		})
+		return count > 0, err
+	}
+
+	// Entries of the feeds subscribed to by hand may be sorted first within the sections
+	manualFirst := dateViewBoostManual(r)
+
+	// Entries sharing the same sort value may be sorted by title or feed title rather than by ID only
+	tiebreak := dateViewTiebreak(r)
+
+	// Helper function to fetch entries for a date section, along with the cursor of the next page.
+	// Sections by publication date can be very large, so they are paginated by keyset rather than by offset.
+	fetchForDateSection := func(s model.DateSection, after *model.EntryCursor, pageSize int) (model.Entries, *model.EntryCursor, error) {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, s)
+		direction := dateViewSectionDirection(r, s, user.EntryDirection)
+		if !s.ByPublicationDate() {
+			// Entries being read are listed by the last time the reader saved their progress
+			if s.InProgress {
+				builder.WithStableSorting("e.read_progress_updated_at", "desc")
+			} else {
+				builder.WithStableTiebreakSorting(order, tiebreak, direction)
+			}
+			entries, err := builder.GetEntries()
+			return entries, nil, err
+		}
+
+		builder.WithPinnedFeedsFirst()
+		if manualFirst {
+			builder.WithManualFeedsFirst()
+		}
+		builder.WithStableTiebreakSorting(order, tiebreak, direction)
+		if after != nil {
+			builder.AfterCursor(after, order, direction)
+		}
+
+		// One more entry than a page tells whether there is a next page
+		builder.WithLimit(pageSize + 1)
+		entries, err := builder.GetEntries()
+		if err != nil || len(entries) <= pageSize {
+			return entries, nil, err
+		}
+
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+		return entries, model.NewEntryCursor(last, order, true, manualFirst).WithTiebreak(last, tiebreak), nil
+	}
+
+	// Helper function to fetch the newest entry of each feed not opened today for a date section
+	fetchNewestOfUnvisitedFeeds := func(s model.DateSection) (model.Entries, error) {
+		feedIDs, err := h.store.FeedsWithUnreadNotOpenedSince(user.ID, midnight)
+		if err != nil || len(feedIDs) == 0 {
+			return nil, err
+		}
+
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		builder.WithFeedIDs(feedIDs)
+		builder.WithStableSorting("published_at", "desc")
+		withDateSectionBounds(r, builder, user, s)
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return nil, err
+		}
+
+		newestEntries := make(model.Entries, 0, len(feedIDs))
+		seenFeeds := make(map[int64]bool, len(feedIDs))
+		for _, entry := range entries {
+			if !seenFeeds[entry.FeedID] {
+				seenFeeds[entry.FeedID] = true
+				newestEntries = append(newestEntries, entry)
+			}
+		}
+		return newestEntries, nil
+	}
+
+	// Entries of the newest section shown by a previous load of the session are left out of its count
+	// when the user prefers it, so the count only reflects entries never shown
+	seenEntryIDs := request.DateViewSeenEntryIDs(r)
+	excludeSeen := user.DateViewExcludeSeen && len(seenEntryIDs) > 0
+	newestFullyFetched := false
+
+	// Helper function to count the entries of a date section never shown during the session
+	countUnseenForDateSection := func(s model.DateSection, entries model.Entries, fullyFetched bool) (int, error) {
+		if fullyFetched {
+			count := 0
+			for _, entry := range entries {
+				if !slices.Contains(seenEntryIDs, entry.ID) {
+					count++
+				}
+			}
+			return count, nil
+		}
+
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, s)
+		builder.WithoutEntryIDs(seenEntryIDs)
+		return builder.CountEntries()
+	}
+
+	collapsedSections, err := h.store.DateViewCollapsedSections(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	dateEntriesPath := route.Path(h.router, "dateEntries")
+	printer := locale.NewPrinter(user.Language)
+
+	// The reading time of the entries read recently gives the pace of the catch-up estimates, which need
+	// the reading times of the entries to be estimated
+	readingPace := 0
+	if user.ShowReadingTime {
+		readingPace, err = h.store.ReadingTimeMarkedReadSince(user.ID, now.AddDate(0, 0, -dateViewReadingPaceDays))
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-	case "earlier":
-		earlierEntries, err = fetchForDateRange(nil, &last30dStart)
+	}
+
+	// Get counts for all sections (for navigation) and fetch entries only for the selected section
+	sectionViews := make([]*dateSectionView, 0, len(sections))
+	countUnread := 0
+	for _, s := range sections {
+		sectionView := &dateSectionView{
+			Name:      s.Name,
+			URL:       dateEntriesPath + "?" + dateViewQuery(r, s.Name),
+			Lazy:      !s.Month.IsZero(),
+			Collapsed: slices.Contains(collapsedSections, s.Name),
+			After:     s.After,
+			Before:    s.Before,
+		}
+
+		if sectionView.Lazy {
+			sectionView.Label = dateViewMonthLabel(printer, s.Month)
+		} else if s.Name == model.DateSectionCustom {
+			sectionView.Label = printer.Printf(s.LabelKey, s.After.Add(time.Microsecond).Format(time.DateOnly), s.Before.Format(time.DateOnly))
+		} else {
+			sectionView.Label = s.DisplayLabel(printer.Printf)
+		}
+
+		// Fetch entries only for the selected section, or every publication date section but months for "all"
+		selected := (section == "all" && !sectionView.Lazy && s.ByPublicationDate()) || section == s.Name
+		fullyFetched := false
+		if focusUnvisited && section == s.Name {
+			sectionView.Entries, err = fetchNewestOfUnvisitedFeeds(s)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		} else if selected {
+			// The cursor only pages through the selected section
+			var after, next *model.EntryCursor
+			if section == s.Name {
+				after = cursor
+			}
+
+			// Sections of the "all" view are capped, the whole section is one click away
+			pageSize := user.EntriesPerPage
+			if section == "all" {
+				pageSize = min(pageSize, config.Opts.DateViewMaxEntriesPerSectionInAll())
+			}
+
+			sectionView.Entries, next, err = fetchForDateSection(s, after, pageSize)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+
+			if next != nil {
+				sectionView.NextURL = dateEntriesPath + "?" + dateViewQuery(r, s.Name) + "&cursor=" + next.Token()
+			}
+			fullyFetched = after == nil && next == nil
+		}
+
+		sectionView.DaysAgo = dateViewDaysAgo(sectionView.Entries, now)
+
+		// A section listed in full is counted from its entries, so a feed refresh between two queries
+		// cannot make the count and the list disagree. Without navigation, other sections are not counted.
+		switch {
+		case fullyFetched:
+			sectionView.Count = len(sectionView.Entries)
+		case sectionView.Lazy:
+			sectionView.Count = monthCounts[s.Name]
+		case showNavigation || selected:
+			sectionView.Count, err = countForDateSection(s)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+
+		// How stale the section is, from the listed entries when the whole section is listed
+		if sectionView.Count > 0 && !sectionView.Lazy {
+			if fullyFetched {
+				sectionView.AvgAge = dateViewAverageAge(sectionView.Entries, now)
+			} else {
+				builder := h.newDateViewQueryBuilder(r, user.ID)
+				withDateSectionBounds(r, builder, user, s)
+				sectionView.AvgAge, err = builder.AverageEntryAge(now)
+				if err != nil {
+					html.ServerError(w, r, err)
+					return
+				}
+			}
+		}
+
+		// How long clearing the section takes at the pace the user read over the last days
+		if readingPace > 0 && sectionView.Count > 0 && !sectionView.Lazy {
+			readingTime := dateViewTotalReadingTime(sectionView.Entries)
+			if !fullyFetched {
+				builder := h.newDateViewQueryBuilder(r, user.ID)
+				withDateSectionBounds(r, builder, user, s)
+				readingTime, err = builder.TotalReadingTime()
+				if err != nil {
+					html.ServerError(w, r, err)
+					return
+				}
+			}
+			sectionView.CatchUp = dateViewCatchUpEstimate(readingTime, readingPace)
+		}
+
+		if s.Name == sections[0].Name {
+			newestFullyFetched = fullyFetched
+			if excludeSeen && sectionView.Count > 0 {
+				unseenCount, err := countUnseenForDateSection(s, sectionView.Entries, fullyFetched)
+				if err != nil {
+					html.ServerError(w, r, err)
+					return
+				}
+				sectionView.SeenCount = sectionView.Count - unseenCount
+				sectionView.Count = unseenCount
+			}
+		}
+
+		if sectionView.Count > 0 && !sectionView.Lazy {
+			sectionView.HasPriority, err = hasPriorityForDateSection(s)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+
+		sectionViews = append(sectionViews, sectionView)
+		if s.ByPublicationDate() {
+			countUnread += sectionView.Count
+		}
+	}
+
+	// Blocked domains, like the entry filters, are left out of the sections but not of the sidebar count
+	blockedDomains, err := h.store.DateViewBlockedDomains(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	// Without entry filters the total comes from the same count as the sidebar rather than from the sum
+	// of the sections, which misses entries published exactly on a section boundary
+	if !dateViewHasEntryFilters(r) && len(blockedDomains) == 0 {
+		countUnread, err = h.store.DateSectionCount(user.ID, now, dateViewCountKey(r, user, "all"), func() (int, error) {
+			return h.store.CountGloballyVisibleUnreadEntries(user.ID, dateViewIncludeDisabled(r))
+		})
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-	default: // "all" or any other value
-		// Fetch all sections
-		todayEntries, err = fetchForDateRange(&todayStart, nil)
+
+		// An empty date view counts the day towards the inbox zero streak
+		if countUnread == 0 {
+			if err := h.store.RecordInboxZeroDay(user.ID, timezone.Now(userTimezone)); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+	}
+
+	// Optionally preload the entries of the next non-empty section so the client can switch to it without a round trip
+	nextNonEmptySection := dateViewNextNonEmptySection(sectionViews, section)
+	var prefetchedSection *dateSectionView
+	if dateViewPrefetchNext(r) && nextNonEmptySection != nil && !focusUnvisited {
+		if s, found := model.FindDateSection(sections, nextNonEmptySection.Name); found {
+			prefetchedSection = &dateSectionView{
+				Name:  nextNonEmptySection.Name,
+				Label: nextNonEmptySection.Label,
+				Count: nextNonEmptySection.Count,
+				URL:   nextNonEmptySection.URL,
+			}
+
+			var next *model.EntryCursor
+			prefetchedSection.Entries, next, err = fetchForDateSection(s, nil, user.EntriesPerPage)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+			if next != nil {
+				prefetchedSection.NextURL = dateEntriesPath + "?" + dateViewQuery(r, s.Name) + "&cursor=" + next.Token()
+			}
+			prefetchedSection.DaysAgo = dateViewDaysAgo(prefetchedSection.Entries, now)
+		}
+	}
+
+	// Count entries of the newest section that arrived since the previous page load of this session
+	newSinceLastLoad := 0
+	if lastLoadedAt := request.LastDateViewLoadedAt(r); !lastLoadedAt.IsZero() && sectionViews[0].Count > 0 {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, sections[0])
+		builder.AfterCreatedDate(lastLoadedAt)
+		newSinceLastLoad, err = builder.CountEntries()
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-		last2dEntries, err = fetchForDateRange(&last2dStart, &todayStart)
+	}
+
+	// Count entries read since local midnight for the progress meter, which is part of the navigation
+	readTodayCount := 0
+	inboxZeroStreak := 0
+	todayTotal := 0
+	todayUnread := 0
+	var todayTopRefresh *model.FeedRefreshEntryCount
+	if showNavigation {
+		readTodayCount, err = h.store.CountEntriesMarkedReadSince(user.ID, midnight)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-		last7dEntries, err = fetchForDateRange(&last7dStart, &last2dStart)
+
+		inboxZeroStreak, err = h.store.InboxZeroStreak(user.ID, now)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-		last30dEntries, err = fetchForDateRange(&last30dStart, &last7dStart)
+
+		// The share of the today section already read, counting its read entries the page does not list
+		if today := publicationSections[0]; today.Name == "today" && !dateViewTrash(r) {
+			builder := h.newDateViewReadOrUnreadQueryBuilder(r, user.ID)
+			withDateSectionBounds(r, builder, user, today)
+			todayTotal, err = builder.CountEntries()
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+
+			todayUnread, err = countForDateSection(today)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+
+			// The cached unread count may lag behind a feed refresh
+			todayUnread = min(todayUnread, todayTotal)
+
+			// A feed suddenly backfilling its archive shows up as a single refresh creating many entries today
+			if today.After != nil {
+				todayTopRefresh, err = h.store.TopFeedRefreshSince(user.ID, *today.After)
+				if err != nil {
+					html.ServerError(w, r, err)
+					return
+				}
+			}
+		}
+	}
+
+	// Daily entry counts of the last days for the header sparkline, also part of the navigation
+	var dailyEntryCounts []model.DailyEntryCount
+	if showNavigation {
+		dailyEntryCounts, err = h.store.DailyEntryCounts(user.ID, dateViewSparklineDays)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
-		earlierEntries, err = fetchForDateRange(nil, &last30dStart)
+	}
+
+	// Labels of the user for the triage navigation
+	var entryLabels []string
+	if showNavigation {
+		entryLabels, err = h.store.EntryLabels(user.ID)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
 	}
 
-	// Calculate total count
-	countUnread := countToday + countLast2d + countLast7d + countLast30d + countEarlier
-
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
-	view.Set("todayEntries", todayEntries)
-	view.Set("last2dEntries", last2dEntries)
-	view.Set("last7dEntries", last7dEntries)
-	view.Set("last30dEntries", last30dEntries)
-	view.Set("earlierEntries", earlierEntries)
-	view.Set("countToday", countToday)
-	view.Set("countLast2d", countLast2d)
-	view.Set("countLast7d", countLast7d)
-	view.Set("countLast30d", countLast30d)
-	view.Set("countEarlier", countEarlier)
+	view.Set("sections", sectionViews)
+	view.Set("backlogFeeds", backlogFeeds)
+	view.Set("newSinceLastLoad", newSinceLastLoad)
+	view.Set("readTodayCount", readTodayCount)
+	view.Set("inboxZeroStreak", inboxZeroStreak)
+	view.Set("todayTotal", todayTotal)
+	view.Set("todayUnread", todayUnread)
+	view.Set("todayTopRefresh", todayTopRefresh)
+	view.Set("dailyEntryCounts", dailyEntryCounts)
+	view.Set("sparkline", dateViewSparkline(dailyEntryCounts))
+	view.Set("sparklineWidth", len(dailyEntryCounts)*dateViewSparklineBarWidth)
+	view.Set("sparklineHeight", dateViewSparklineHeight)
 	view.Set("section", section)
+	view.Set("nextNonEmptySection", nextNonEmptySection)
+	view.Set("isSelectedSectionEmpty", dateViewIsSelectedSectionEmpty(sectionViews, section))
+	view.Set("emptySectionMessageKey", dateViewEmptySectionMessageKey(section))
+	view.Set("prefetchedSection", prefetchedSection)
+	view.Set("sectionURL", dateEntriesPath+"?"+dateViewQuery(r, section))
+	view.Set("allSectionsURL", dateEntriesPath+"?"+dateViewQuery(r, "all"))
+	view.Set("focusUnvisited", focusUnvisited)
+	view.Set("focusURL", dateEntriesPath+"?"+dateViewFocusQuery(r, sections[0].Name, !focusUnvisited))
+	view.Set("starred", dateViewStarred(r))
+	view.Set("starredURL", dateEntriesPath+"?"+dateViewStarredQuery(r, sections[0].Name, !dateViewStarred(r)))
+	view.Set("trash", dateViewTrash(r))
+	view.Set("trashURL", dateEntriesPath+"?"+dateViewTrashQuery(r, sections[0].Name, !dateViewTrash(r)))
+	view.Set("restoreURL", route.Path(h.router, "restoreDateEntries")+"?"+dateViewQuery(r, section))
+	view.Set("updatedBasis", dateViewUpdatedBasis(r))
+	view.Set("updatedBasisURL", dateEntriesPath+"?"+dateViewUpdatedBasisQuery(r, sections[0].Name, !dateViewUpdatedBasis(r)))
+	view.Set("collapseSectionURL", route.Path(h.router, "collapseDateSection"))
+	view.Set("markAsReadURL", route.Path(h.router, "markDateEntriesAsRead")+"?"+dateViewMarkAsReadQuery(r, section, time.Now()))
+	view.Set("category", category)
+	view.Set("invalidTimezone", invalidTimezone)
+	view.Set("entryLabels", entryLabels)
+	view.Set("label", dateViewLabel(r))
+	view.Set("layout", dateViewLayout(r))
+	if category != nil {
+		view.Set("markCategoryAsReadURL", route.Path(h.router, "markDateCategoryAsRead")+"?category_id="+strconv.FormatInt(category.ID, 10))
+	}
+	view.Set("saveSectionURL", route.Path(h.router, "saveDateSectionEntries")+"?"+dateViewQuery(r, section))
+	view.Set("randomEntryURL", route.Path(h.router, "randomDateEntry")+"?"+dateViewQuery(r, section))
+	view.Set("fetchSectionContentURL", route.Path(h.router, "fetchDateSectionContent")+"?"+dateViewQuery(r, section))
 	view.Set("menu", "date_entries")
 	view.Set("user", user)
 	view.Set("countUnread", countUnread)
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	// The warning about feeds with errors only counts the feeds of the categories the page is filtered to
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrorsInCategories(user.ID, dateViewCategoryIDs(r)))
 	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
 
-	html.OK(w, r, view.Render("date_entries"))
+	body := view.Render("date_entries")
+	sess.SetLastDateViewLoadedAt()
+	if user.DateViewExcludeSeen && len(sectionViews[0].Entries) > 0 {
+		sess.SetDateViewSeenEntryIDs(dateViewSeenEntryIDs(seenEntryIDs, sectionViews[0].Entries, newestFullyFetched))
+	}
+
+	html.OK(w, r, body)
 }
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/database"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/template"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+)
+
+// dateViewAllSectionsQueryBudget is the number of queries a section=all load may run, whatever the number of
+// entries: a query per section and per navigation feature, but never one per entry or per feed.
+const dateViewAllSectionsQueryBudget = 50
+
+// countingDriverName is the database driver counting the queries of the storage under test.
+const countingDriverName = "postgres-query-counting"
+
+var (
+	registerCountingDriver sync.Once
+	countedQueries         atomic.Int64
+)
+
+// countingDriver opens PostgreSQL connections counting every statement they run.
+type countingDriver struct{}
+
+func (countingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := (&pq.Driver{}).Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &countingConn{conn}, nil
+}
+
+// countingConn counts the queries and statements run on the wrapped connection.
+type countingConn struct {
+	driver.Conn
+}
+
+func (c *countingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	countedQueries.Add(1)
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *countingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	countedQueries.Add(1)
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *countingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+// newQueryCountingTestStorage returns a storage connected to the database given by TEST_MINIFLUX_DATABASE_URL,
+// with all migrations applied, whose queries are counted. The test is skipped when the variable is not set.
+func newQueryCountingTestStorage(t *testing.T) *storage.Storage {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_MINIFLUX_DATABASE_URL")
+	if dsn == "" {
+		t.Skip(`Set TEST_MINIFLUX_DATABASE_URL to run the date view query count test`)
+	}
+
+	registerCountingDriver.Do(func() { sql.Register(countingDriverName, countingDriver{}) })
+
+	db, err := sql.Open(countingDriverName, dsn)
+	if err != nil {
+		t.Fatalf(`Unable to connect to the database: %v`, err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf(`Unable to run database migrations: %v`, err)
+	}
+
+	return storage.NewStorage(db)
+}
+
+// createQueryCountingTestFeed creates a feed of the user with the given number of entries in each date section.
+func createQueryCountingTestFeed(t *testing.T, store *storage.Storage, userID int64, now time.Time, entriesPerSection int) {
+	t.Helper()
+
+	category, err := store.FirstCategory(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries model.Entries
+	for _, age := range []time.Duration{time.Hour, 36 * time.Hour, 5 * 24 * time.Hour, 20 * 24 * time.Hour, 90 * 24 * time.Hour} {
+		for range entriesPerSection {
+			entry := model.NewEntry()
+			entry.Title = "Entry"
+			entry.Hash = crypto.SHA256(fmt.Sprint(rand.Int64()))
+			entry.URL = "https://example.org/" + entry.Hash
+			entry.Date = now.Add(-age)
+			entries = append(entries, entry)
+		}
+	}
+
+	feedURL := fmt.Sprintf("https://example.org/feed-%d.xml", rand.IntN(1_000_000_000))
+	if err := store.CreateFeed(&model.Feed{
+		UserID:   userID,
+		FeedURL:  feedURL,
+		SiteURL:  "https://example.org/",
+		Title:    feedURL,
+		Category: category,
+		Entries:  entries,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// countDateViewAllSectionsQueries returns the number of queries run to render the section=all date view of the user.
+func countDateViewAllSectionsQueries(t *testing.T, h *handler, userID int64, now time.Time) int64 {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/entries/by-date?section=all&now="+now.Format(time.RFC3339), nil)
+	ctx := context.WithValue(r.Context(), request.UserIDContextKey, userID)
+	ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
+	w := httptest.NewRecorder()
+
+	countedQueries.Store(0)
+	h.showDateEntriesPage(w, r.WithContext(ctx))
+	count := countedQueries.Load()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusOK)
+	}
+	return count
+}
+
+func TestShowDateEntriesPageAllSectionsQueryCount(t *testing.T) {
+	store := newQueryCountingTestStorage(t)
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	user, err := store.CreateUser(&model.UserCreationRequest{
+		Username: fmt.Sprintf("ui_test_%d", rand.IntN(1_000_000_000)),
+		Password: "test123456",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.RemoveUser(user.ID) })
+
+	router := mux.NewRouter()
+	Serve(router, store, nil)
+	templateEngine := template.NewEngine(router)
+	templateEngine.ParseTemplates()
+	h := &handler{router: router, store: store, tpl: templateEngine}
+
+	now := time.Now().Truncate(time.Second)
+	createQueryCountingTestFeed(t, store, user.ID, now, 2)
+	fewEntriesCount := countDateViewAllSectionsQueries(t, h, user.ID, now)
+
+	if fewEntriesCount > dateViewAllSectionsQueryBudget {
+		t.Errorf(`The section=all view ran %d queries, more than the budget of %d`, fewEntriesCount, dateViewAllSectionsQueryBudget)
+	}
+
+	// More entries and feeds must not add any query
+	for range 3 {
+		createQueryCountingTestFeed(t, store, user.ID, now, 10)
+	}
+	if manyEntriesCount := countDateViewAllSectionsQueries(t, h, user.ID, now); manyEntriesCount != fewEntriesCount {
+		t.Errorf(`The section=all view ran %d queries with more entries, instead of %d`, manyEntriesCount, fewEntriesCount)
+	}
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+)
+
+// CUSTOM: the bulk fetch of the original content of a date section downloads at most
+// dateViewFetchContentLimit web pages, dateViewFetchContentWorkers at a time.
+const (
+	dateViewFetchContentLimit   = 50
+	dateViewFetchContentWorkers = 4
+)
+
+// dateSectionContentResult reports the outcome of the fetch of the original content of one entry.
+type dateSectionContentResult struct {
+	EntryID int64  `json:"entry_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// dateSectionContentResults reports the outcome of the bulk fetch of the original content of a date section.
+type dateSectionContentResults struct {
+	Succeeded int                         `json:"succeeded"`
+	Failed    int                         `json:"failed"`
+	Truncated bool                        `json:"truncated"`
+	Entries   []*dateSectionContentResult `json:"entries"`
+}
+
+// CUSTOM: fetchDateSectionContent downloads the original content of the entries of the selected date section
+// and stores it, the same way the "Fetch original content" action of a single entry does.
+func (h *handler) fetchDateSectionContent(w http.ResponseWriter, r *http.Request) {
+	section := request.QueryStringParam(r, "section", "all")
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	dateSections, found := dateViewSectionsToMark(r, user, now, section)
+	if !found {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	// One more entry than the limit tells whether the section holds more entries than are fetched
+	entries := make(model.Entries, 0, dateViewFetchContentLimit+1)
+	for _, dateSection := range dateSections {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, dateSection)
+		builder.WithStableSorting(user.EntryOrder, user.EntryDirection)
+		builder.WithLimit(dateViewFetchContentLimit + 1 - len(entries))
+
+		sectionEntries, err := builder.GetEntries()
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+
+		if entries = append(entries, sectionEntries...); len(entries) > dateViewFetchContentLimit {
+			break
+		}
+	}
+
+	truncated := false
+	if len(entries) > dateViewFetchContentLimit {
+		entries = entries[:dateViewFetchContentLimit]
+		truncated = true
+	}
+
+	// The scraper rules, proxy and other fetch settings come from the feed of each entry
+	feeds := make(map[int64]*model.Feed)
+	for _, entry := range entries {
+		if _, found := feeds[entry.FeedID]; found {
+			continue
+		}
+
+		feedBuilder := storage.NewFeedQueryBuilder(h.store, user.ID)
+		feedBuilder.WithFeedID(entry.FeedID)
+		feed, err := feedBuilder.GetFeed()
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+		feeds[entry.FeedID] = feed
+	}
+
+	result := fetchDateSectionContentConcurrently(r, entries, dateViewFetchContentWorkers, func(entry *model.Entry) error {
+		feed := feeds[entry.FeedID]
+		if feed == nil {
+			return fmt.Errorf("feed #%d not found", entry.FeedID)
+		}
+
+		if err := processor.ProcessEntryWebPage(feed, entry, user); err != nil {
+			return err
+		}
+
+		return h.store.UpdateEntryTitleAndContent(entry)
+	})
+	result.Truncated = truncated
+
+	slog.Info("Fetched the original content of a date section",
+		slog.Int64("user_id", user.ID),
+		slog.String("section", section),
+		slog.Int("succeeded", result.Succeeded),
+		slog.Int("failed", result.Failed),
+	)
+
+	json.OK(w, r, result)
+}
+
+// fetchDateSectionContentConcurrently fetches the content of the entries with at most the given number of
+// fetches in flight and reports the outcome of each entry, in the order of the entries.
+// Entries not started yet when the client goes away are reported as failed.
+func fetchDateSectionContentConcurrently(r *http.Request, entries model.Entries, workers int, fetch func(*model.Entry) error) *dateSectionContentResults {
+	results := make([]*dateSectionContentResult, len(entries))
+	semaphore := make(chan struct{}, workers)
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		results[i] = &dateSectionContentResult{EntryID: entry.ID}
+
+		select {
+		case <-r.Context().Done():
+			results[i].Error = r.Context().Err().Error()
+			continue
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(result *dateSectionContentResult, entry *model.Entry) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if err := fetch(entry); err != nil {
+				result.Error = err.Error()
+				return
+			}
+			result.Success = true
+		}(results[i], entry)
+	}
+	wg.Wait()
+
+	summary := &dateSectionContentResults{Entries: results}
+	for _, result := range results {
+		if result.Success {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+	return summary
+}
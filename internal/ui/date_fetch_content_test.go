@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestFetchDateSectionContentBoundsConcurrency(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/fetch-content?section=today", nil)
+	entries := model.Entries{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+
+	var inFlight, maxInFlight atomic.Int32
+	result := fetchDateSectionContentConcurrently(r, entries, 2, func(entry *model.Entry) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if entry.ID == 3 {
+			return errors.New("unable to scrape")
+		}
+		return nil
+	})
+
+	if maxInFlight.Load() > 2 {
+		t.Errorf(`Expected at most 2 fetches in flight, got %d`, maxInFlight.Load())
+	}
+
+	if result.Succeeded != 4 || result.Failed != 1 {
+		t.Errorf(`Expected 4 succeeded and 1 failed, got %d and %d`, result.Succeeded, result.Failed)
+	}
+
+	for i, entryResult := range result.Entries {
+		if entryResult.EntryID != entries[i].ID {
+			t.Errorf(`Expected the results in the order of the entries, got entry #%d at position %d`, entryResult.EntryID, i)
+		}
+	}
+
+	if failed := result.Entries[2]; failed.Success || failed.Error != "unable to scrape" {
+		t.Errorf(`Unexpected result for the failed entry: %+v`, failed)
+	}
+}
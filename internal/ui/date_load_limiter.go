@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import "sync"
+
+// dateViewLoadRetryAfter is the number of seconds a client is asked to wait before loading the date view again.
+const dateViewLoadRetryAfter = 5
+
+// CUSTOM: dateViewLoadLimiter counts the date view pages being loaded by each user. A page issues a query per section,
+// so a runaway client reloading it in a loop could otherwise keep the database busy for everyone.
+// A nil limiter, or a limit of 0, lets every load through.
+type dateViewLoadLimiter struct {
+	mu       sync.Mutex
+	limit    int
+	inFlight map[int64]int
+}
+
+func newDateViewLoadLimiter(limit int) *dateViewLoadLimiter {
+	return &dateViewLoadLimiter{limit: limit, inFlight: make(map[int64]int)}
+}
+
+// acquire reserves a load for the user, it returns false when the user already has as many loads in flight as allowed.
+// Each successful acquire must be followed by a release.
+func (l *dateViewLoadLimiter) acquire(userID int64) bool {
+	if l == nil || l.limit <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[userID] >= l.limit {
+		return false
+	}
+	l.inFlight[userID]++
+	return true
+}
+
+// release ends a load reserved by acquire.
+func (l *dateViewLoadLimiter) release(userID int64) {
+	if l == nil || l.limit <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[userID] <= 1 {
+		delete(l.inFlight, userID)
+	} else {
+		l.inFlight[userID]--
+	}
+}
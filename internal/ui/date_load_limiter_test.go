@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDateViewLoadLimiterBoundsTheLoadsOfEachUser(t *testing.T) {
+	limiter := newDateViewLoadLimiter(2)
+
+	if !limiter.acquire(1) || !limiter.acquire(1) {
+		t.Fatal(`The first two loads should be allowed`)
+	}
+
+	if limiter.acquire(1) {
+		t.Error(`A third concurrent load should be rejected`)
+	}
+
+	// Other users are not affected
+	if !limiter.acquire(2) {
+		t.Error(`The load of another user should be allowed`)
+	}
+
+	limiter.release(1)
+	if !limiter.acquire(1) {
+		t.Error(`A load should be allowed again once another one completes`)
+	}
+
+	limiter.release(1)
+	limiter.release(1)
+	limiter.release(2)
+	if len(limiter.inFlight) != 0 {
+		t.Errorf(`Expected no load in flight, got %v`, limiter.inFlight)
+	}
+}
+
+func TestDateViewLoadLimiterWithoutLimit(t *testing.T) {
+	for _, limiter := range []*dateViewLoadLimiter{nil, newDateViewLoadLimiter(0)} {
+		for range 10 {
+			if !limiter.acquire(1) {
+				t.Fatal(`Every load should be allowed without a limit`)
+			}
+		}
+		limiter.release(1)
+	}
+}
+
+func TestShowDateEntriesPageRejectsTooManyConcurrentLoads(t *testing.T) {
+	limiter := newDateViewLoadLimiter(1)
+	limiter.acquire(0)
+
+	// The limit is checked before any database access.
+	h := &handler{dateViewLoads: limiter}
+	r := httptest.NewRequest(http.MethodGet, "/entries/by-date?section=all", nil)
+	w := httptest.NewRecorder()
+	h.showDateEntriesPage(w, r)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, http.StatusTooManyRequests)
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "5" {
+		t.Errorf(`Unexpected Retry-After header, got %q`, retryAfter)
+	}
+}
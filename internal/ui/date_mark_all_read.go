@@ -4,70 +4,336 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/timezone"
 )
 
-// CUSTOM: markDateEntriesAsRead marks entries as read within the selected date section
+// CUSTOM: markDateEntriesAsRead marks entries as read, or removed, within the selected date section. The entries
+// matching the optional "except_q" search query keep their status, and so do the starred entries with
+// "keep_starred_unread=1", whichever way the section is marked.
 func (h *handler) markDateEntriesAsRead(w http.ResponseWriter, r *http.Request) {
+	// Get section filter from query parameter
+	section := request.QueryStringParam(r, "section", "all")
+
+	// Optionally leave starred entries unread so they survive the bulk action
+	keepStarred := request.QueryBoolParam(r, "keep_starred_unread", false)
+
+	// Optionally leave the entries of errored feeds unread as a reminder to look into the feeds
+	skipErroredFeeds := request.QueryBoolParam(r, "skip_errored_feeds", false)
+
+	// Entries can be archived as removed instead of read to keep them out of the date view for good
+	status := request.QueryStringParam(r, "status", model.EntryStatusRead)
+	if status != model.EntryStatusRead && status != model.EntryStatusRemoved {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidStatus, fmt.Errorf("invalid status %q", status))
+		return
+	}
+
+	// Removed entries never change status again, the trash restores them instead
+	if dateViewTrash(r) {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidStatus, errors.New("entries of the trash can only be restored"))
+		return
+	}
+
+	// Entries matching the optional search query, such as "important", survive the bulk action
+	exceptQuery, err := dateViewExceptQuery(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidExceptQuery, err)
+		return
+	}
+
 	userID := request.UserID(r)
 
 	user, err := h.store.UserByID(userID)
 	if err != nil {
-		json.ServerError(w, r, err)
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
 		return
 	}
 
-	// Get section filter from query parameter
-	section := request.QueryStringParam(r, "section", "all")
+	// Get current time in user's timezone, or the client-supplied reference time
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	// Entries fetched after the page was rendered, or after the past date the page looked back at, are left alone
+	asOf, err := dateViewAsOf(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidAsOf, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	if !h.dateViewCategoriesExist(userID, dateViewCategoryIDs(r)) || !h.dateViewCategoriesExist(userID, dateViewExcludedCategoryIDs(r)) {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidCategory, errors.New("unknown category"))
+		return
+	}
+
+	// The optional reason, such as "bankruptcy", categorizes the bulk action in the activity log
+	reason, err := dateViewMarkReason(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReason, err)
+		return
+	}
+
+	// Determine the date ranges based on section, using the same boundaries as showDateEntriesPage
+	dateSections, found := dateViewSectionsToMark(r, user, now, section)
+	if !found {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	// Entries of blocked domains are left alone, which MarkEntriesInDateRange does not support either
+	blockedDomains, err := h.store.DateViewBlockedDomains(userID)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	// The entries changed below, for the linked account
+	var markedIDs []int64
+	var rangeSections []model.DateSection
+	for _, dateSection := range dateSections {
+		// Filters not supported by MarkEntriesInDateRange need the matching entries to be selected first
+		if !dateSection.ByPublicationDate() || dateViewHasEntryFilters(r) || len(blockedDomains) > 0 {
+			builder := h.newDateViewQueryBuilder(r, userID)
+			if keepStarred {
+				builder.WithStarred(false)
+			}
+			if asOf != nil {
+				builder.NotCreatedAfter(*asOf)
+			}
+			if skipErroredFeeds {
+				builder.WithoutErroredFeeds()
+			}
+			builder.WithoutSearchQuery(exceptQuery)
+			withDateSectionBounds(r, builder, user, dateSection)
+
+			entryIDs, err := builder.GetEntryIDs()
+			if err != nil {
+				json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+				return
+			}
 
-	// Get current time in user's timezone
-	now := timezone.Now(user.Timezone)
-
-	// Calculate date boundaries matching the showDateEntriesPage logic
-	todayStart := now.Add(-24 * time.Hour)
-	last2dStart := now.Add(-48 * time.Hour)
-	last7dStart := now.Add(-7 * 24 * time.Hour)
-	last30dStart := now.Add(-30 * 24 * time.Hour)
-
-	var afterDate, beforeDate *time.Time
-
-	// Determine date range based on section
-	switch section {
-	case "today":
-		afterDate = &todayStart
-		beforeDate = nil // Up to now
-	case "last2d":
-		afterDate = &last2dStart
-		beforeDate = &todayStart
-	case "last7d":
-		afterDate = &last7dStart
-		beforeDate = &last2dStart
-	case "last30d":
-		afterDate = &last30dStart
-		beforeDate = &last7dStart
-	case "earlier":
-		afterDate = nil // Beginning of time
-		beforeDate = &last30dStart
-	case "all":
-		// Mark all globally visible entries as read
-		if err := h.store.MarkGloballyVisibleFeedsAsRead(userID); err != nil {
-			json.ServerError(w, r, err)
+			if len(entryIDs) > 0 {
+				if err := h.store.SetEntriesStatus(userID, entryIDs, status); err != nil {
+					json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+					return
+				}
+			}
+			markedIDs = append(markedIDs, entryIDs...)
+			continue
+		}
+
+		rangeSections = append(rangeSections, dateSection)
+	}
+
+	// Mark entries in the date ranges, leaving entries of disabled feeds alone unless the date view shows them.
+	// Several sections are marked at once, whether they follow each other or not.
+	if len(rangeSections) > 0 {
+		update := dateViewRangeStatusUpdate(r, user, rangeSections[0], status, keepStarred)
+		update.CreatedAsOf = asOf
+		update.SkipErroredFeeds = skipErroredFeeds
+		update.ExceptSearchQuery = exceptQuery
+
+		entryIDs, err := h.store.MarkEntriesInDateRanges(userID, update, dateViewSectionRanges(rangeSections))
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
 			return
 		}
-		json.OK(w, r, "OK")
-		return
+		markedIDs = append(markedIDs, entryIDs...)
 	}
+	marked := len(markedIDs)
 
-	// Mark entries in the specified date range
-	if err := h.store.MarkEntriesAsReadInDateRange(userID, afterDate, beforeDate); err != nil {
-		json.ServerError(w, r, err)
+	// The bulk action is recorded so a sudden drop of the unread count can be explained later
+	if err := h.store.CreateBulkStatusUpdate(&model.BulkStatusUpdate{
+		UserID:     userID,
+		Section:    section,
+		Status:     status,
+		EntryCount: marked,
+		Reason:     reason,
+	}); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
 		return
 	}
 
+	if status == model.EntryStatusRead && marked > 0 {
+		h.propagateReadStatusToLinkedAccount(userID, section, markedIDs)
+	}
+
+	// Clearing the last unread entries counts the day towards the inbox zero streak
+	if marked > 0 {
+		remaining, err := h.store.CountGloballyVisibleUnreadEntries(userID, false)
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+
+		if remaining == 0 {
+			if err := h.store.RecordInboxZeroDay(userID, timezone.Now(userTimezone)); err != nil {
+				json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+				return
+			}
+		}
+	}
+
 	json.OK(w, r, "OK")
 }
+
+// propagateReadStatusToLinkedAccount marks as read the given entries, just marked as read by the user, in the
+// account linked to the user by the configuration, if any. A failure is logged but never fails the bulk action:
+// the entries of the user are already marked.
+func (h *handler) propagateReadStatusToLinkedAccount(userID int64, section string, entryIDs []int64) {
+	linkedUserID, found := config.Opts.DateViewLinkedAccount(userID)
+	if !found {
+		return
+	}
+
+	count, err := h.store.MarkLinkedEntriesAsRead(userID, linkedUserID, entryIDs)
+	if err != nil {
+		slog.Warn("Unable to mark the entries of a date section as read in the linked account",
+			slog.Int64("user_id", userID),
+			slog.Int64("linked_user_id", linkedUserID),
+			slog.String("section", section),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	slog.Info("Marked the entries of a date section as read in the linked account",
+		slog.Int64("user_id", userID),
+		slog.Int64("linked_user_id", linkedUserID),
+		slog.String("section", section),
+		slog.Int("nb_entries", count),
+	)
+}
+
+// dateViewSectionsToMark returns the date sections whose entries are marked for the given section name, or
+// comma separated names such as "today,last7d". The "all" section marks each publication date section of the page
+// rather than every unread entry, so it only changes entries the page shows: entries published exactly on a
+// section boundary belong to no section.
+func dateViewSectionsToMark(r *http.Request, user *model.User, now time.Time, name string) ([]model.DateSection, bool) {
+	if name == "all" {
+		return model.PublicationDateSections(user.DateSections(dateViewScheme(r), now)), true
+	}
+
+	var dateSections []model.DateSection
+	for sectionName := range strings.SplitSeq(name, ",") {
+		dateSection, found := dateViewSection(r, user, now, sectionName)
+		if !found {
+			return nil, false
+		}
+
+		if !slices.ContainsFunc(dateSections, func(s model.DateSection) bool { return s.Name == dateSection.Name }) {
+			dateSections = append(dateSections, dateSection)
+		}
+	}
+	return dateSections, true
+}
+
+// dateViewRangeStatusUpdate returns the status update of the entries within the publication date range of the section.
+func dateViewRangeStatusUpdate(r *http.Request, user *model.User, section model.DateSection, status string, keepStarred bool) *model.DateRangeStatusUpdate {
+	return &model.DateRangeStatusUpdate{
+		Status:          status,
+		After:           section.After,
+		Before:          section.Before,
+		KeepStarred:     keepStarred,
+		IncludeDisabled: dateViewIncludeDisabled(r),
+		UseLatestDate:   user.DateViewUseLatestDate,
+	}
+}
+
+// dateViewSectionRanges returns the publication date range of each section, in the same order.
+func dateViewSectionRanges(sections []model.DateSection) []model.DateRange {
+	ranges := make([]model.DateRange, 0, len(sections))
+	for _, section := range sections {
+		ranges = append(ranges, model.DateRange{After: section.After, Before: section.Before})
+	}
+	return ranges
+}
+
+// dateViewAsOf returns the RFC3339 "as_of" query parameter, the time the page was rendered, or nil when it is missing.
+func dateViewAsOf(r *http.Request) (*time.Time, error) {
+	value := request.QueryStringParam(r, "as_of", "")
+	if value == "" {
+		return nil, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid "as_of" value %q: %v`, value, err)
+	}
+	return &asOf, nil
+}
+
+// dateViewExceptQueryMaxLength bounds the length of the search query of the entries a bulk status update leaves alone.
+const dateViewExceptQueryMaxLength = 200
+
+// dateViewExceptQuery returns the "except_q" query parameter of a bulk status update, or an empty string. The
+// entries it matches, with the full-text search of the entries list, keep their status. A query without any letter
+// or digit is rejected: it matches no entry, so it would leave none of them alone.
+func dateViewExceptQuery(r *http.Request) (string, error) {
+	query := strings.TrimSpace(request.QueryStringParam(r, "except_q", ""))
+	if query == "" {
+		return "", nil
+	}
+
+	if utf8.RuneCountInString(query) > dateViewExceptQueryMaxLength {
+		return "", fmt.Errorf(`"except_q" longer than %d characters`, dateViewExceptQueryMaxLength)
+	}
+
+	if !strings.ContainsFunc(query, func(c rune) bool { return unicode.IsLetter(c) || unicode.IsDigit(c) }) {
+		return "", fmt.Errorf(`invalid "except_q" value %q: no word to search for`, query)
+	}
+	return query, nil
+}
+
+// dateViewMarkReasonMaxLength bounds the length of the reason given to a bulk status update.
+const dateViewMarkReasonMaxLength = 50
+
+// dateViewMarkReason returns the lowercase "reason" query parameter of a bulk status update, or an empty string.
+func dateViewMarkReason(r *http.Request) (string, error) {
+	reason := strings.ToLower(strings.TrimSpace(request.QueryStringParam(r, "reason", "")))
+	if utf8.RuneCountInString(reason) > dateViewMarkReasonMaxLength {
+		return "", fmt.Errorf("reason longer than %d characters", dateViewMarkReasonMaxLength)
+	}
+	return reason, nil
+}
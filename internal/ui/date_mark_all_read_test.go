@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestMarkDateEntriesAsReadRejectsInvalidStatusWithErrorCode(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&status=unread", nil)
+	w := httptest.NewRecorder()
+
+	// The status is validated before any database access.
+	h := &handler{}
+	h.markDateEntriesAsRead(w, r)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var body struct {
+		ErrorCode string `json:"error_code"`
+	}
+	if err := json_parser.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.ErrorCode != dateViewErrorInvalidStatus {
+		t.Errorf(`Unexpected error code, got %q instead of %q`, body.ErrorCode, dateViewErrorInvalidStatus)
+	}
+}
+
+func TestMarkDateEntriesAsReadRejectsTheTrash(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&trash=1", nil)
+	w := httptest.NewRecorder()
+
+	// Removed entries never change status, this is checked before any database access.
+	h := &handler{}
+	h.markDateEntriesAsRead(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), dateViewErrorInvalidStatus) {
+		t.Errorf(`Unexpected body %q, the error code %q is missing`, w.Body.String(), dateViewErrorInvalidStatus)
+	}
+}
+
+func TestDateViewSectionsToMarkCoversThePageSections(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, scheme := range []string{model.DateSectionSchemeDefault, model.DateSectionSchemeSimple} {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=all&buckets="+scheme, nil)
+		sections, found := dateViewSectionsToMark(r, &model.User{}, now, "all")
+		if !found {
+			t.Fatalf(`The "all" section should be found (%s)`, scheme)
+		}
+
+		// "all" marks section by section with the bounds of the page, never an unbounded range
+		expected := model.PublicationDateSections((&model.User{}).DateSections(scheme, now))
+		if len(sections) != len(expected) {
+			t.Fatalf(`Expected %d sections, got %d (%s)`, len(expected), len(sections), scheme)
+		}
+
+		for i, section := range sections {
+			if section.Name != expected[i].Name || (section.After == nil && section.Before == nil) {
+				t.Errorf(`Unexpected section %q at position %d (%s)`, section.Name, i, scheme)
+			}
+		}
+	}
+}
+
+func TestDateViewSectionsToMarkSelectsOneSection(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=last2d", nil)
+
+	sections, found := dateViewSectionsToMark(r, &model.User{}, now, "last2d")
+	if !found || len(sections) != 1 || sections[0].Name != "last2d" {
+		t.Fatalf(`Expected only the last2d section, got %v`, sections)
+	}
+
+	if _, found := dateViewSectionsToMark(r, &model.User{}, now, "tomorrow"); found {
+		t.Error(`An unknown section should not be found`)
+	}
+}
+
+func TestDateViewSectionsToMarkSelectsSeveralSections(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read", nil)
+
+	sections, found := dateViewSectionsToMark(r, &model.User{}, now, "today,last7d,today")
+	if !found {
+		t.Fatal(`Expected the sections to be found`)
+	}
+
+	if len(sections) != 2 || sections[0].Name != "today" || sections[1].Name != "last7d" {
+		t.Errorf(`Expected the today and last7d sections once each, got %+v`, sections)
+	}
+
+	if _, found := dateViewSectionsToMark(r, &model.User{}, now, "today,unknown"); found {
+		t.Error(`An unknown section in the list should not be found`)
+	}
+
+	ranges := dateViewSectionRanges(sections)
+	if len(ranges) != 2 || ranges[0].After != sections[0].After || ranges[1].Before != sections[1].Before {
+		t.Errorf(`Expected the ranges to follow the section bounds, got %+v`, ranges)
+	}
+}
+
+func TestDateViewSectionsToMarkOpenedRecently(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=opened_recently", nil)
+
+	sections, found := dateViewSectionsToMark(r, &model.User{}, now, model.DateSectionOpenedRecently)
+	if !found || len(sections) != 1 {
+		t.Fatalf(`Expected only the opened recently section, got %v`, sections)
+	}
+
+	section := sections[0]
+	if section.OpenedAfter == nil || !section.OpenedAfter.Equal(now.Add(-24*time.Hour)) {
+		t.Errorf(`Expected entries opened during the last day, got %v`, section.OpenedAfter)
+	}
+
+	if section.After != nil || section.Before != nil {
+		t.Error(`The opened recently section should not bound the publication date`)
+	}
+}
+
+func TestDateViewSectionsToMarkInProgress(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=in_progress", nil)
+
+	sections, found := dateViewSectionsToMark(r, &model.User{}, now, model.DateSectionInProgress)
+	if !found || len(sections) != 1 {
+		t.Fatalf(`Expected only the in progress section, got %v`, sections)
+	}
+
+	section := sections[0]
+	if !section.InProgress || section.ByPublicationDate() {
+		t.Error(`The in progress section should select entries by their reading progress`)
+	}
+
+	if section.After != nil || section.Before != nil {
+		t.Error(`The in progress section should not bound the publication date`)
+	}
+}
+
+func TestDateViewSectionsToMarkNewFeeds(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=new_feeds", nil)
+
+	sections, found := dateViewSectionsToMark(r, &model.User{}, now, model.DateSectionNewFeeds)
+	if !found || len(sections) != 1 {
+		t.Fatalf(`Expected only the new feeds section, got %v`, sections)
+	}
+
+	section := sections[0]
+	if section.FeedCreatedAfter == nil || !section.FeedCreatedAfter.Equal(now.AddDate(0, 0, -model.DateSectionNewFeedsDays)) {
+		t.Errorf(`Expected entries of the feeds subscribed to during the last week, got %v`, section.FeedCreatedAfter)
+	}
+
+	if section.After != nil || section.Before != nil || section.ByPublicationDate() {
+		t.Error(`The new feeds section should not bound the publication date`)
+	}
+}
+
+func TestDateViewSectionsToMarkFuture(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=future", nil)
+
+	sections, found := dateViewSectionsToMark(r, &model.User{}, now, model.DateSectionFuture)
+	if !found || len(sections) != 1 {
+		t.Fatalf(`Expected only the future section, got %v`, sections)
+	}
+
+	section := sections[0]
+	if section.After == nil || !section.After.Equal(now) || section.Before != nil {
+		t.Errorf(`Expected entries published after now, got %v-%v`, section.After, section.Before)
+	}
+
+	if section.ByPublicationDate() {
+		t.Error(`The future section should not be part of the "all" selection, the newest section holds its entries`)
+	}
+}
+
+func TestDateViewSectionsToMarkMatchThePageFetchRanges(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	months := []time.Time{
+		time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, scheme := range []string{model.DateSectionSchemeDefault, model.DateSectionSchemeSimple} {
+		for _, user := range []*model.User{{}, {DateViewUseLatestDate: true}} {
+			r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?buckets="+scheme, nil)
+
+			// Every section the page can list, with or without the oldest section split into months
+			sections := user.DateSections(scheme, now)
+			pageSections := append(slices.Clone(sections), dateViewWithMonthSections(sections, months)...)
+
+			for _, pageSection := range pageSections {
+				marked, found := dateViewSectionsToMark(r, user, now, pageSection.Name)
+				if !found || len(marked) != 1 {
+					t.Fatalf(`Expected only the %q section to be marked, got %v (%s)`, pageSection.Name, marked, scheme)
+				}
+
+				if !reflect.DeepEqual(marked[0], pageSection) {
+					t.Errorf(`Expected the %q section to be marked with the page bounds, got %+v instead of %+v (%s)`, pageSection.Name, marked[0], pageSection, scheme)
+				}
+
+				if !pageSection.ByPublicationDate() {
+					continue
+				}
+
+				update := dateViewRangeStatusUpdate(r, user, marked[0], model.EntryStatusRead, false)
+				if !reflect.DeepEqual(update.After, pageSection.After) || !reflect.DeepEqual(update.Before, pageSection.Before) || update.UseLatestDate != user.DateViewUseLatestDate {
+					t.Errorf(`Expected the %q range to match the page fetch range, got %+v (%s)`, pageSection.Name, update, scheme)
+				}
+			}
+		}
+	}
+}
+
+func TestDateViewAsOf(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today", nil)
+	if asOf, err := dateViewAsOf(r); err != nil || asOf != nil {
+		t.Errorf(`Expected no bound without "as_of", got %v (%v)`, asOf, err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&as_of=2025-03-10T08:30:00.123456Z", nil)
+	expected := time.Date(2025, time.March, 10, 8, 30, 0, 123456000, time.UTC)
+	if asOf, err := dateViewAsOf(r); err != nil || asOf == nil || !asOf.Equal(expected) {
+		t.Errorf(`Expected the render time %v, got %v (%v)`, expected, asOf, err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&as_of=yesterday", nil)
+	if _, err := dateViewAsOf(r); err == nil {
+		t.Error(`An invalid "as_of" value should be rejected`)
+	}
+}
+
+func TestDateViewExceptQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&except_q=+important+", nil)
+	if query, err := dateViewExceptQuery(r); err != nil || query != "important" {
+		t.Errorf(`Expected the trimmed query, got %q (%v)`, query, err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today", nil)
+	if query, err := dateViewExceptQuery(r); err != nil || query != "" {
+		t.Errorf(`Expected no query, got %q (%v)`, query, err)
+	}
+
+	for _, value := range []string{"%21%3F", strings.Repeat("a", dateViewExceptQueryMaxLength+1)} {
+		r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&except_q="+value, nil)
+		if _, err := dateViewExceptQuery(r); err == nil {
+			t.Errorf(`The query %q should be rejected`, value)
+		}
+	}
+}
+
+func TestMarkDateEntriesAsReadRejectsInvalidExceptQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=today&except_q=%2A%2A", nil)
+	w := httptest.NewRecorder()
+
+	// The query is validated before any database access.
+	h := &handler{}
+	h.markDateEntriesAsRead(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), dateViewErrorInvalidExceptQuery) {
+		t.Errorf(`Unexpected body %q, the error code %q is missing`, w.Body.String(), dateViewErrorInvalidExceptQuery)
+	}
+}
+
+func TestDateViewMarkReason(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=earlier&reason=+Bankruptcy+", nil)
+	if reason, err := dateViewMarkReason(r); err != nil || reason != "bankruptcy" {
+		t.Errorf(`Expected the lowercase reason, got %q (%v)`, reason, err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=earlier", nil)
+	if reason, err := dateViewMarkReason(r); err != nil || reason != "" {
+		t.Errorf(`Expected no reason, got %q (%v)`, reason, err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-all-as-read?section=earlier&reason="+strings.Repeat("a", dateViewMarkReasonMaxLength+1), nil)
+	if _, err := dateViewMarkReason(r); err == nil {
+		t.Error(`A reason that long should be rejected`)
+	}
+}
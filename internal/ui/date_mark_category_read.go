@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// CUSTOM: markDateCategoryAsRead marks every unread entry of the category filtered by the date view as read,
+// across all date sections at once.
+func (h *handler) markDateCategoryAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	categoryID := request.QueryInt64Param(r, "category_id", 0)
+
+	if categoryID <= 0 || !h.store.CategoryIDExists(userID, categoryID) {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidCategory, fmt.Errorf("unknown category %d", categoryID))
+		return
+	}
+
+	if err := h.store.MarkCategoryEntriesAsRead(userID, categoryID); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, "OK")
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: markDateEntriesBatchAsRead marks the given entries as read, or removed, when they belong to the
+// selected date section. Clients fade entries out one by one and confirm them in a single batch.
+func (h *handler) markDateEntriesBatchAsRead(w http.ResponseWriter, r *http.Request) {
+	var statusUpdateRequest model.EntriesStatusUpdateRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&statusUpdateRequest); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidEntries, err)
+		return
+	}
+
+	if len(statusUpdateRequest.EntryIDs) == 0 {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidEntries, errors.New("the list of entries cannot be empty"))
+		return
+	}
+
+	if statusUpdateRequest.Status == "" {
+		statusUpdateRequest.Status = model.EntryStatusRead
+	}
+
+	if statusUpdateRequest.Status != model.EntryStatusRead && statusUpdateRequest.Status != model.EntryStatusRemoved {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidStatus, fmt.Errorf("invalid status %q", statusUpdateRequest.Status))
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	// Only keep the entries shown in the section, so a stale client cannot mark entries outside of it
+	builder := h.newDateViewQueryBuilder(r, user.ID)
+	builder.WithEntryIDs(statusUpdateRequest.EntryIDs)
+
+	section := request.QueryStringParam(r, "section", "all")
+	if section != "all" {
+		dateSection, found := dateViewSection(r, user, now, section)
+		if !found {
+			json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+			return
+		}
+
+		withDateSectionBounds(r, builder, user, dateSection)
+	}
+
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	if len(entryIDs) == 0 {
+		json.OK(w, r, map[string][]int64{"entry_ids": {}})
+		return
+	}
+
+	if err := h.store.SetEntriesStatus(user.ID, entryIDs, statusUpdateRequest.Status); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, map[string][]int64{"entry_ids": entryIDs})
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// CUSTOM: dateViewDefaultKeepPerFeed is the number of unread entries left to each feed when "keep" is not given.
+const dateViewDefaultKeepPerFeed = 5
+
+// CUSTOM: markDateEntriesKeepingNewestAsRead reduces the backlog by marking every unread entry as read,
+// except for the newest entries of each feed.
+func (h *handler) markDateEntriesKeepingNewestAsRead(w http.ResponseWriter, r *http.Request) {
+	keepPerFeed, err := dateViewKeepPerFeed(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidKeep, err)
+		return
+	}
+
+	marked, err := h.store.MarkReadKeepNewestPerFeed(request.UserID(r), keepPerFeed)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, map[string]int{"marked": marked})
+}
+
+// dateViewKeepPerFeed returns the number of unread entries left to each feed, requested with the "keep" query parameter.
+func dateViewKeepPerFeed(r *http.Request) (int, error) {
+	value := request.QueryStringParam(r, "keep", "")
+	if value == "" {
+		return dateViewDefaultKeepPerFeed, nil
+	}
+
+	keepPerFeed, err := strconv.Atoi(value)
+	if err != nil || keepPerFeed < 0 {
+		return 0, fmt.Errorf(`invalid "keep" parameter %q, expected a number of entries`, value)
+	}
+	return keepPerFeed, nil
+}
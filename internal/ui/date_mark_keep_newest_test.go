@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDateViewKeepPerFeed(t *testing.T) {
+	scenarios := map[string]int{
+		"":        dateViewDefaultKeepPerFeed,
+		"keep=0":  0,
+		"keep=12": 12,
+	}
+
+	for query, expected := range scenarios {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-keeping-newest-as-read?"+query, nil)
+		if result, err := dateViewKeepPerFeed(r); err != nil || result != expected {
+			t.Errorf(`Unexpected number of entries kept for %q, got %d (%v) instead of %d`, query, result, err, expected)
+		}
+	}
+
+	for _, query := range []string{"keep=-1", "keep=few"} {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-keeping-newest-as-read?"+query, nil)
+		if _, err := dateViewKeepPerFeed(r); err == nil {
+			t.Errorf(`The number of entries kept %q should be rejected`, query)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: dateViewDefaultReadRatio is the read ratio under which a feed is rarely read when "threshold" is not given.
+const dateViewDefaultReadRatio = 0.1
+
+// CUSTOM: dateViewDefaultReadRatioMinEntries is the number of entries no longer unread a feed needs for its read ratio
+// to count, when "min_entries" is not given.
+const dateViewDefaultReadRatioMinEntries = 20
+
+// CUSTOM: markDateSectionRarelyReadAsRead marks as read the unread entries of the selected date section coming from
+// the feeds whose read ratio is below the "threshold" query parameter, leaving the feeds the user engages with alone.
+func (h *handler) markDateSectionRarelyReadAsRead(w http.ResponseWriter, r *http.Request) {
+	threshold, minEntries, err := dateViewReadRatioThreshold(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidThreshold, err)
+		return
+	}
+
+	reason, err := dateViewMarkReason(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReason, err)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	dateSection, errorCode, err := dateViewNoisyFeedsSection(r, user)
+	if err != nil {
+		json.BadRequestWithCode(w, r, errorCode, err)
+		return
+	}
+
+	ratios, err := h.store.FeedReadRatios(user.ID, minEntries)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	rarelyRead := dateViewFeedsReadBelow(ratios, threshold)
+	if len(rarelyRead) == 0 {
+		json.OK(w, r, map[string]any{"marked": 0, "feeds": rarelyRead})
+		return
+	}
+
+	feedIDs := make([]int64, 0, len(rarelyRead))
+	for _, ratio := range rarelyRead {
+		feedIDs = append(feedIDs, ratio.FeedID)
+	}
+
+	builder := h.newDateViewQueryBuilder(r, user.ID)
+	builder.WithFeedIDs(feedIDs)
+	withDateSectionBounds(r, builder, user, dateSection)
+
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	if len(entryIDs) > 0 {
+		if err := h.store.SetEntriesStatus(user.ID, entryIDs, model.EntryStatusRead); err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+	}
+
+	if err := h.store.CreateBulkStatusUpdate(&model.BulkStatusUpdate{
+		UserID:     user.ID,
+		Section:    dateSection.Name,
+		Status:     model.EntryStatusRead,
+		EntryCount: len(entryIDs),
+		Reason:     reason,
+	}); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, map[string]any{"marked": len(entryIDs), "feeds": rarelyRead})
+}
+
+// dateViewReadRatioThreshold returns the read ratio under which a feed is rarely read, from the "threshold" query
+// parameter between 0 and 1, and the history a feed needs for its ratio to count, from the "min_entries" query parameter.
+func dateViewReadRatioThreshold(r *http.Request) (float64, int, error) {
+	threshold := dateViewDefaultReadRatio
+	if value := request.QueryStringParam(r, "threshold", ""); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			return 0, 0, fmt.Errorf(`invalid "threshold" parameter %q, expected a ratio between 0 and 1`, value)
+		}
+		threshold = parsed
+	}
+
+	minEntries := dateViewDefaultReadRatioMinEntries
+	if value := request.QueryStringParam(r, "min_entries", ""); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf(`invalid "min_entries" parameter %q, expected a number of entries`, value)
+		}
+		minEntries = parsed
+	}
+
+	return threshold, minEntries, nil
+}
+
+// dateViewFeedsReadBelow returns the feeds whose read ratio is below the threshold.
+func dateViewFeedsReadBelow(ratios []*model.FeedReadRatio, threshold float64) []*model.FeedReadRatio {
+	rarelyRead := make([]*model.FeedReadRatio, 0)
+	for _, ratio := range ratios {
+		if ratio.ReadRatio < threshold {
+			rarelyRead = append(rarelyRead, ratio)
+		}
+	}
+	return rarelyRead
+}
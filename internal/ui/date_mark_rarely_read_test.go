@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestDateViewReadRatioThreshold(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-rarely-read-as-read?section=today", nil)
+	if threshold, minEntries, err := dateViewReadRatioThreshold(r); err != nil || threshold != dateViewDefaultReadRatio || minEntries != dateViewDefaultReadRatioMinEntries {
+		t.Errorf(`Expected the default threshold, got %v and %d (%v)`, threshold, minEntries, err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-rarely-read-as-read?section=today&threshold=0.25&min_entries=5", nil)
+	if threshold, minEntries, err := dateViewReadRatioThreshold(r); err != nil || threshold != 0.25 || minEntries != 5 {
+		t.Errorf(`Expected the requested threshold, got %v and %d (%v)`, threshold, minEntries, err)
+	}
+
+	for _, query := range []string{"threshold=0", "threshold=1.5", "threshold=low", "min_entries=0", "min_entries=many"} {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-rarely-read-as-read?"+query, nil)
+		if _, _, err := dateViewReadRatioThreshold(r); err == nil {
+			t.Errorf(`The threshold %q should be rejected`, query)
+		}
+	}
+}
+
+func TestDateViewFeedsReadBelow(t *testing.T) {
+	ratios := []*model.FeedReadRatio{
+		{FeedID: 1, OpenedCount: 0, SettledCount: 40, ReadRatio: 0},
+		{FeedID: 2, OpenedCount: 2, SettledCount: 40, ReadRatio: 0.05},
+		{FeedID: 3, OpenedCount: 4, SettledCount: 40, ReadRatio: 0.1},
+		{FeedID: 4, OpenedCount: 30, SettledCount: 40, ReadRatio: 0.75},
+	}
+
+	rarelyRead := dateViewFeedsReadBelow(ratios, 0.1)
+	if len(rarelyRead) != 2 || rarelyRead[0].FeedID != 1 || rarelyRead[1].FeedID != 2 {
+		t.Errorf(`Expected the feeds read strictly below the threshold, got %+v`, rarelyRead)
+	}
+
+	if rarelyRead := dateViewFeedsReadBelow(nil, 0.1); rarelyRead == nil || len(rarelyRead) != 0 {
+		t.Errorf(`Expected an empty list, got %v`, rarelyRead)
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: markDateEntryAsReadAndShowNext marks the entry being read as read and returns the next unread entry
+// of its date section, in the order the date view lists them, so the keyboard flow never leaves the section.
+// The entry ID is null once the section is exhausted, the URL then leads back to the date view.
+func (h *handler) markDateEntryAsReadAndShowNext(w http.ResponseWriter, r *http.Request) {
+	entryID := request.QueryInt64Param(r, "entry_id", 0)
+	if entryID <= 0 {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidEntries, errors.New(`the "entry_id" parameter is required`))
+		return
+	}
+
+	section := request.QueryStringParam(r, "section", "")
+	if section == "" || section == "all" {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	order, err := dateViewOrder(r, user.EntryOrder)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidOrder, err)
+		return
+	}
+
+	// Only the sections by publication date are paginated by keyset, the others have no stable next entry
+	dateSection, found := dateViewSection(r, user, now, section)
+	if !found || !dateSection.ByPublicationDate() {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	// The entry may already be read, after a repeated key press, its position in the section still applies
+	entry, err := h.store.NewEntryQueryBuilder(user.ID).WithEntryID(entryID).GetEntry()
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.SetEntriesStatus(user.ID, []int64{entry.ID}, model.EntryStatusRead); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	// The next entry is the first one sorted after the current entry, with the sorting of showDateEntriesPage
+	manualFirst := dateViewBoostManual(r)
+	tiebreak := dateViewTiebreak(r)
+	direction := dateViewSectionDirection(r, dateSection, user.EntryDirection)
+	cursor := model.NewEntryCursor(entry, order, true, manualFirst).WithTiebreak(entry, tiebreak)
+
+	builder := h.newDateViewQueryBuilder(r, user.ID)
+	withDateSectionBounds(r, builder, user, dateSection)
+	builder.WithPinnedFeedsFirst()
+	if manualFirst {
+		builder.WithManualFeedsFirst()
+	}
+	builder.WithStableTiebreakSorting(order, tiebreak, direction)
+	builder.AfterCursor(cursor, order, direction)
+	builder.WithLimit(1)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	if len(entries) == 0 {
+		json.OK(w, r, map[string]any{
+			"entry_id": nil,
+			"url":      route.Path(h.router, "dateEntries") + "?" + dateViewQuery(r, section),
+		})
+		return
+	}
+
+	json.OK(w, r, map[string]any{
+		"entry_id": entries[0].ID,
+		"url":      route.Path(h.router, "unreadEntry", "entryID", entries[0].ID),
+	})
+}
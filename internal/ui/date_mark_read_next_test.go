@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMarkDateEntryAsReadAndShowNextRejectsInvalidParameters(t *testing.T) {
+	for _, testCase := range []struct {
+		query     string
+		errorCode string
+	}{
+		{"section=today", dateViewErrorInvalidEntries},
+		{"entry_id=-1&section=today", dateViewErrorInvalidEntries},
+		{"entry_id=42", dateViewErrorInvalidSection},
+		{"entry_id=42&section=all", dateViewErrorInvalidSection},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-read-and-next?"+testCase.query, nil)
+		w := httptest.NewRecorder()
+
+		// The entry and the section are validated before any database access.
+		h := &handler{}
+		h.markDateEntryAsReadAndShowNext(w, r)
+
+		resp := w.Result()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf(`Unexpected status code for %q, got %d instead of %d`, testCase.query, resp.StatusCode, http.StatusBadRequest)
+		}
+
+		var body struct {
+			ErrorCode string `json:"error_code"`
+		}
+		if err := json_parser.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if body.ErrorCode != testCase.errorCode {
+			t.Errorf(`Unexpected error code for %q, got %q instead of %q`, testCase.query, body.ErrorCode, testCase.errorCode)
+		}
+	}
+}
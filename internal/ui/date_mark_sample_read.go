@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// CUSTOM: dateViewDefaultKeepPercentage is the share of a section left unread when "keep" is not given.
+const dateViewDefaultKeepPercentage = 10
+
+// CUSTOM: markDateSectionSampleAsRead reduces the backlog of a date section by marking its entries as read,
+// except for a random sample of them, so a hopelessly large section becomes readable again.
+func (h *handler) markDateSectionSampleAsRead(w http.ResponseWriter, r *http.Request) {
+	keepPercentage, err := dateViewKeepPercentage(r)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidKeep, err)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	section := request.QueryStringParam(r, "section", "all")
+	dateSections, found := dateViewSectionsToMark(r, user, now, section)
+	if !found {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	// The sample is drawn from the entries of the whole selection, not section by section
+	var entryIDs []int64
+	for _, dateSection := range dateSections {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, dateSection)
+
+		sectionEntryIDs, err := builder.GetEntryIDs()
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+		entryIDs = append(entryIDs, sectionEntryIDs...)
+	}
+
+	marked, kept := 0, 0
+	if len(entryIDs) > 0 {
+		marked, kept, err = h.store.MarkEntriesReadKeepingSample(user.ID, entryIDs, keepPercentage)
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+	}
+
+	json.OK(w, r, map[string]int{"marked": marked, "kept": kept})
+}
+
+// dateViewKeepPercentage returns the share of the entries left unread, requested with the "keep" query parameter.
+func dateViewKeepPercentage(r *http.Request) (int, error) {
+	value := request.QueryStringParam(r, "keep", "")
+	if value == "" {
+		return dateViewDefaultKeepPercentage, nil
+	}
+
+	keepPercentage, err := strconv.Atoi(value)
+	if err != nil || keepPercentage < 0 || keepPercentage > 100 {
+		return 0, fmt.Errorf(`invalid "keep" parameter %q, expected a percentage between 0 and 100`, value)
+	}
+	return keepPercentage, nil
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDateViewKeepPercentage(t *testing.T) {
+	scenarios := map[string]int{
+		"":         dateViewDefaultKeepPercentage,
+		"keep=0":   0,
+		"keep=25":  25,
+		"keep=100": 100,
+	}
+
+	for query, expected := range scenarios {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-sample-as-read?"+query, nil)
+		if result, err := dateViewKeepPercentage(r); err != nil || result != expected {
+			t.Errorf(`Unexpected keep percentage for %q, got %d (%v) instead of %d`, query, result, err, expected)
+		}
+	}
+
+	for _, query := range []string{"keep=-1", "keep=101", "keep=half"} {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/mark-sample-as-read?"+query, nil)
+		if _, err := dateViewKeepPercentage(r); err == nil {
+			t.Errorf(`The keep percentage %q should be rejected`, query)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+)
+
+// dateViewNoisyFeedsThreshold is the default number of unread entries in a section from which a feed is noisy.
+const dateViewNoisyFeedsThreshold = 20
+
+// CUSTOM: showDateSectionNoisyFeeds suggests the feeds having at least "threshold" unread entries in the
+// selected date section, so they can be unsubscribed with unsubscribeDateSectionNoisyFeeds.
+func (h *handler) showDateSectionNoisyFeeds(w http.ResponseWriter, r *http.Request) {
+	threshold := request.QueryIntParam(r, "threshold", dateViewNoisyFeedsThreshold)
+	if threshold < 1 {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidThreshold, errors.New("the threshold must be at least 1"))
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	dateSection, errorCode, err := dateViewNoisyFeedsSection(r, user)
+	if err != nil {
+		json.BadRequestWithCode(w, r, errorCode, err)
+		return
+	}
+
+	builder := h.newDateViewQueryBuilder(r, user.ID)
+	withDateSectionBounds(r, builder, user, dateSection)
+	counts, err := builder.CountEntriesByFeed(threshold)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, counts)
+}
+
+// CUSTOM: unsubscribeDateSectionNoisyFeeds marks the entries of the given feeds in the selected date
+// section as read, then removes the feeds. Nothing happens unless the request is explicitly confirmed.
+func (h *handler) unsubscribeDateSectionNoisyFeeds(w http.ResponseWriter, r *http.Request) {
+	type noisyFeedsUnsubscribeRequest struct {
+		FeedIDs []int64 `json:"feed_ids"`
+		Confirm bool    `json:"confirm"`
+	}
+
+	var unsubscribeRequest noisyFeedsUnsubscribeRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&unsubscribeRequest); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidFeeds, err)
+		return
+	}
+
+	if !unsubscribeRequest.Confirm {
+		json.BadRequestWithCode(w, r, dateViewErrorNotConfirmed, errors.New("removing feeds must be confirmed"))
+		return
+	}
+
+	if len(unsubscribeRequest.FeedIDs) == 0 {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidFeeds, errors.New("the list of feeds cannot be empty"))
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	dateSection, errorCode, err := dateViewNoisyFeedsSection(r, user)
+	if err != nil {
+		json.BadRequestWithCode(w, r, errorCode, err)
+		return
+	}
+
+	// Check every feed first, so an unknown feed does not leave the others half processed
+	for _, feedID := range unsubscribeRequest.FeedIDs {
+		if !h.store.FeedExists(user.ID, feedID) {
+			json.BadRequestWithCode(w, r, dateViewErrorInvalidFeeds, fmt.Errorf("unknown feed #%d", feedID))
+			return
+		}
+	}
+
+	markedEntries := 0
+	for _, feedID := range unsubscribeRequest.FeedIDs {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		builder.WithFeedID(feedID)
+		withDateSectionBounds(r, builder, user, dateSection)
+
+		entryIDs, err := builder.GetEntryIDs()
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+
+		if len(entryIDs) > 0 {
+			if err := h.store.SetEntriesStatus(user.ID, entryIDs, model.EntryStatusRead); err != nil {
+				json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+				return
+			}
+			markedEntries += len(entryIDs)
+		}
+
+		if err := h.store.RemoveFeed(user.ID, feedID); err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+	}
+
+	json.OK(w, r, map[string]any{
+		"feed_ids":       unsubscribeRequest.FeedIDs,
+		"marked_entries": markedEntries,
+	})
+}
+
+// dateViewNoisyFeedsSection returns the date section selected for the noisy feeds, with the error code
+// of an invalid request.
+func dateViewNoisyFeedsSection(r *http.Request, user *model.User) (model.DateSection, string, error) {
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		return model.DateSection{}, dateViewErrorInvalidTimezone, err
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		return model.DateSection{}, dateViewErrorInvalidNow, err
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		return model.DateSection{}, dateViewErrorInvalidMedia, err
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		return model.DateSection{}, dateViewErrorInvalidLang, err
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		return model.DateSection{}, dateViewErrorInvalidReading, err
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		return model.DateSection{}, dateViewErrorInvalidDuration, err
+	}
+
+	section := request.QueryStringParam(r, "section", "")
+	dateSection, found := dateViewSection(r, user, now, section)
+	if !found {
+		return model.DateSection{}, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section)
+	}
+	return dateSection, "", nil
+}
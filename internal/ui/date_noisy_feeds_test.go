@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnsubscribeDateSectionNoisyFeedsRequiresConfirmation(t *testing.T) {
+	body := strings.NewReader(`{"feed_ids": [1, 2]}`)
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/noisy-feeds?section=earlier", body)
+	w := httptest.NewRecorder()
+
+	// The confirmation is checked before any database access, so no feed can be removed by mistake.
+	h := &handler{}
+	h.unsubscribeDateSectionNoisyFeeds(w, r)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var response struct {
+		ErrorCode string `json:"error_code"`
+	}
+	if err := json_parser.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+
+	if response.ErrorCode != dateViewErrorNotConfirmed {
+		t.Errorf(`Unexpected error code, got %q instead of %q`, response.ErrorCode, dateViewErrorNotConfirmed)
+	}
+}
+
+func TestShowDateSectionNoisyFeedsRejectsInvalidThreshold(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/entries/by-date/noisy-feeds?section=earlier&threshold=0", nil)
+	w := httptest.NewRecorder()
+
+	h := &handler{}
+	h.showDateSectionNoisyFeeds(w, r)
+
+	if resp := w.Result(); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, http.StatusBadRequest)
+	}
+}
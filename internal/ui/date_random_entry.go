@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+)
+
+// CUSTOM: showRandomDateEntry redirects to a random unread entry of the selected date section,
+// picked among the entries the date view lists with the same filters.
+func (h *handler) showRandomDateEntry(w http.ResponseWriter, r *http.Request) {
+	section := request.QueryStringParam(r, "section", "earlier")
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	dateSection, found := dateViewSection(r, user, now, section)
+	if !found {
+		html.BadRequest(w, r, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	builder := h.newDateViewQueryBuilder(r, user.ID)
+	withDateSectionBounds(r, builder, user, dateSection)
+	builder.WithSorting("random()", "")
+	builder.WithLimit(1)
+
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if len(entryIDs) == 0 {
+		html.NotFound(w, r)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "unreadEntry", "entryID", entryIDs[0]))
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// CUSTOM: restoreDateEntries brings the removed entries of the selected section of the trash back as unread.
+func (h *handler) restoreDateEntries(w http.ResponseWriter, r *http.Request) {
+	section := request.QueryStringParam(r, "section", "all")
+
+	// Only the trash lists removed entries, any other view would find nothing to restore
+	if !dateViewTrash(r) {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, errors.New("only the sections of the trash can be restored"))
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	if !h.dateViewCategoriesExist(user.ID, dateViewCategoryIDs(r)) || !h.dateViewCategoriesExist(user.ID, dateViewExcludedCategoryIDs(r)) {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidCategory, errors.New("unknown category"))
+		return
+	}
+
+	dateSections, found := dateViewSectionsToMark(r, user, now, section)
+	if !found {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	restored := 0
+	for _, dateSection := range dateSections {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, dateSection)
+
+		entryIDs, err := builder.GetEntryIDs()
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+
+		if len(entryIDs) == 0 {
+			continue
+		}
+
+		count, err := h.store.RestoreRemovedEntries(user.ID, entryIDs)
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+		restored += count
+	}
+
+	slog.Info("Restored the removed entries of a date section",
+		slog.Int64("user_id", user.ID),
+		slog.String("section", section),
+		slog.Int("nb_entries", restored),
+	)
+
+	json.OK(w, r, "OK")
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRestoreDateEntriesRequiresTheTrash(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/restore?section=today", nil)
+	w := httptest.NewRecorder()
+
+	// The trash is required before any database access.
+	h := &handler{}
+	h.restoreDateEntries(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), dateViewErrorInvalidSection) {
+		t.Errorf(`Unexpected body %q, the error code %q is missing`, w.Body.String(), dateViewErrorInvalidSection)
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: the bulk save of a date section sends at most dateViewSaveEntriesLimit entries,
+// one every dateViewSaveEntriesInterval, to spare the external services.
+const (
+	dateViewSaveEntriesLimit    = 50
+	dateViewSaveEntriesInterval = 250 * time.Millisecond
+)
+
+// dateSectionSaveResult reports the outcome of the bulk save of a date section.
+type dateSectionSaveResult struct {
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Truncated bool     `json:"truncated"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// CUSTOM: saveDateSectionEntries sends the entries of the selected date section to the configured
+// third-party services, the same way the "Save" action of a single entry does.
+func (h *handler) saveDateSectionEntries(w http.ResponseWriter, r *http.Request) {
+	section := request.QueryStringParam(r, "section", "all")
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	if _, err := dateViewMedia(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidMedia, err)
+		return
+	}
+
+	if _, err := dateViewLanguage(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidLang, err)
+		return
+	}
+
+	if _, _, err := dateViewReadingTime(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidReading, err)
+		return
+	}
+
+	if _, _, err := dateViewDuration(r); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidDuration, err)
+		return
+	}
+
+	dateSections, found := dateViewSectionsToMark(r, user, now, section)
+	if !found {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", section))
+		return
+	}
+
+	if !h.store.HasSaveEntry(user.ID) {
+		json.BadRequestWithCode(w, r, dateViewErrorNoIntegration, errors.New("no third-party service is configured to save entries"))
+		return
+	}
+
+	userIntegrations, err := h.store.Integration(user.ID)
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	// One more entry than the limit tells whether the section holds more entries than are sent
+	entries := make(model.Entries, 0, dateViewSaveEntriesLimit+1)
+	for _, dateSection := range dateSections {
+		builder := h.newDateViewQueryBuilder(r, user.ID)
+		withDateSectionBounds(r, builder, user, dateSection)
+		builder.WithStableSorting(user.EntryOrder, user.EntryDirection)
+		builder.WithLimit(dateViewSaveEntriesLimit + 1 - len(entries))
+
+		sectionEntries, err := builder.GetEntries()
+		if err != nil {
+			json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+			return
+		}
+
+		if entries = append(entries, sectionEntries...); len(entries) > dateViewSaveEntriesLimit {
+			break
+		}
+	}
+
+	result := &dateSectionSaveResult{}
+	if len(entries) > dateViewSaveEntriesLimit {
+		entries = entries[:dateViewSaveEntriesLimit]
+		result.Truncated = true
+	}
+
+	saveDateSectionEntriesWithInterval(r, entries, dateViewSaveEntriesInterval, func(entry *model.Entry) error {
+		return integration.SendEntry(entry, userIntegrations)
+	}, result)
+
+	slog.Info("Saved the entries of a date section",
+		slog.Int64("user_id", user.ID),
+		slog.String("section", section),
+		slog.Int("succeeded", result.Succeeded),
+		slog.Int("failed", result.Failed),
+	)
+
+	json.OK(w, r, result)
+}
+
+// saveDateSectionEntriesWithInterval saves the entries one at a time, waiting the given interval between two of
+// them, and records the outcome in the result. It stops early when the client goes away.
+func saveDateSectionEntriesWithInterval(r *http.Request, entries model.Entries, interval time.Duration, save func(*model.Entry) error, result *dateSectionSaveResult) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i, entry := range entries {
+		if i > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+
+		if err := save(entry); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("entry #%d: %v", entry.ID, err))
+			continue
+		}
+		result.Succeeded++
+	}
+}
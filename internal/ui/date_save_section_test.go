@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestSaveDateSectionEntriesAggregatesErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/save-section?section=today", nil)
+	entries := model.Entries{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	result := &dateSectionSaveResult{}
+	saveDateSectionEntriesWithInterval(r, entries, time.Millisecond, func(entry *model.Entry) error {
+		if entry.ID == 2 {
+			return errors.New("service unavailable")
+		}
+		return nil
+	}, result)
+
+	if result.Succeeded != 2 || result.Failed != 1 {
+		t.Errorf(`Expected 2 succeeded and 1 failed, got %d and %d`, result.Succeeded, result.Failed)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0] != "entry #2: service unavailable" {
+		t.Errorf(`Unexpected errors: %v`, result.Errors)
+	}
+}
+
+func TestSaveDateSectionEntriesStopsWhenTheClientGoesAway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/save-section?section=today", nil).WithContext(ctx)
+	entries := model.Entries{{ID: 1}, {ID: 2}}
+
+	result := &dateSectionSaveResult{}
+	saveDateSectionEntriesWithInterval(r, entries, time.Hour, func(entry *model.Entry) error {
+		cancel()
+		return nil
+	}, result)
+
+	if result.Succeeded != 1 || result.Failed != 0 {
+		t.Errorf(`Expected only the first entry to be saved, got %d succeeded and %d failed`, result.Succeeded, result.Failed)
+	}
+}
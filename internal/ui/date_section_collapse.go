@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// CUSTOM: collapseDateSection remembers whether a section of the date view is collapsed,
+// so the section=all view restores it on the next load.
+func (h *handler) collapseDateSection(w http.ResponseWriter, r *http.Request) {
+	type dateSectionCollapseRequest struct {
+		Section   string `json:"section"`
+		Collapsed bool   `json:"collapsed"`
+	}
+
+	var collapseRequest dateSectionCollapseRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&collapseRequest); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, err)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	userTimezone, err := dateViewTimezone(r, user.Timezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidTimezone, err)
+		return
+	}
+
+	now, err := dateViewNow(r, userTimezone)
+	if err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidNow, err)
+		return
+	}
+
+	// Only names of the sections the page can show are stored
+	if _, found := dateViewSection(r, user, now, collapseRequest.Section); !found {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSection, fmt.Errorf("unknown date section %q", collapseRequest.Section))
+		return
+	}
+
+	if err := h.store.SetDateViewSectionCollapsed(user.ID, collapseRequest.Section, collapseRequest.Collapsed); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, "OK")
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCollapseDateSectionRejectsInvalidBodyWithErrorCode(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/entries/by-date/collapse-section", strings.NewReader(`{"section": 42}`))
+	w := httptest.NewRecorder()
+
+	// The body is decoded before any database access.
+	h := &handler{}
+	h.collapseDateSection(w, r)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var body struct {
+		ErrorCode string `json:"error_code"`
+	}
+	if err := json_parser.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.ErrorCode != dateViewErrorInvalidSection {
+		t.Errorf(`Unexpected error code, got %q instead of %q`, body.ErrorCode, dateViewErrorInvalidSection)
+	}
+}
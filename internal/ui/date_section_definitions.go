@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+// CUSTOM: updateDateSectionDefinitions replaces the date sections defined by the user with the ordered list of the
+// request, as the settings page sends it after a section was dragged. An empty list goes back to the scheme sections.
+func (h *handler) updateDateSectionDefinitions(w http.ResponseWriter, r *http.Request) {
+	type dateSectionDefinitionsRequest struct {
+		Sections model.DateSectionDefinitions `json:"sections"`
+	}
+
+	var definitionsRequest dateSectionDefinitionsRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&definitionsRequest); err != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSections, err)
+		return
+	}
+
+	if validationErr := validator.ValidateDateSectionDefinitions(definitionsRequest.Sections); validationErr != nil {
+		json.BadRequestWithCode(w, r, dateViewErrorInvalidSections, validationErr.Error())
+		return
+	}
+
+	if err := h.store.UpdateDateViewSectionDefinitions(request.UserID(r), definitionsRequest.Sections); err != nil {
+		json.ServerErrorWithCode(w, r, dateViewErrorServer, err)
+		return
+	}
+
+	json.OK(w, r, "OK")
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpdateDateSectionDefinitionsRejectsInvalidSections(t *testing.T) {
+	bodies := []string{
+		`{"sections": 42}`,
+		`{"sections": [{"label": "Today", "days": 1}, {"label": "Today", "days": 7}]}`,
+		`{"sections": [{"label": "This week", "days": 7}, {"label": "Today", "days": 1}]}`,
+	}
+
+	for _, body := range bodies {
+		r := httptest.NewRequest(http.MethodPost, "/entries/by-date/sections", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		// The sections are validated before any database access.
+		h := &handler{}
+		h.updateDateSectionDefinitions(w, r)
+
+		resp := w.Result()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf(`Unexpected status code for %s, got %d instead of %d`, body, resp.StatusCode, http.StatusBadRequest)
+		}
+
+		var result struct {
+			ErrorCode string `json:"error_code"`
+		}
+		if err := json_parser.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatal(err)
+		}
+
+		if result.ErrorCode != dateViewErrorInvalidSections {
+			t.Errorf(`Unexpected error code for %s, got %q instead of %q`, body, result.ErrorCode, dateViewErrorInvalidSections)
+		}
+	}
+}
@@ -0,0 +1,1019 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/validator"
+)
+
+// CUSTOM: machine-readable error codes returned by the date view JSON endpoints.
+const (
+	dateViewErrorInvalidSection     = "invalid_section"
+	dateViewErrorInvalidStatus      = "invalid_status"
+	dateViewErrorInvalidNow         = "invalid_now"
+	dateViewErrorInvalidMedia       = "invalid_media"
+	dateViewErrorInvalidLang        = "invalid_lang"
+	dateViewErrorInvalidReading     = "invalid_reading_time"
+	dateViewErrorInvalidDuration    = "invalid_duration"
+	dateViewErrorInvalidTimezone    = "invalid_timezone"
+	dateViewErrorInvalidEntries     = "invalid_entries"
+	dateViewErrorInvalidFeeds       = "invalid_feeds"
+	dateViewErrorInvalidThreshold   = "invalid_threshold"
+	dateViewErrorNotConfirmed       = "confirmation_required"
+	dateViewErrorNoIntegration      = "no_integration"
+	dateViewErrorInvalidKeep        = "invalid_keep"
+	dateViewErrorInvalidCategory    = "invalid_category"
+	dateViewErrorInvalidAsOf        = "invalid_as_of"
+	dateViewErrorInvalidReason      = "invalid_reason"
+	dateViewErrorInvalidOrder       = "invalid_order"
+	dateViewErrorInvalidSections    = "invalid_sections"
+	dateViewErrorInvalidDomain      = "invalid_domain"
+	dateViewErrorInvalidExceptQuery = "invalid_except_q"
+	dateViewErrorServer             = "server_error"
+)
+
+// CUSTOM: maxDateViewClockSkew bounds how far a client-supplied "now" may be from the server clock.
+const maxDateViewClockSkew = 24 * time.Hour
+
+// dateViewTimezone returns the timezone of the date section boundaries. API clients acting for the user
+// in another display timezone may override the user timezone with the "X-Timezone" header.
+// A stored user timezone that can no longer be loaded, such as a renamed zone, falls back to UTC.
+func dateViewTimezone(r *http.Request, userTimezone string) (string, error) {
+	value := r.Header.Get("X-Timezone")
+	if value == "" {
+		if !dateViewTimezoneIsValid(userTimezone) {
+			return "UTC", nil
+		}
+		return userTimezone, nil
+	}
+
+	if _, found := timezone.AvailableTimezones()[value]; !found {
+		return "", fmt.Errorf(`invalid "X-Timezone" header %q`, value)
+	}
+	return value, nil
+}
+
+// dateViewTimezoneIsValid reports whether the timezone can be loaded. timezone.Now silently uses the server
+// timezone for the others, which would shift every section boundary.
+func dateViewTimezoneIsValid(tz string) bool {
+	_, err := time.LoadLocation(tz)
+	return err == nil
+}
+
+// dateViewNow returns the reference time used to compute the date sections.
+// Authenticated clients may align the sections to their own clock with the RFC3339 "now" query parameter,
+// or look back at the sections as they were at a past date with the RFC3339 "look_back" query parameter.
+// The "as_of" parameter is not a reference time: it bounds the creation date of the entries marked as read.
+func dateViewNow(r *http.Request, userTimezone string) (time.Time, error) {
+	now := timezone.Now(userTimezone)
+	if !request.IsAuthenticated(r) {
+		return now, nil
+	}
+
+	if value := request.QueryStringParam(r, "look_back", ""); value != "" {
+		return parseDateViewLookBackNow(value, now)
+	}
+
+	value := request.QueryStringParam(r, "now", "")
+	if value == "" {
+		return now, nil
+	}
+
+	return parseDateViewNow(value, now)
+}
+
+func parseDateViewNow(value string, serverNow time.Time) (time.Time, error) {
+	clientNow, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(`invalid "now" parameter %q: %v`, value, err)
+	}
+
+	if skew := clientNow.Sub(serverNow); skew > maxDateViewClockSkew || skew < -maxDateViewClockSkew {
+		return time.Time{}, fmt.Errorf(`the "now" parameter %q is too far from the server time`, value)
+	}
+
+	return clientNow, nil
+}
+
+// parseDateViewLookBackNow returns the past reference time given by the "look_back" parameter in the timezone of the
+// server time, so calendar days still follow the user. Any past date is accepted, but not one beyond the allowed clock skew.
+func parseDateViewLookBackNow(value string, serverNow time.Time) (time.Time, error) {
+	lookBack, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(`invalid "look_back" parameter %q: %v`, value, err)
+	}
+
+	if lookBack.Sub(serverNow) > maxDateViewClockSkew {
+		return time.Time{}, fmt.Errorf(`the "look_back" parameter %q is in the future`, value)
+	}
+
+	return lookBack.In(serverNow.Location()), nil
+}
+
+// dateViewScheme returns the section scheme requested with the "buckets" query parameter.
+func dateViewScheme(r *http.Request) string {
+	if request.QueryStringParam(r, "buckets", "") == model.DateSectionSchemeSimple {
+		return model.DateSectionSchemeSimple
+	}
+	return model.DateSectionSchemeDefault
+}
+
+// dateViewIncludeDisabled reports whether entries of disabled feeds are shown,
+// requested with the "include_disabled=1" query parameter.
+func dateViewIncludeDisabled(r *http.Request) bool {
+	return request.QueryBoolParam(r, "include_disabled", false)
+}
+
+// dateViewStarred reports whether the date view lists the starred entries by the date they were starred,
+// requested with the "starred=1" query parameter.
+func dateViewStarred(r *http.Request) bool {
+	return request.QueryBoolParam(r, "starred", false)
+}
+
+// dateViewTrash reports whether the date view lists the entries the user removed by the date they were removed,
+// requested with the "trash=1" query parameter, so recent removals can be reviewed and restored.
+func dateViewTrash(r *http.Request) bool {
+	return request.QueryBoolParam(r, "trash", false)
+}
+
+// dateViewUpdatedBasis reports whether the date view places entries by the date the feed last modified them
+// instead of their publication date, requested with the "basis=updated" query parameter. Entries the feed never
+// modified are left out.
+func dateViewUpdatedBasis(r *http.Request) bool {
+	return request.QueryStringParam(r, "basis", "") == "updated"
+}
+
+// dateViewHideShared reports whether the entries that are currently shared are left out of the date view,
+// requested with the "hide_shared=1" query parameter.
+func dateViewHideShared(r *http.Request) bool {
+	return request.QueryBoolParam(r, "hide_shared", false)
+}
+
+// dateViewPrefetchNext reports whether the page of a single section also preloads the entries of the next
+// non-empty section, requested with the "prefetch_next=1" query parameter.
+func dateViewPrefetchNext(r *http.Request) bool {
+	return request.QueryBoolParam(r, "prefetch_next", false)
+}
+
+// dateViewLayout returns the layout of the sections requested with the "layout" query parameter:
+// "timeline" to space the entries by their publication date, or an empty string for the plain list.
+func dateViewLayout(r *http.Request) string {
+	if request.QueryStringParam(r, "layout", "") == "timeline" {
+		return "timeline"
+	}
+	return ""
+}
+
+// dateViewStableOnly reports whether the entries modified since they were fetched are left out of the date view,
+// requested with the "stable_only=1" query parameter.
+func dateViewStableOnly(r *http.Request) bool {
+	return request.QueryBoolParam(r, "stable_only", false)
+}
+
+// dateViewOpened returns whether the date view only shows the entries the user opened before, or only the ones
+// never opened, requested with the "opened=true" or "opened=false" query parameter. It is nil for any other value.
+func dateViewOpened(r *http.Request) *bool {
+	opened, err := strconv.ParseBool(request.QueryStringParam(r, "opened", ""))
+	if err != nil {
+		return nil
+	}
+	return &opened
+}
+
+// dateViewScrapedOnly reports whether only the entries whose content was fetched by the scraper are shown,
+// requested with the "scraped_only=1" query parameter.
+func dateViewScrapedOnly(r *http.Request) bool {
+	return request.QueryBoolParam(r, "scraped_only", false)
+}
+
+// dateViewMedia returns the media filter requested with the "media" query parameter:
+// "audio" or "video" for entries with such an enclosure, "none" for entries without any.
+func dateViewMedia(r *http.Request) (string, error) {
+	media := request.QueryStringParam(r, "media", "")
+	switch media {
+	case "", "audio", "video", "none":
+		return media, nil
+	}
+	return "", fmt.Errorf(`invalid "media" parameter %q`, media)
+}
+
+// dateViewLanguagePattern matches the language tags accepted by the "lang" filter, such as "de" or "en-us".
+var dateViewLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{1,8})*$`)
+
+// dateViewLanguage returns the lowercase language tag requested with the "lang" query parameter,
+// or an empty string when entries of any language are shown.
+func dateViewLanguage(r *http.Request) (string, error) {
+	language := strings.ToLower(request.QueryStringParam(r, "lang", ""))
+	if language != "" && !dateViewLanguagePattern.MatchString(language) {
+		return "", fmt.Errorf(`invalid "lang" parameter %q`, language)
+	}
+	return language, nil
+}
+
+// dateViewReadingTime returns the reading time range in minutes requested with the "min_reading_time"
+// and "max_reading_time" query parameters, both inclusive. A zero value leaves that side of the range open.
+func dateViewReadingTime(r *http.Request) (minReadingTime, maxReadingTime int, err error) {
+	return dateViewMinutesRange(r, "min_reading_time", "max_reading_time")
+}
+
+// dateViewDuration returns the media duration range in minutes requested with the "min_duration"
+// and "max_duration" query parameters, both inclusive. A zero value leaves that side of the range open.
+func dateViewDuration(r *http.Request) (minDuration, maxDuration int, err error) {
+	return dateViewMinutesRange(r, "min_duration", "max_duration")
+}
+
+// dateViewMinutesRange returns the range in minutes requested with the given query parameters.
+func dateViewMinutesRange(r *http.Request, minParam, maxParam string) (minMinutes, maxMinutes int, err error) {
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{
+		{minParam, &minMinutes},
+		{maxParam, &maxMinutes},
+	} {
+		value := request.QueryStringParam(r, param.name, "")
+		if value == "" {
+			continue
+		}
+
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return 0, 0, fmt.Errorf(`invalid %q parameter %q`, param.name, value)
+		}
+		*param.value = minutes
+	}
+
+	if maxMinutes > 0 && minMinutes > maxMinutes {
+		return 0, 0, fmt.Errorf(`%q %d is greater than %q %d`, minParam, minMinutes, maxParam, maxMinutes)
+	}
+	return minMinutes, maxMinutes, nil
+}
+
+// dateViewTag returns the lowercase feed tag requested with the "tag" query parameter,
+// or an empty string when entries of every feed are shown.
+func dateViewTag(r *http.Request) string {
+	return strings.ToLower(strings.TrimSpace(request.QueryStringParam(r, "tag", "")))
+}
+
+// dateViewLabel returns the lowercase entry label requested with the "label" query parameter,
+// or an empty string when entries are shown whatever their labels.
+func dateViewLabel(r *http.Request) string {
+	return strings.ToLower(strings.TrimSpace(request.QueryStringParam(r, "label", "")))
+}
+
+// dateViewSource returns the feed subscription source requested with the "source" query parameter,
+// such as "manual", or an empty string when entries of every feed are shown.
+func dateViewSource(r *http.Request) string {
+	return strings.ToLower(strings.TrimSpace(request.QueryStringParam(r, "source", "")))
+}
+
+// dateViewCategoryIDs returns the sorted categories requested with the repeated "category_id" query parameter,
+// or nil when entries of every category are shown. Invalid values are ignored.
+func dateViewCategoryIDs(r *http.Request) []int64 {
+	return dateViewCategoryIDsParam(r, "category_id")
+}
+
+// dateViewExcludedCategoryIDs returns the sorted categories left out of the date view, requested with the repeated
+// "exclude_category_id" query parameter, or nil. Invalid values are ignored.
+func dateViewExcludedCategoryIDs(r *http.Request) []int64 {
+	return dateViewCategoryIDsParam(r, "exclude_category_id")
+}
+
+// dateViewCategoryIDsParam returns the sorted and distinct positive category IDs of the repeated query parameter.
+func dateViewCategoryIDsParam(r *http.Request, name string) []int64 {
+	var categoryIDs []int64
+	for _, value := range r.URL.Query()[name] {
+		categoryID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || categoryID <= 0 || slices.Contains(categoryIDs, categoryID) {
+			continue
+		}
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	slices.Sort(categoryIDs)
+	return categoryIDs
+}
+
+// dateViewCategoriesExist reports whether every requested category belongs to the user.
+func (h *handler) dateViewCategoriesExist(userID int64, categoryIDs []int64) bool {
+	for _, categoryID := range categoryIDs {
+		if !h.store.CategoryIDExists(userID, categoryID) {
+			return false
+		}
+	}
+	return true
+}
+
+// dateViewBoostManual reports whether the entries of the feeds subscribed to by hand are sorted first
+// within the sections, requested with the "boost=manual" query parameter.
+func dateViewBoostManual(r *http.Request) bool {
+	return request.QueryStringParam(r, "boost", "") == model.FeedSourceManual
+}
+
+// dateViewHasEntryFilters reports whether the date view filters entries on attributes that
+// MarkEntriesInDateRange does not support, so the matching entries have to be selected first.
+func dateViewHasEntryFilters(r *http.Request) bool {
+	media, _ := dateViewMedia(r)
+	language, _ := dateViewLanguage(r)
+	minReadingTime, maxReadingTime, _ := dateViewReadingTime(r)
+	minDuration, maxDuration, _ := dateViewDuration(r)
+	return media != "" || language != "" || minReadingTime > 0 || maxReadingTime > 0 || minDuration > 0 || maxDuration > 0 || dateViewTag(r) != "" || dateViewSource(r) != "" ||
+		len(dateViewCategoryIDs(r)) > 0 || len(dateViewExcludedCategoryIDs(r)) > 0 || dateViewLabel(r) != "" || dateViewStarred(r) || dateViewTrash(r) || dateViewUpdatedBasis(r) || dateViewHideShared(r) ||
+		dateViewStableOnly(r) || dateViewScrapedOnly(r) || dateViewOpened(r) != nil
+}
+
+// newDateViewQueryBuilder returns a query builder for the unread entries that the date view shows, or the removed
+// ones in the trash. Invalid filters are ignored, handlers report them with dateViewMedia, dateViewLanguage,
+// dateViewReadingTime and dateViewDuration.
+func (h *handler) newDateViewQueryBuilder(r *http.Request, userID int64) *storage.EntryQueryBuilder {
+	builder := h.store.NewEntryQueryBuilder(userID)
+	if dateViewTrash(r) {
+		builder.WithStatus(model.EntryStatusRemoved)
+	} else {
+		builder.WithStatus(model.EntryStatusUnread)
+	}
+	return withDateViewFilters(r, builder)
+}
+
+// newDateViewReadOrUnreadQueryBuilder returns a query builder for the entries the date view would show,
+// read ones included, to measure how much of a section was read.
+func (h *handler) newDateViewReadOrUnreadQueryBuilder(r *http.Request, userID int64) *storage.EntryQueryBuilder {
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	return withDateViewFilters(r, builder)
+}
+
+// withDateViewFilters restricts the builder to the entries matching the filters of the date view.
+func withDateViewFilters(r *http.Request, builder *storage.EntryQueryBuilder) *storage.EntryQueryBuilder {
+	builder.WithDateViewVisibility(dateViewIncludeDisabled(r))
+
+	if dateViewStarred(r) {
+		builder.WithStarred(true)
+	}
+
+	if dateViewHideShared(r) {
+		builder.WithoutShareCode()
+	}
+
+	if dateViewStableOnly(r) {
+		builder.WithoutModificationsSinceFetch()
+	}
+
+	if dateViewScrapedOnly(r) {
+		builder.WithScrapedContent()
+	}
+
+	if opened := dateViewOpened(r); opened != nil {
+		builder.WithOpened(*opened)
+	}
+
+	switch media, _ := dateViewMedia(r); media {
+	case "audio", "video":
+		builder.WithEnclosureMimeTypePrefix(media + "/")
+	case "none":
+		builder.WithoutEnclosureMimeTypePrefix("audio/")
+		builder.WithoutEnclosureMimeTypePrefix("video/")
+	}
+
+	if language, _ := dateViewLanguage(r); language != "" {
+		builder.WithLanguage(language)
+	}
+
+	if tag := dateViewTag(r); tag != "" {
+		builder.WithFeedTag(tag)
+	}
+
+	if source := dateViewSource(r); source != "" {
+		builder.WithFeedSource(source)
+	}
+
+	if label := dateViewLabel(r); label != "" {
+		builder.WithEntryLabel(label)
+	}
+
+	if categoryIDs := dateViewCategoryIDs(r); len(categoryIDs) > 0 {
+		builder.WithCategoryIDs(categoryIDs)
+	}
+
+	if excludedCategoryIDs := dateViewExcludedCategoryIDs(r); len(excludedCategoryIDs) > 0 {
+		builder.WithoutCategoryIDs(excludedCategoryIDs)
+	}
+
+	minReadingTime, maxReadingTime, _ := dateViewReadingTime(r)
+	if minReadingTime > 0 {
+		builder.WithMinReadingTime(minReadingTime)
+	}
+	if maxReadingTime > 0 {
+		builder.WithMaxReadingTime(maxReadingTime)
+	}
+
+	if minDuration, maxDuration, _ := dateViewDuration(r); minDuration > 0 || maxDuration > 0 {
+		builder.WithEnclosureDuration(minDuration*60, maxDuration*60)
+	}
+	return builder
+}
+
+// withDateSectionBounds restricts the builder to the window of the section. Entries are placed by their
+// publication date, or by the most recent of their publication and modification dates when the user prefers it.
+// A display date override set on the entry takes precedence over both. The trash places entries by the date
+// they were removed instead, the starred mode by the date they were starred, and the updated basis by the date
+// the feed last modified them.
+func withDateSectionBounds(r *http.Request, builder *storage.EntryQueryBuilder, user *model.User, section model.DateSection) {
+	if section.OpenedAfter != nil {
+		builder.AfterOpenedDate(*section.OpenedAfter)
+	}
+
+	if section.InProgress {
+		builder.WithReadingInProgress()
+	}
+
+	if section.FeedCreatedAfter != nil {
+		builder.AfterFeedCreatedDate(*section.FeedCreatedAfter)
+	}
+
+	if dateViewTrash(r) {
+		if section.After != nil {
+			builder.AfterRemovedDate(*section.After)
+		}
+		if section.Before != nil {
+			builder.BeforeRemovedDate(*section.Before)
+		}
+		return
+	}
+
+	if dateViewStarred(r) {
+		if section.After != nil {
+			builder.AfterStarredDate(*section.After)
+		}
+		if section.Before != nil {
+			builder.BeforeStarredDate(*section.Before)
+		}
+		return
+	}
+
+	if dateViewUpdatedBasis(r) {
+		if section.After != nil {
+			builder.AfterUpdatedDate(*section.After)
+		}
+		if section.Before != nil {
+			builder.BeforeUpdatedDate(*section.Before)
+		}
+		return
+	}
+
+	builder.WithinDateSection(section, user.DateViewUseLatestDate)
+}
+
+// dateViewCountKey identifies the count of a section in the date section count cache. It holds every
+// parameter the count depends on, except the reference time which the cache tracks by minute.
+func dateViewCountKey(r *http.Request, user *model.User, section string) string {
+	media, _ := dateViewMedia(r)
+	language, _ := dateViewLanguage(r)
+	minReadingTime, maxReadingTime, _ := dateViewReadingTime(r)
+
+	values := url.Values{}
+	values.Set("section", section)
+	values.Set("from", request.QueryStringParam(r, "from", ""))
+	values.Set("to", request.QueryStringParam(r, "to", ""))
+	values.Set("buckets", dateViewScheme(r))
+	values.Set("include_disabled", strconv.FormatBool(dateViewIncludeDisabled(r)))
+	values.Set("media", media)
+	values.Set("lang", language)
+	values.Set("tag", dateViewTag(r))
+	values.Set("source", dateViewSource(r))
+	for _, categoryID := range dateViewCategoryIDs(r) {
+		values.Add("category_id", strconv.FormatInt(categoryID, 10))
+	}
+	for _, categoryID := range dateViewExcludedCategoryIDs(r) {
+		values.Add("exclude_category_id", strconv.FormatInt(categoryID, 10))
+	}
+	values.Set("label", dateViewLabel(r))
+	values.Set("starred", strconv.FormatBool(dateViewStarred(r)))
+	values.Set("trash", strconv.FormatBool(dateViewTrash(r)))
+	values.Set("updated_basis", strconv.FormatBool(dateViewUpdatedBasis(r)))
+	values.Set("hide_shared", strconv.FormatBool(dateViewHideShared(r)))
+	values.Set("stable_only", strconv.FormatBool(dateViewStableOnly(r)))
+	values.Set("scraped_only", strconv.FormatBool(dateViewScrapedOnly(r)))
+	if opened := dateViewOpened(r); opened != nil {
+		values.Set("opened", strconv.FormatBool(*opened))
+	}
+	values.Set("min_reading_time", strconv.Itoa(minReadingTime))
+	values.Set("max_reading_time", strconv.Itoa(maxReadingTime))
+	minDuration, maxDuration, _ := dateViewDuration(r)
+	values.Set("min_duration", strconv.Itoa(minDuration))
+	values.Set("max_duration", strconv.Itoa(maxDuration))
+	values.Set("latest_date", strconv.FormatBool(user.DateViewUseLatestDate))
+	if user.DateViewDayResetHour != nil {
+		values.Set("reset_hour", strconv.Itoa(*user.DateViewDayResetHour))
+	}
+
+	// The "X-Timezone" header moves the section boundaries, handlers reject the invalid ones beforehand
+	userTimezone, _ := dateViewTimezone(r, user.Timezone)
+	values.Set("tz", userTimezone)
+	return "ui?" + values.Encode()
+}
+
+// dateViewEarlierByMonth reports whether the oldest section is split into calendar months,
+// requested with the "earlier=months" query parameter. Months are counted by publication date,
+// so the starred mode, the trash and the updated basis never split the oldest section.
+func dateViewEarlierByMonth(r *http.Request) bool {
+	return request.QueryStringParam(r, "earlier", "") == "months" && !dateViewStarred(r) && !dateViewTrash(r) && !dateViewUpdatedBasis(r)
+}
+
+// dateViewSection returns the section of the date view with the given name, including the month
+// sections of the oldest section.
+func dateViewSection(r *http.Request, user *model.User, now time.Time, name string) (model.DateSection, bool) {
+	if name == model.DateSectionCustom {
+		section, err := dateViewCustomSection(r, now.Location())
+		if err != nil || section == nil {
+			return model.DateSection{}, false
+		}
+		return *section, true
+	}
+
+	sections := user.DateSections(dateViewScheme(r), now)
+	if section, found := model.FindDateSection(sections, name); found {
+		return section, true
+	}
+
+	publicationSections := model.PublicationDateSections(sections)
+	return model.ParseMonthDateSection(publicationSections[len(publicationSections)-1], name)
+}
+
+// dateViewCustomSection returns the section of the explicit range of days requested with the "from" and "to"
+// query parameters, such as "2024-01-01", in the given timezone. The from day is included and the to day excluded.
+// It returns nil when neither parameter is given.
+func dateViewCustomSection(r *http.Request, location *time.Location) (*model.DateSection, error) {
+	fromValue := request.QueryStringParam(r, "from", "")
+	toValue := request.QueryStringParam(r, "to", "")
+	if fromValue == "" && toValue == "" {
+		return nil, nil
+	}
+
+	if fromValue == "" || toValue == "" {
+		return nil, fmt.Errorf(`the "from" and "to" parameters are both required, got %q and %q`, fromValue, toValue)
+	}
+
+	from, err := time.ParseInLocation(time.DateOnly, fromValue, location)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid "from" parameter %q: %v`, fromValue, err)
+	}
+
+	to, err := time.ParseInLocation(time.DateOnly, toValue, location)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid "to" parameter %q: %v`, toValue, err)
+	}
+
+	if !to.After(from) {
+		return nil, fmt.Errorf(`the "to" parameter %q is not after the "from" parameter %q`, toValue, fromValue)
+	}
+
+	section := model.NewCustomDateSection(from, to)
+	return &section, nil
+}
+
+// dateViewWithMonthSections replaces the oldest publication date section by one section per given month.
+func dateViewWithMonthSections(sections []model.DateSection, months []time.Time) []model.DateSection {
+	oldestIndex := len(model.PublicationDateSections(sections)) - 1
+	monthSections := model.NewMonthDateSections(sections[oldestIndex], months)
+	return slices.Replace(slices.Clone(sections), oldestIndex, oldestIndex+1, monthSections...)
+}
+
+// dateViewFocusUnvisited reports whether the newest section only lists the newest entry of each feed
+// not opened today, requested with the "focus=unvisited" query parameter.
+func dateViewFocusUnvisited(r *http.Request) bool {
+	return request.QueryStringParam(r, "focus", "") == "unvisited"
+}
+
+// dateViewFocusQuery returns the query string selecting the given section with the focus mode turned on or off.
+func dateViewFocusQuery(r *http.Request, section string, focus bool) string {
+	values, _ := url.ParseQuery(dateViewQuery(r, section))
+	if focus {
+		values.Set("focus", "unvisited")
+	} else {
+		values.Del("focus")
+	}
+	return values.Encode()
+}
+
+// dateViewStarredQuery returns the query string selecting the given section with the starred mode turned on or off.
+func dateViewStarredQuery(r *http.Request, section string, starred bool) string {
+	values, _ := url.ParseQuery(dateViewQuery(r, section))
+	if starred {
+		values.Set("starred", "1")
+	} else {
+		values.Del("starred")
+	}
+	return values.Encode()
+}
+
+// dateViewTrashQuery returns the query string selecting the given section with the trash turned on or off.
+func dateViewTrashQuery(r *http.Request, section string, trash bool) string {
+	values, _ := url.ParseQuery(dateViewQuery(r, section))
+	if trash {
+		values.Set("trash", "1")
+	} else {
+		values.Del("trash")
+	}
+	return values.Encode()
+}
+
+// dateViewUpdatedBasisQuery returns the query string selecting the given section with the updated basis turned on or off.
+func dateViewUpdatedBasisQuery(r *http.Request, section string, updated bool) string {
+	values, _ := url.ParseQuery(dateViewQuery(r, section))
+	if updated {
+		values.Set("basis", "updated")
+	} else {
+		values.Del("basis")
+	}
+	return values.Encode()
+}
+
+// dateViewShowNavigation reports whether the counts of every section are computed for the navigation,
+// turned off with the "nav=0" query parameter.
+func dateViewShowNavigation(r *http.Request) bool {
+	return request.QueryBoolParam(r, "nav", true)
+}
+
+// dateViewCursor returns the position after which the selected section is listed, from the "cursor"
+// query parameter. The first page has no cursor.
+func dateViewCursor(r *http.Request) (*model.EntryCursor, error) {
+	token := request.QueryStringParam(r, "cursor", "")
+	if token == "" {
+		return nil, nil
+	}
+
+	cursor, err := model.ParseEntryCursor(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// A cursor of a listing sorted with another tiebreak would skip or repeat entries
+	if cursor.Tiebreak != dateViewTiebreak(r) {
+		return nil, fmt.Errorf(`the cursor does not match the tiebreak %q`, dateViewTiebreak(r))
+	}
+	return cursor, nil
+}
+
+// dateViewOrder returns the sorting order of the entries requested with the "order" query parameter,
+// or the given default order when it is missing.
+func dateViewOrder(r *http.Request, defaultOrder string) (string, error) {
+	order := request.QueryStringParam(r, "order", "")
+	if order == "" {
+		return defaultOrder, nil
+	}
+
+	if err := validator.ValidateEntryOrder(order); err != nil {
+		return "", err
+	}
+	return order, nil
+}
+
+// dateViewTiebreak returns the order of the entries sharing the same sort value, requested with the "tiebreak"
+// query parameter: "title" or "feed_title", or an empty string when the ties are broken by entry ID only.
+func dateViewTiebreak(r *http.Request) string {
+	switch tiebreak := request.QueryStringParam(r, "tiebreak", ""); tiebreak {
+	case model.EntryTiebreakTitle, model.EntryTiebreakFeedTitle:
+		return tiebreak
+	}
+	return ""
+}
+
+// dateViewSeenEntryIDs returns the entries of the newest section seen during the session once the given
+// entries are shown. When they are the whole section, earlier entries no longer listed are forgotten.
+func dateViewSeenEntryIDs(previous []int64, shown model.Entries, wholeSection bool) []int64 {
+	var seen []int64
+	known := make(map[int64]bool)
+	if !wholeSection {
+		for _, entryID := range previous {
+			if !known[entryID] {
+				known[entryID] = true
+				seen = append(seen, entryID)
+			}
+		}
+	}
+	for _, entry := range shown {
+		if !known[entry.ID] {
+			known[entry.ID] = true
+			seen = append(seen, entry.ID)
+		}
+	}
+
+	// Keep the session data small, the oldest seen entries are dropped first
+	if len(seen) > dateViewSeenEntriesLimit {
+		seen = seen[len(seen)-dateViewSeenEntriesLimit:]
+	}
+	return seen
+}
+
+// dateViewSectionDirection returns the sorting direction of the entries of the section, overridden for a section
+// with the "sort_<section>" query parameter, such as "sort_earlier=asc" to clear the oldest backlog first.
+// Month sections follow the parameter of the section they split.
+func dateViewSectionDirection(r *http.Request, section model.DateSection, defaultDirection string) string {
+	name := section.Name
+	if !section.Month.IsZero() {
+		name, _, _ = strings.Cut(name, "-")
+	}
+
+	switch direction := request.QueryStringParam(r, "sort_"+name, ""); direction {
+	case "asc", "desc":
+		return direction
+	default:
+		return defaultDirection
+	}
+}
+
+// dateViewSortQuery returns the valid "sort_<section>" query parameters of the request.
+func dateViewSortQuery(r *http.Request) url.Values {
+	values := url.Values{}
+	for key, value := range r.URL.Query() {
+		if !strings.HasPrefix(key, "sort_") || len(value) == 0 {
+			continue
+		}
+		if value[0] == "asc" || value[0] == "desc" {
+			values.Set(key, value[0])
+		}
+	}
+	return values
+}
+
+// dateViewQuery returns the query string selecting the given section while keeping the
+// parameters that shape the date view.
+func dateViewQuery(r *http.Request, section string) string {
+	values := url.Values{}
+	values.Set("section", section)
+	if from, to := request.QueryStringParam(r, "from", ""), request.QueryStringParam(r, "to", ""); from != "" && to != "" {
+		values.Set("from", from)
+		values.Set("to", to)
+	}
+	if scheme := dateViewScheme(r); scheme != model.DateSectionSchemeDefault {
+		values.Set("buckets", scheme)
+	}
+	if dateViewEarlierByMonth(r) {
+		values.Set("earlier", "months")
+	}
+	if dateViewIncludeDisabled(r) {
+		values.Set("include_disabled", "1")
+	}
+	if dateViewStarred(r) {
+		values.Set("starred", "1")
+	}
+	if dateViewTrash(r) {
+		values.Set("trash", "1")
+	}
+	if dateViewUpdatedBasis(r) {
+		values.Set("basis", "updated")
+	}
+	if dateViewHideShared(r) {
+		values.Set("hide_shared", "1")
+	}
+	if dateViewStableOnly(r) {
+		values.Set("stable_only", "1")
+	}
+	if dateViewScrapedOnly(r) {
+		values.Set("scraped_only", "1")
+	}
+	if opened := dateViewOpened(r); opened != nil {
+		values.Set("opened", strconv.FormatBool(*opened))
+	}
+	if layout := dateViewLayout(r); layout != "" {
+		values.Set("layout", layout)
+	}
+	if dateViewPrefetchNext(r) {
+		values.Set("prefetch_next", "1")
+	}
+	if media, _ := dateViewMedia(r); media != "" {
+		values.Set("media", media)
+	}
+	if language, _ := dateViewLanguage(r); language != "" {
+		values.Set("lang", language)
+	}
+	if tag := dateViewTag(r); tag != "" {
+		values.Set("tag", tag)
+	}
+	if source := dateViewSource(r); source != "" {
+		values.Set("source", source)
+	}
+	for _, categoryID := range dateViewCategoryIDs(r) {
+		values.Add("category_id", strconv.FormatInt(categoryID, 10))
+	}
+	for _, categoryID := range dateViewExcludedCategoryIDs(r) {
+		values.Add("exclude_category_id", strconv.FormatInt(categoryID, 10))
+	}
+	if label := dateViewLabel(r); label != "" {
+		values.Set("label", label)
+	}
+	if dateViewBoostManual(r) {
+		values.Set("boost", model.FeedSourceManual)
+	}
+	if tiebreak := dateViewTiebreak(r); tiebreak != "" {
+		values.Set("tiebreak", tiebreak)
+	}
+	if order, err := dateViewOrder(r, ""); err == nil && order != "" {
+		values.Set("order", order)
+	}
+	minReadingTime, maxReadingTime, _ := dateViewReadingTime(r)
+	if minReadingTime > 0 {
+		values.Set("min_reading_time", strconv.Itoa(minReadingTime))
+	}
+	if maxReadingTime > 0 {
+		values.Set("max_reading_time", strconv.Itoa(maxReadingTime))
+	}
+	minDuration, maxDuration, _ := dateViewDuration(r)
+	if minDuration > 0 {
+		values.Set("min_duration", strconv.Itoa(minDuration))
+	}
+	if maxDuration > 0 {
+		values.Set("max_duration", strconv.Itoa(maxDuration))
+	}
+	if dateViewFocusUnvisited(r) {
+		values.Set("focus", "unvisited")
+	}
+	if request.IsAuthenticated(r) {
+		// The client clock keeps placing the sections the page showed, whatever the request that follows
+		if now := request.QueryStringParam(r, "now", ""); now != "" {
+			values.Set("now", now)
+		}
+		if lookBack := request.QueryStringParam(r, "look_back", ""); lookBack != "" {
+			values.Set("look_back", lookBack)
+		}
+	}
+	if !dateViewShowNavigation(r) {
+		values.Set("nav", "0")
+	}
+	for key, value := range dateViewSortQuery(r) {
+		values[key] = value
+	}
+	return values.Encode()
+}
+
+// dateViewMarkAsReadQuery returns the query string of the URL marking the section as read, which leaves out
+// the entries created after renderedAt. The reference time of the page, "now" or "look_back", is kept as is.
+func dateViewMarkAsReadQuery(r *http.Request, section string, renderedAt time.Time) string {
+	return dateViewQuery(r, section) + "&as_of=" + url.QueryEscape(renderedAt.UTC().Format(time.RFC3339Nano))
+}
+
+// dateViewAverageAge returns the average age at now of the publication dates of the entries, zero without entries.
+func dateViewAverageAge(entries model.Entries, now time.Time) time.Duration {
+	if len(entries) == 0 {
+		return 0
+	}
+
+	// Summed in seconds, as the nanoseconds of many old entries would overflow
+	var totalSeconds float64
+	for _, entry := range entries {
+		totalSeconds += now.Sub(entry.Date).Seconds()
+	}
+	return time.Duration(totalSeconds / float64(len(entries)) * float64(time.Second))
+}
+
+// dateViewMonthLabel returns the translated label of the section of the calendar month, such as "January 2025".
+func dateViewMonthLabel(printer *locale.Printer, month time.Time) string {
+	monthName := printer.Print("date_group.month." + strings.ToLower(month.Month().String()))
+	return printer.Printf("date_group.month", monthName, month.Year())
+}
+
+// dateViewReadingPaceDays is the number of days over which the reading pace of the catch-up estimates is measured.
+const dateViewReadingPaceDays = 7
+
+// dateViewTotalReadingTime returns the sum of the reading times of the entries, in minutes.
+func dateViewTotalReadingTime(entries model.Entries) int {
+	minutes := 0
+	for _, entry := range entries {
+		minutes += entry.ReadingTime
+	}
+	return minutes
+}
+
+// dateViewCatchUpEstimate returns the time reading the given minutes takes for a user who read paceMinutes over
+// the last dateViewReadingPaceDays days, zero when either is zero.
+func dateViewCatchUpEstimate(readingMinutes, paceMinutes int) time.Duration {
+	if readingMinutes <= 0 || paceMinutes <= 0 {
+		return 0
+	}
+
+	days := float64(readingMinutes) * dateViewReadingPaceDays / float64(paceMinutes)
+	return time.Duration(days * float64(24*time.Hour))
+}
+
+// dateViewDaysAgo returns the calendar days since the date placing each entry in the date view, so the badge
+// of an entry follows the calendar days of the user timezone rather than the hours elapsed.
+func dateViewDaysAgo(entries model.Entries, now time.Time) map[int64]int {
+	daysAgo := make(map[int64]int, len(entries))
+	for _, entry := range entries {
+		daysAgo[entry.ID] = model.CalendarDaysAgo(entry.DateViewDate(), now)
+	}
+	return daysAgo
+}
+
+// dateViewNextNonEmptySection returns the first section after the selected one, in navigation order, that has
+// unread entries, or nil when none remains or no single section is selected.
+func dateViewNextNonEmptySection(sectionViews []*dateSectionView, section string) *dateSectionView {
+	selected := slices.IndexFunc(sectionViews, func(sectionView *dateSectionView) bool {
+		return sectionView.Name == section
+	})
+	if selected < 0 {
+		return nil
+	}
+
+	for _, sectionView := range sectionViews[selected+1:] {
+		if sectionView.Count > 0 {
+			return sectionView
+		}
+	}
+	return nil
+}
+
+// dateViewEmptySectionMessageKeys holds the message shown when a section of the same name has no unread entry.
+var dateViewEmptySectionMessageKeys = map[string]string{
+	"today":                         "page.date_entries.empty_section.today",
+	"last2d":                        "page.date_entries.empty_section.last_2d",
+	"last7d":                        "page.date_entries.empty_section.last_7d",
+	"last30d":                       "page.date_entries.empty_section.last_30d",
+	"earlier":                       "page.date_entries.empty_section.earlier",
+	"recent":                        "page.date_entries.empty_section.recent",
+	"this_week":                     "page.date_entries.empty_section.this_week",
+	"older":                         "page.date_entries.empty_section.older",
+	model.DateSectionOpenedRecently: "page.date_entries.empty_section.opened_recently",
+	model.DateSectionInProgress:     "page.date_entries.empty_section.in_progress",
+	model.DateSectionNewFeeds:       "page.date_entries.empty_section.new_feeds",
+	model.DateSectionFuture:         "page.date_entries.empty_section.future",
+}
+
+// dateViewEmptySectionMessageKey returns the translation key of the message shown when the section has
+// no unread entry, the generic one for sections without their own, such as months.
+func dateViewEmptySectionMessageKey(section string) string {
+	if key, found := dateViewEmptySectionMessageKeys[section]; found {
+		return key
+	}
+	return "page.date_entries.empty_section"
+}
+
+// dateViewIsSelectedSectionEmpty tells whether a single section is selected and lists no entry.
+func dateViewIsSelectedSectionEmpty(sectionViews []*dateSectionView, section string) bool {
+	for _, sectionView := range sectionViews {
+		if sectionView.Name == section {
+			return len(sectionView.Entries) == 0
+		}
+	}
+	return false
+}
+
+// CUSTOM: dimensions of the daily entry count sparkline of the date view header, in SVG user units.
+const (
+	dateViewSparklineDays     = 30
+	dateViewSparklineBarWidth = 3
+	dateViewSparklineHeight   = 20
+)
+
+// dateViewSparklineBar is one day of the sparkline, positioned so the template only has to draw it.
+type dateViewSparklineBar struct {
+	Day    time.Time
+	Count  int
+	X      int
+	Y      int
+	Height int
+}
+
+// dateViewSparkline scales the daily entry counts to the sparkline height, the busiest day filling it.
+// Days with a few entries still get a visible bar.
+func dateViewSparkline(counts []model.DailyEntryCount) []dateViewSparklineBar {
+	maxCount := 0
+	for _, count := range counts {
+		maxCount = max(maxCount, count.Count)
+	}
+
+	bars := make([]dateViewSparklineBar, 0, len(counts))
+	for i, count := range counts {
+		height := 0
+		if maxCount > 0 {
+			height = count.Count * dateViewSparklineHeight / maxCount
+			if count.Count > 0 {
+				height = max(height, 1)
+			}
+		}
+
+		bars = append(bars, dateViewSparklineBar{
+			Day:    count.Day,
+			Count:  count.Count,
+			X:      i * dateViewSparklineBarWidth,
+			Y:      dateViewSparklineHeight - height,
+			Height: height,
+		})
+	}
+	return bars
+}
@@ -0,0 +1,648 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"context"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestParseDateViewNow(t *testing.T) {
+	serverNow := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	result, err := parseDateViewNow("2025-03-10T08:30:00-05:00", serverNow)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := time.Date(2025, time.March, 10, 13, 30, 0, 0, time.UTC)
+	if !result.Equal(expected) {
+		t.Errorf(`Unexpected reference time, got %v instead of %v`, result, expected)
+	}
+
+	if _, offset := result.Zone(); offset != -5*3600 {
+		t.Errorf(`The client offset should be preserved, got %d`, offset)
+	}
+}
+
+func TestDateViewTimezone(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date", nil)
+	if result, err := dateViewTimezone(r, "Europe/Paris"); err != nil || result != "Europe/Paris" {
+		t.Errorf(`Expected the user timezone without header, got %q (%v)`, result, err)
+	}
+
+	if result, err := dateViewTimezone(r, "Mars/Olympus_Mons"); err != nil || result != "UTC" {
+		t.Errorf(`Expected an invalid user timezone to fall back to UTC, got %q (%v)`, result, err)
+	}
+
+	r.Header.Set("X-Timezone", "America/New_York")
+	if result, err := dateViewTimezone(r, "Europe/Paris"); err != nil || result != "America/New_York" {
+		t.Errorf(`Expected the timezone of the header, got %q (%v)`, result, err)
+	}
+
+	for _, value := range []string{"Mars/Olympus_Mons", "europe/paris", "Local"} {
+		r.Header.Set("X-Timezone", value)
+		if _, err := dateViewTimezone(r, "Europe/Paris"); err == nil {
+			t.Errorf(`The timezone %q should be rejected`, value)
+		}
+	}
+}
+
+func TestDateViewCountKeyDependsOnTheTimezone(t *testing.T) {
+	user := &model.User{Timezone: "Europe/Paris"}
+	r := httptest.NewRequest("GET", "/entries/by-date", nil)
+	userKey := dateViewCountKey(r, user, "today")
+
+	r.Header.Set("X-Timezone", "America/New_York")
+	if key := dateViewCountKey(r, user, "today"); key == userKey {
+		t.Errorf(`Expected another count key in another timezone, got %q for both`, key)
+	}
+
+	r.Header.Set("X-Timezone", "Europe/Paris")
+	if key := dateViewCountKey(r, user, "today"); key != userKey {
+		t.Errorf(`Expected the same count key in the timezone of the user, got %q instead of %q`, key, userKey)
+	}
+}
+
+func TestDateViewCountKeyDependsOnTheDayResetHour(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date", nil)
+	resetHour := 4
+	keys := map[string]bool{
+		dateViewCountKey(r, &model.User{}, "today"):                                 true,
+		dateViewCountKey(r, &model.User{DateViewDayResetHour: &resetHour}, "today"): true,
+	}
+
+	resetHour = 0
+	keys[dateViewCountKey(r, &model.User{DateViewDayResetHour: &resetHour}, "today")] = true
+	if len(keys) != 3 {
+		t.Errorf(`Expected a count key for each day reset hour, got %v`, keys)
+	}
+}
+
+func TestDateViewTimezoneIsValid(t *testing.T) {
+	for tz, expected := range map[string]bool{
+		"Europe/Paris":      true,
+		"UTC":               true,
+		"Mars/Olympus_Mons": false,
+		"Europe/Kiev ":      false,
+	} {
+		if result := dateViewTimezoneIsValid(tz); result != expected {
+			t.Errorf(`Unexpected validity of the timezone %q, got %v`, tz, result)
+		}
+	}
+}
+
+func TestParseDateViewNowRejectsInvalidValues(t *testing.T) {
+	serverNow := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{
+		"yesterday",
+		"2025-03-10",
+		"2025-03-12T12:00:01Z",
+		"2025-03-08T11:59:59Z",
+		"2099-01-01T00:00:00Z",
+	} {
+		if _, err := parseDateViewNow(value, serverNow); err == nil {
+			t.Errorf(`The value %q should be rejected`, value)
+		}
+	}
+}
+
+func TestParseDateViewLookBackNow(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverNow := time.Date(2025, time.March, 14, 12, 0, 0, 0, location)
+
+	result, err := parseDateViewLookBackNow("2025-03-10T08:30:00Z", serverNow)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := time.Date(2025, time.March, 10, 9, 30, 0, 0, location)
+	if !result.Equal(expected) || result.Location() != location {
+		t.Errorf(`Unexpected reference time, got %v instead of %v`, result, expected)
+	}
+
+	for _, value := range []string{"last monday", "2025-03-10", "2025-03-16T12:00:00Z"} {
+		if _, err := parseDateViewLookBackNow(value, serverNow); err == nil {
+			t.Errorf(`The value %q should be rejected`, value)
+		}
+	}
+}
+
+func TestDateViewNowLooksBackAtPastDates(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?look_back=2025-03-10T08:30:00Z&now=2099-01-01T00:00:00Z", nil)
+	if result, err := dateViewNow(r, "UTC"); err != nil || result.Year() == 2025 {
+		t.Errorf(`The "look_back" parameter should be ignored for anonymous requests, got %v (%v)`, result, err)
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), request.IsAuthenticatedContextKey, true))
+	result, err := dateViewNow(r, "UTC")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := time.Date(2025, time.March, 10, 8, 30, 0, 0, time.UTC); !result.Equal(expected) {
+		t.Errorf(`The "look_back" parameter should take precedence over "now", got %v instead of %v`, result, expected)
+	}
+
+	// The render time sent to mark the section as read does not replace the clock of the client
+	r = httptest.NewRequest("GET", "/entries/by-date?as_of=2025-03-10T08:30:00Z", nil)
+	r = r.WithContext(context.WithValue(r.Context(), request.IsAuthenticatedContextKey, true))
+	if result, err := dateViewNow(r, "UTC"); err != nil || result.Year() == 2025 {
+		t.Errorf(`The "as_of" parameter should not change the reference time, got %v (%v)`, result, err)
+	}
+}
+
+func TestDateViewMedia(t *testing.T) {
+	for _, media := range []string{"", "audio", "video", "none"} {
+		r := httptest.NewRequest("GET", "/entries/by-date?media="+media, nil)
+		result, err := dateViewMedia(r)
+		if err != nil {
+			t.Errorf(`Unexpected error for media %q: %v`, media, err)
+		}
+		if result != media {
+			t.Errorf(`Unexpected media, got %q instead of %q`, result, media)
+		}
+	}
+
+	r := httptest.NewRequest("GET", "/entries/by-date?media=image", nil)
+	if _, err := dateViewMedia(r); err == nil {
+		t.Error(`An unknown media filter should be rejected`)
+	}
+}
+
+func TestDateViewLanguage(t *testing.T) {
+	for value, expected := range map[string]string{"": "", "de": "de", "EN-US": "en-us", "de-AT": "de-at"} {
+		r := httptest.NewRequest("GET", "/entries/by-date?lang="+value, nil)
+		result, err := dateViewLanguage(r)
+		if err != nil {
+			t.Errorf(`Unexpected error for language %q: %v`, value, err)
+		}
+		if result != expected {
+			t.Errorf(`Unexpected language, got %q instead of %q`, result, expected)
+		}
+	}
+
+	for _, value := range []string{"d", "german", "de_DE", "de%25"} {
+		r := httptest.NewRequest("GET", "/entries/by-date?lang="+value, nil)
+		if _, err := dateViewLanguage(r); err == nil {
+			t.Errorf(`The language %q should be rejected`, value)
+		}
+	}
+}
+
+func TestDateViewDuration(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?min_duration=10&max_duration=30", nil)
+	if minDuration, maxDuration, err := dateViewDuration(r); err != nil || minDuration != 10 || maxDuration != 30 {
+		t.Errorf(`Unexpected duration range, got %d-%d (%v)`, minDuration, maxDuration, err)
+	}
+
+	if !dateViewHasEntryFilters(r) {
+		t.Error(`Expected the duration range to be an entry filter`)
+	}
+
+	if result := dateViewQuery(r, "today"); result != "max_duration=30&min_duration=10&section=today" {
+		t.Errorf(`Unexpected query string, got %q`, result)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date?min_duration=30&max_duration=10", nil)
+	if _, _, err := dateViewDuration(r); err == nil || err.Error() != `"min_duration" 30 is greater than "max_duration" 10` {
+		t.Errorf(`Expected the inverted duration range to be rejected, got %v`, err)
+	}
+}
+
+func TestDateViewReadingTime(t *testing.T) {
+	for query, expected := range map[string][2]int{
+		"":                                       {0, 0},
+		"max_reading_time=5":                     {0, 5},
+		"min_reading_time=15":                    {15, 0},
+		"min_reading_time=5&max_reading_time=15": {5, 15},
+		"min_reading_time=10&max_reading_time=10": {10, 10},
+	} {
+		r := httptest.NewRequest("GET", "/entries/by-date?"+query, nil)
+		minReadingTime, maxReadingTime, err := dateViewReadingTime(r)
+		if err != nil {
+			t.Errorf(`Unexpected error for %q: %v`, query, err)
+		}
+		if minReadingTime != expected[0] || maxReadingTime != expected[1] {
+			t.Errorf(`Unexpected reading time range for %q, got %d-%d instead of %d-%d`, query, minReadingTime, maxReadingTime, expected[0], expected[1])
+		}
+	}
+
+	for _, query := range []string{"max_reading_time=-1", "min_reading_time=short", "min_reading_time=15&max_reading_time=5"} {
+		r := httptest.NewRequest("GET", "/entries/by-date?"+query, nil)
+		if _, _, err := dateViewReadingTime(r); err == nil {
+			t.Errorf(`The reading time range %q should be rejected`, query)
+		}
+	}
+}
+
+func TestDateViewQueryKeepsViewParameters(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?section=today&buckets=simple&earlier=months&include_disabled=1&media=audio&lang=de&tag=+News&source=manual&boost=manual&category_id=7&category_id=3&exclude_category_id=9&exclude_category_id=x&label=ToRead&hide_shared=1&stable_only=1&scraped_only=1&layout=timeline&prefetch_next=1&max_reading_time=5&opened=0&now=2025-03-10T08:30:00Z", nil)
+
+	expected := "boost=manual&buckets=simple&category_id=3&category_id=7&earlier=months&exclude_category_id=9&hide_shared=1&include_disabled=1&label=toread&lang=de&layout=timeline&max_reading_time=5&media=audio&opened=false&prefetch_next=1&scraped_only=1&section=recent&source=manual&stable_only=1&tag=news"
+	if result := dateViewQuery(r, "recent"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+
+	// The starred mode is kept, but it never splits the oldest section into months
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&earlier=months&starred=1", nil)
+	expected = "section=recent&starred=1"
+	if result := dateViewQuery(r, "recent"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+
+	// So is the trash
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&earlier=months&trash=1", nil)
+	expected = "section=recent&trash=1"
+	if result := dateViewQuery(r, "recent"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+
+	// So is the updated basis, unlike unknown bases
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&earlier=months&basis=updated", nil)
+	expected = "basis=updated&section=recent"
+	if result := dateViewQuery(r, "recent"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&basis=created", nil)
+	expected = "section=recent"
+	if result := dateViewQuery(r, "recent"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+	// Looking back at a past date is kept for authenticated users
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&look_back=2025-03-10T08:30:00Z", nil)
+	if result := dateViewQuery(r, "recent"); result != "section=recent" {
+		t.Errorf(`Unexpected query string for an anonymous request, got %q`, result)
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), request.IsAuthenticatedContextKey, true))
+	expected = "look_back=2025-03-10T08%3A30%3A00Z&section=recent"
+	if result := dateViewQuery(r, "recent"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestDateViewQueryKeepsTheClientNow(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?section=today&now=2025-03-10T08:30:00Z", nil)
+	if result := dateViewQuery(r, "today"); result != "section=today" {
+		t.Errorf(`Unexpected query string for an anonymous request, got %q`, result)
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), request.IsAuthenticatedContextKey, true))
+	expected := "now=2025-03-10T08%3A30%3A00Z&section=today"
+	if result := dateViewQuery(r, "today"); result != expected {
+		t.Errorf(`Unexpected query string, got %q instead of %q`, result, expected)
+	}
+
+	// Marking the section as read places it with the same clock as the page
+	renderedAt := time.Date(2025, time.March, 10, 8, 31, 0, 0, time.UTC)
+	expected = "now=2025-03-10T08%3A30%3A00Z&section=today&as_of=2025-03-10T08%3A31%3A00Z"
+	if result := dateViewMarkAsReadQuery(r, "today", renderedAt); result != expected {
+		t.Errorf(`Unexpected mark as read query string, got %q instead of %q`, result, expected)
+	}
+
+	// So does looking back at a past date, the render time still bounding the entries to mark
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&look_back=2025-03-01T00:00:00Z", nil)
+	r = r.WithContext(context.WithValue(r.Context(), request.IsAuthenticatedContextKey, true))
+	expected = "look_back=2025-03-01T00%3A00%3A00Z&section=today&as_of=2025-03-10T08%3A31%3A00Z"
+	if result := dateViewMarkAsReadQuery(r, "today", renderedAt); result != expected {
+		t.Errorf(`Unexpected mark as read query string, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestDateViewShowNavigation(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?section=today", nil)
+	if !dateViewShowNavigation(r) {
+		t.Error(`The navigation should be shown by default`)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&nav=0", nil)
+	if dateViewShowNavigation(r) {
+		t.Error(`The navigation should be hidden with nav=0`)
+	}
+
+	if result := dateViewQuery(r, "last2d"); result != "nav=0&section=last2d" {
+		t.Errorf(`Unexpected query string, got %q`, result)
+	}
+}
+
+func TestDateViewOpened(t *testing.T) {
+	for query, expected := range map[string]string{"": "any", "opened=maybe": "any", "opened=true": "true", "opened=1": "true", "opened=false": "false"} {
+		result := "any"
+		if opened := dateViewOpened(httptest.NewRequest("GET", "/entries/by-date?"+query, nil)); opened != nil {
+			result = strconv.FormatBool(*opened)
+		}
+
+		if result != expected {
+			t.Errorf(`Unexpected opened filter for %q, got %s instead of %s`, query, result, expected)
+		}
+	}
+}
+
+func TestDateViewSeenEntryIDs(t *testing.T) {
+	shown := model.Entries{{ID: 3}, {ID: 4}}
+
+	// A page of the section adds its entries to the ones seen before
+	if seen := dateViewSeenEntryIDs([]int64{1, 3}, shown, false); !slices.Equal(seen, []int64{1, 3, 4}) {
+		t.Errorf(`Unexpected seen entries after a page, got %v`, seen)
+	}
+
+	// The whole section replaces them, entries no longer listed were read or left the section
+	if seen := dateViewSeenEntryIDs([]int64{1, 3}, shown, true); !slices.Equal(seen, []int64{3, 4}) {
+		t.Errorf(`Unexpected seen entries after the whole section, got %v`, seen)
+	}
+
+	previous := make([]int64, dateViewSeenEntriesLimit)
+	for i := range previous {
+		previous[i] = int64(i + 10)
+	}
+	seen := dateViewSeenEntryIDs(previous, shown, false)
+	if len(seen) != dateViewSeenEntriesLimit || seen[0] != 12 || seen[len(seen)-1] != 4 {
+		t.Errorf(`Expected the oldest seen entries to be dropped first, got %d entries from %d to %d`, len(seen), seen[0], seen[len(seen)-1])
+	}
+}
+
+func TestDateViewNextNonEmptySection(t *testing.T) {
+	sectionViews := []*dateSectionView{
+		{Name: "today", Count: 2},
+		{Name: "last2d", Count: 0},
+		{Name: "last7d", Count: 5},
+		{Name: "earlier", Count: 0},
+	}
+
+	if next := dateViewNextNonEmptySection(sectionViews, "today"); next == nil || next.Name != "last7d" {
+		t.Errorf(`Expected the last7d section after today, got %v`, next)
+	}
+
+	for _, section := range []string{"last7d", "earlier", "all"} {
+		if next := dateViewNextNonEmptySection(sectionViews, section); next != nil {
+			t.Errorf(`Expected no section after %q, got %q`, section, next.Name)
+		}
+	}
+}
+
+func TestDateViewIsSelectedSectionEmpty(t *testing.T) {
+	sectionViews := []*dateSectionView{
+		{Name: "today", Count: 1, Entries: model.Entries{model.NewEntry()}},
+		{Name: "last7d", Count: 0},
+	}
+
+	if !dateViewIsSelectedSectionEmpty(sectionViews, "last7d") {
+		t.Error(`Expected the last7d section to be empty`)
+	}
+
+	for _, section := range []string{"today", "all"} {
+		if dateViewIsSelectedSectionEmpty(sectionViews, section) {
+			t.Errorf(`Expected no empty state for %q`, section)
+		}
+	}
+}
+
+func TestDateViewEmptySectionMessageKey(t *testing.T) {
+	if key := dateViewEmptySectionMessageKey("last7d"); key != "page.date_entries.empty_section.last_7d" {
+		t.Errorf(`Unexpected message key for last7d: %q`, key)
+	}
+
+	if key := dateViewEmptySectionMessageKey("earlier-2024-12"); key != "page.date_entries.empty_section" {
+		t.Errorf(`Expected the generic message key for a month, got %q`, key)
+	}
+}
+
+func TestDateViewSparklineScalesToTheBusiestDay(t *testing.T) {
+	day := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	bars := dateViewSparkline([]model.DailyEntryCount{
+		{Day: day, Count: 40},
+		{Day: day.AddDate(0, 0, 1), Count: 0},
+		{Day: day.AddDate(0, 0, 2), Count: 1},
+	})
+
+	if len(bars) != 3 {
+		t.Fatalf(`Expected one bar per day, got %d`, len(bars))
+	}
+
+	if bars[0].Height != dateViewSparklineHeight || bars[0].Y != 0 {
+		t.Errorf(`Expected the busiest day to fill the sparkline, got %+v`, bars[0])
+	}
+
+	if bars[1].Height != 0 || bars[1].Y != dateViewSparklineHeight {
+		t.Errorf(`Expected an empty bar for a day without entries, got %+v`, bars[1])
+	}
+
+	if bars[2].Height != 1 || bars[2].X != 2*dateViewSparklineBarWidth {
+		t.Errorf(`Expected a visible bar for a quiet day, got %+v`, bars[2])
+	}
+
+	if bars := dateViewSparkline([]model.DailyEntryCount{{Day: day}}); bars[0].Height != 0 {
+		t.Errorf(`Expected empty bars without entries, got %+v`, bars[0])
+	}
+}
+
+func TestDateViewSectionDirection(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?section=all&sort_today=desc&sort_earlier=asc&sort_last2d=sideways", nil)
+	month := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		section  model.DateSection
+		expected string
+	}{
+		{model.DateSection{Name: "today"}, "desc"},
+		{model.DateSection{Name: "earlier"}, "asc"},
+		{model.DateSection{Name: "earlier-2024-12", Month: month}, "asc"},
+		{model.DateSection{Name: "last2d"}, "asc"},
+		{model.DateSection{Name: "last7d"}, "asc"},
+	} {
+		if result := dateViewSectionDirection(r, testCase.section, "asc"); result != testCase.expected {
+			t.Errorf(`Unexpected direction of the %q section, got %q instead of %q`, testCase.section.Name, result, testCase.expected)
+		}
+	}
+
+	// Valid overrides are kept by the links of the page
+	if result := dateViewQuery(r, "today"); result != "section=today&sort_earlier=asc&sort_today=desc" {
+		t.Errorf(`Unexpected query string, got %q`, result)
+	}
+}
+
+func TestDateViewCursorMatchesTheTiebreak(t *testing.T) {
+	entry := model.NewEntry()
+	entry.ID = 42
+	entry.Feed.Title = "Feed"
+	token := model.NewEntryCursor(entry, "published_at", true, false).WithTiebreak(entry, model.EntryTiebreakFeedTitle).Token()
+
+	r := httptest.NewRequest("GET", "/entries/by-date?section=today&tiebreak=feed_title&cursor="+token, nil)
+	if cursor, err := dateViewCursor(r); err != nil || cursor.TiebreakValue != "Feed" {
+		t.Errorf(`Expected the cursor to be accepted, got %+v (%v)`, cursor, err)
+	}
+
+	if result := dateViewQuery(r, "today"); result != "section=today&tiebreak=feed_title" {
+		t.Errorf(`Unexpected query string, got %q`, result)
+	}
+
+	// The cursor of another tiebreak is rejected rather than paging through a different order
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&tiebreak=title&cursor="+token, nil)
+	if _, err := dateViewCursor(r); err == nil {
+		t.Error(`Expected the cursor of another tiebreak to be rejected`)
+	}
+
+	// Unknown tiebreaks are ignored
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&tiebreak=author", nil)
+	if tiebreak := dateViewTiebreak(r); tiebreak != "" {
+		t.Errorf(`Expected no tiebreak, got %q`, tiebreak)
+	}
+}
+
+func TestDateViewCategoryIDs(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?category_id=7&category_id=3&category_id=abc&category_id=-1&category_id=7", nil)
+	if categoryIDs := dateViewCategoryIDs(r); !slices.Equal(categoryIDs, []int64{3, 7}) {
+		t.Errorf(`Expected the sorted valid categories, got %v`, categoryIDs)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date", nil)
+	if categoryIDs := dateViewCategoryIDs(r); categoryIDs != nil {
+		t.Errorf(`Expected no category, got %v`, categoryIDs)
+	}
+
+	// Excluded categories have their own parameter
+	r = httptest.NewRequest("GET", "/entries/by-date?category_id=3&exclude_category_id=5&exclude_category_id=2", nil)
+	if categoryIDs := dateViewExcludedCategoryIDs(r); !slices.Equal(categoryIDs, []int64{2, 5}) {
+		t.Errorf(`Expected the sorted excluded categories, got %v`, categoryIDs)
+	}
+	if !dateViewHasEntryFilters(r) {
+		t.Error(`Excluding categories should be an entry filter`)
+	}
+}
+
+func TestDateViewOrder(t *testing.T) {
+	r := httptest.NewRequest("GET", "/entries/by-date?section=today&order=title", nil)
+	if order, err := dateViewOrder(r, "published_at"); err != nil || order != "title" {
+		t.Errorf(`Expected the requested order, got %q (%v)`, order, err)
+	}
+
+	if result := dateViewQuery(r, "today"); result != "order=title&section=today" {
+		t.Errorf(`Unexpected query string, got %q`, result)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today", nil)
+	if order, err := dateViewOrder(r, "published_at"); err != nil || order != "published_at" {
+		t.Errorf(`Expected the default order, got %q (%v)`, order, err)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date?section=today&order=random()", nil)
+	if _, err := dateViewOrder(r, "published_at"); err == nil {
+		t.Error(`Expected an unknown order to be rejected`)
+	}
+}
+
+func TestDateViewCustomSection(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/Paris")
+	r := httptest.NewRequest("GET", "/entries/by-date?from=2024-01-01&to=2024-01-08", nil)
+	section, err := dateViewCustomSection(r, location)
+	if err != nil || section == nil {
+		t.Fatalf(`Expected the custom section to be accepted, got %+v (%v)`, section, err)
+	}
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, location)
+	if !section.After.Before(from) || section.After.Add(time.Microsecond) != from {
+		t.Errorf(`Expected the from day to be included, got %v`, section.After)
+	}
+
+	if to := time.Date(2024, time.January, 8, 0, 0, 0, 0, location); !section.Before.Equal(to) {
+		t.Errorf(`Expected the to day to be excluded, got %v`, section.Before)
+	}
+
+	// Every link of the page, including the mark as read action, keeps the custom range
+	if result := dateViewQuery(r, model.DateSectionCustom); result != "from=2024-01-01&section=custom&to=2024-01-08" {
+		t.Errorf(`Unexpected query string, got %q`, result)
+	}
+
+	user := &model.User{Timezone: "Europe/Paris"}
+	if marked, found := dateViewSection(r, user, from, model.DateSectionCustom); !found || !marked.Before.Equal(*section.Before) {
+		t.Errorf(`Expected the custom section to be found, got %+v`, marked)
+	}
+
+	r = httptest.NewRequest("GET", "/entries/by-date", nil)
+	if section, err := dateViewCustomSection(r, location); err != nil || section != nil {
+		t.Errorf(`Expected no custom section, got %+v (%v)`, section, err)
+	}
+
+	if _, found := dateViewSection(r, user, from, model.DateSectionCustom); found {
+		t.Error(`The custom section should not be found without a range`)
+	}
+
+	for _, query := range []string{"from=2024-01-01", "to=2024-01-08", "from=2024-01-08&to=2024-01-01", "from=2024-01-01&to=2024-01-01", "from=yesterday&to=2024-01-08"} {
+		r := httptest.NewRequest("GET", "/entries/by-date?"+query, nil)
+		if _, err := dateViewCustomSection(r, location); err == nil {
+			t.Errorf(`The custom range %q should be rejected`, query)
+		}
+	}
+}
+
+func TestDateViewMonthLabel(t *testing.T) {
+	month := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	scenarios := map[string]string{
+		"en_US": "December 2024",
+		"fr_FR": "décembre 2024",
+		"ja_JP": "2024年12月",
+	}
+	for language, expected := range scenarios {
+		if label := dateViewMonthLabel(locale.NewPrinter(language), month); label != expected {
+			t.Errorf(`Unexpected label in %s, got %q instead of %q`, language, label, expected)
+		}
+	}
+}
+
+func TestDateViewCatchUpEstimate(t *testing.T) {
+	entries := model.Entries{{ID: 1, ReadingTime: 20}, {ID: 2, ReadingTime: 40}}
+	if minutes := dateViewTotalReadingTime(entries); minutes != 60 {
+		t.Fatalf(`Unexpected reading time, got %d minutes instead of 60`, minutes)
+	}
+
+	// 70 minutes read over the week is 10 minutes a day, so an hour of reading takes 6 days
+	section := &dateSectionView{CatchUp: dateViewCatchUpEstimate(60, 70)}
+	if section.CatchUp != 6*24*time.Hour || section.CatchUpDays() != 6 {
+		t.Errorf(`Unexpected estimate, got %v (%d days)`, section.CatchUp, section.CatchUpDays())
+	}
+
+	// A partial day counts as a whole one
+	section = &dateSectionView{CatchUp: dateViewCatchUpEstimate(15, 70)}
+	if section.CatchUpDays() != 2 {
+		t.Errorf(`Expected 1.5 days to round up to 2, got %d`, section.CatchUpDays())
+	}
+
+	if estimate := dateViewCatchUpEstimate(60, 0); estimate != 0 {
+		t.Errorf(`Expected no estimate without reading pace, got %v`, estimate)
+	}
+	if estimate := dateViewCatchUpEstimate(0, 70); estimate != 0 {
+		t.Errorf(`Expected no estimate without reading time, got %v`, estimate)
+	}
+}
+
+func TestDateViewAverageAge(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	if age := dateViewAverageAge(nil, now); age != 0 {
+		t.Errorf(`Expected no average age without entries, got %v`, age)
+	}
+
+	entries := model.Entries{
+		{ID: 1, Date: now.Add(-time.Hour)},
+		{ID: 2, Date: now.Add(-5 * time.Hour)},
+		{ID: 3, Date: now.AddDate(-30, 0, 0)},
+	}
+	expected := (6*time.Hour + now.Sub(entries[2].Date)) / 3
+	if age := dateViewAverageAge(entries, now); age.Round(time.Second) != expected.Round(time.Second) {
+		t.Errorf(`Unexpected average age, got %v instead of %v`, age, expected)
+	}
+}
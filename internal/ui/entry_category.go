@@ -41,6 +41,12 @@ func (h *handler) showCategoryEntryPage(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// CUSTOM: remember the entry was opened for the opened recently section of the date view.
+	if err := h.store.SetEntryOpenedAt(user.ID, entry.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	if entry.ShouldMarkAsReadOnView(user) {
 		err = h.store.SetEntriesStatus(user.ID, []int64{entry.ID}, model.EntryStatusRead)
 		if err != nil {
@@ -41,6 +41,18 @@ func (h *handler) showFeedEntryPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// CUSTOM: remember the feed was opened today for the date view focus mode.
+	if err := h.store.SetFeedLastOpenedAt(user.ID, entry.FeedID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	// CUSTOM: remember the entry was opened for the opened recently section of the date view.
+	if err := h.store.SetEntryOpenedAt(user.ID, entry.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	if entry.ShouldMarkAsReadOnView(user) {
 		err = h.store.SetEntriesStatus(user.ID, []int64{entry.ID}, model.EntryStatusRead)
 		if err != nil {
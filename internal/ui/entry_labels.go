@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+)
+
+// CUSTOM: updateEntryLabels replaces the labels of an entry with the comma separated list of the form,
+// then goes back to the entry page it was submitted from.
+func (h *handler) updateEntryLabels(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	entry, err := builder.GetEntry()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.SetEntryLabels(userID, entryID, model.ParseEntryLabels(r.FormValue("labels"))); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, entryLabelsRedirectPath(r.Referer(), route.Path(h.router, "unreadEntry", "entryID", entryID)))
+}
+
+// entryLabelsRedirectPath returns the path and query of the referring page, so the user stays on the entry
+// page of the list they came from, or the fallback path when the referrer is missing.
+func entryLabelsRedirectPath(referer, fallback string) string {
+	u, err := url.Parse(referer)
+	if err != nil || !strings.HasPrefix(u.Path, "/") || strings.HasPrefix(u.Path, "//") {
+		return fallback
+	}
+
+	return (&url.URL{Path: u.Path, RawQuery: u.RawQuery}).String()
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import "testing"
+
+func TestEntryLabelsRedirectPathStaysOnTheSite(t *testing.T) {
+	for referer, expected := range map[string]string{
+		"https://reader.example.org/category/3/entry/42?q=1": "/category/3/entry/42?q=1",
+		"": "/unread/entry/42",
+		"https://evil.example.org//evil.example.org/x": "/unread/entry/42",
+		"not a url": "/unread/entry/42",
+	} {
+		if result := entryLabelsRedirectPath(referer, "/unread/entry/42"); result != expected {
+			t.Errorf(`Unexpected redirect path for %q, got %q instead of %q`, referer, result, expected)
+		}
+	}
+}
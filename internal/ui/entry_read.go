@@ -38,6 +38,12 @@ func (h *handler) showReadEntryPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// CUSTOM: remember the entry was opened for the opened recently section of the date view.
+	if err := h.store.SetEntryOpenedAt(user.ID, entry.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	if user.AlwaysOpenExternalLinks {
 		html.Redirect(w, r, entry.URL)
 		return
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+// CUSTOM: saveEntryReadProgress records how far the reader scrolled through the entry content.
+func (h *handler) saveEntryReadProgress(w http.ResponseWriter, r *http.Request) {
+	type entryReadProgressSaveRequest struct {
+		Progress float64 `json:"progress"`
+	}
+
+	var postData entryReadProgressSaveRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&postData); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if postData.Progress < 0 || postData.Progress > 1 {
+		json.BadRequest(w, r, fmt.Errorf("the reading progress must be between 0 and 1, got %v", postData.Progress))
+		return
+	}
+
+	if err := h.store.SetEntryReadProgress(request.UserID(r), request.RouteInt64Param(r, "entryID"), postData.Progress); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, map[string]string{"message": "saved"})
+}
@@ -33,6 +33,12 @@ func (h *handler) showFeedEntriesPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// CUSTOM: remember the feed was opened today for the date view focus mode.
+	if err := h.store.SetFeedLastOpenedAt(user.ID, feed.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	offset := request.QueryIntParam(r, "offset", 0)
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithFeedID(feed.ID)
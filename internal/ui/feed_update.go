@@ -65,6 +65,7 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
 		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
 		ProxyURL:        model.OptionalString(feedForm.ProxyURL),
+		AgeBasis:        model.OptionalString(feedForm.AgeBasis),
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feed.ID, feedModificationRequest); validationErr != nil {
@@ -79,5 +80,11 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// CUSTOM: tags are stored apart from the feed
+	if err := h.store.SetFeedTags(loggedUser.ID, feed.ID, model.ParseFeedTags(feedForm.Tags)); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feed.ID))
 }
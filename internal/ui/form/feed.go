@@ -34,6 +34,10 @@ type FeedForm struct {
 	FetchViaProxy               bool
 	Disabled                    bool
 	NoMediaPlayer               bool
+	Priority                    bool
+	Pinned                      bool
+	AgeBasis                    string
+	Tags                        string
 	HideGlobally                bool
 	CategoryHidden              bool // Category has "hide_globally"
 	AppriseServiceURLs          string
@@ -73,6 +77,9 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.FetchViaProxy = f.FetchViaProxy
 	feed.Disabled = f.Disabled
 	feed.NoMediaPlayer = f.NoMediaPlayer
+	feed.Priority = f.Priority
+	feed.Pinned = f.Pinned
+	feed.AgeBasis = f.AgeBasis
 	feed.HideGlobally = f.HideGlobally
 	feed.AppriseServiceURLs = f.AppriseServiceURLs
 	feed.WebhookURL = f.WebhookURL
@@ -103,6 +110,11 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		pushoverPriority = 0
 	}
 
+	ageBasis := r.FormValue("age_basis")
+	if ageBasis == "" {
+		ageBasis = model.FeedAgeBasisPublished
+	}
+
 	return &FeedForm{
 		FeedURL:                     r.FormValue("feed_url"),
 		SiteURL:                     r.FormValue("site_url"),
@@ -126,6 +138,10 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		FetchViaProxy:               r.FormValue("fetch_via_proxy") == "1",
 		Disabled:                    r.FormValue("disabled") == "1",
 		NoMediaPlayer:               r.FormValue("no_media_player") == "1",
+		Priority:                    r.FormValue("priority") == "1",
+		Pinned:                      r.FormValue("pinned") == "1",
+		AgeBasis:                    ageBasis,
+		Tags:                        r.FormValue("tags"),
 		HideGlobally:                r.FormValue("hide_globally") == "1",
 		AppriseServiceURLs:          r.FormValue("apprise_service_urls"),
 		WebhookURL:                  r.FormValue("webhook_url"),
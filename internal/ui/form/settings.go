@@ -54,6 +54,9 @@ type SettingsForm struct {
 	KeepFilterEntryRules      string
 	AlwaysOpenExternalLinks   bool
 	OpenExternalLinksInNewTab bool
+	DateViewUseLatestDate     bool
+	DateViewExcludeSeen       bool
+	DateViewDayResetHour      int
 }
 
 // MarkAsReadBehavior returns the MarkReadBehavior from the given MarkReadOnView and MarkReadOnMediaPlayerCompletion values.
@@ -118,6 +121,9 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.KeepFilterEntryRules = s.KeepFilterEntryRules
 	user.AlwaysOpenExternalLinks = s.AlwaysOpenExternalLinks
 	user.OpenExternalLinksInNewTab = s.OpenExternalLinksInNewTab
+	user.DateViewUseLatestDate = s.DateViewUseLatestDate
+	user.DateViewExcludeSeen = s.DateViewExcludeSeen
+	user.SetDateViewDayResetHour(s.DateViewDayResetHour)
 
 	MarkReadOnView, MarkReadOnMediaPlayerCompletion := extractMarkAsReadBehavior(s.MarkReadBehavior)
 	user.MarkReadOnView = MarkReadOnView
@@ -155,6 +161,10 @@ func (s *SettingsForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.settings_media_playback_rate_range")
 	}
 
+	if err := validator.ValidateDateViewDayResetHour(s.DateViewDayResetHour); err != nil {
+		return err
+	}
+
 	if s.ExternalFontHosts != "" {
 		if !validator.IsValidDomainList(s.ExternalFontHosts) {
 			return locale.NewLocalizedError("error.settings_invalid_domain_list")
@@ -182,6 +192,10 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 	if err != nil {
 		mediaPlaybackRate = 1
 	}
+	dateViewDayResetHour, err := strconv.Atoi(r.FormValue("date_view_day_reset_hour"))
+	if err != nil {
+		dateViewDayResetHour = -1
+	}
 	return &SettingsForm{
 		Username:                  r.FormValue("username"),
 		Password:                  r.FormValue("password"),
@@ -211,5 +225,8 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		KeepFilterEntryRules:      r.FormValue("keep_filter_entry_rules"),
 		AlwaysOpenExternalLinks:   r.FormValue("always_open_external_links") == "1",
 		OpenExternalLinksInNewTab: r.FormValue("open_external_links_in_new_tab") == "1",
+		DateViewUseLatestDate:     r.FormValue("date_view_use_latest_date") == "1",
+		DateViewExcludeSeen:       r.FormValue("date_view_exclude_seen") == "1",
+		DateViewDayResetHour:      dateViewDayResetHour,
 	}
 }
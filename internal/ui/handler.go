@@ -16,4 +16,7 @@ type handler struct {
 	store  *storage.Storage
 	tpl    *template.Engine
 	pool   *worker.Pool
+
+	// CUSTOM: dateViewLoads bounds the date view pages loaded at once by each user.
+	dateViewLoads *dateViewLoadLimiter
 }
@@ -9,6 +9,7 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
 )
@@ -21,7 +22,7 @@ func (h *handler) showLoginPage(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		html.Redirect(w, r, route.Path(h.router, user.DefaultHomePage))
+		html.Redirect(w, r, route.Path(h.router, model.HomePageRouteName(user.DefaultHomePage)))
 		return
 	}
 
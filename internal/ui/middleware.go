@@ -132,6 +132,8 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.UserLanguageContextKey, session.Data.Language)
 		ctx = context.WithValue(ctx, request.UserThemeContextKey, session.Data.Theme)
 		ctx = context.WithValue(ctx, request.LastForceRefreshContextKey, session.Data.LastForceRefresh)
+		ctx = context.WithValue(ctx, request.LastDateViewLoadedAtContextKey, session.Data.LastDateViewLoadedAt)
+		ctx = context.WithValue(ctx, request.DateViewSeenEntryIDsContextKey, session.Data.DateViewSeenEntryIDs)
 		ctx = context.WithValue(ctx, request.WebAuthnDataContextKey, session.Data.WebAuthnSessionData)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -170,6 +172,7 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 		"webManifest",
 		"robots",
 		"sharedEntry",
+		"sharedCategoryDigest",
 		"healthcheck",
 		"offline",
 		"proxy",
@@ -270,6 +273,6 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 			config.Opts.BasePath(),
 		))
 
-		html.Redirect(w, r, route.Path(m.router, user.DefaultHomePage))
+		html.Redirect(w, r, route.Path(m.router, model.HomePageRouteName(user.DefaultHomePage)))
 	})
 }
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestSharedCategoryDigestIsTheOnlyPublicDateViewRoute(t *testing.T) {
+	router := mux.NewRouter()
+	m := &middleware{router: router}
+
+	public := make(map[string]bool)
+	for name, path := range map[string]string{
+		"sharedCategoryDigest":       "/share/category/{shareCode}",
+		"dateEntries":                "/entries/by-date",
+		"markDateEntriesAsRead":      "/entries/by-date/mark-all-as-read",
+		"markDateEntriesBatchAsRead": "/entries/by-date/mark-entries-as-read",
+		"shareCategory":              "/category/{categoryID}/share",
+	} {
+		router.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			public[name] = m.isPublicRoute(r)
+		}).Name(name)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	for name, isPublic := range public {
+		if isPublic != (name == "sharedCategoryDigest") {
+			t.Errorf(`Unexpected public access to the %q route: %v`, name, isPublic)
+		}
+	}
+
+	if len(public) != 5 {
+		t.Errorf(`Expected every route to be served, got %v`, public)
+	}
+}
@@ -149,5 +149,5 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 		config.Opts.BasePath(),
 	))
 
-	html.Redirect(w, r, route.Path(h.router, user.DefaultHomePage))
+	html.Redirect(w, r, route.Path(h.router, model.HomePageRouteName(user.DefaultHomePage)))
 }
@@ -4,6 +4,8 @@
 package session // import "miniflux.app/v2/internal/ui/session"
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"miniflux.app/v2/internal/model"
@@ -25,6 +27,19 @@ func (s *Session) SetLastForceRefresh() {
 	s.store.UpdateAppSessionField(s.sessionID, "last_force_refresh", time.Now().UTC().Unix())
 }
 
+func (s *Session) SetLastDateViewLoadedAt() {
+	s.store.UpdateAppSessionField(s.sessionID, "last_date_view_loaded_at", time.Now().UTC().Unix())
+}
+
+// SetDateViewSeenEntryIDs remembers the entries the date entries page showed in the newest section.
+func (s *Session) SetDateViewSeenEntryIDs(entryIDs []int64) {
+	values := make([]string, len(entryIDs))
+	for i, entryID := range entryIDs {
+		values[i] = strconv.FormatInt(entryID, 10)
+	}
+	s.store.UpdateAppSessionField(s.sessionID, "date_view_seen_entry_ids", strings.Join(values, ","))
+}
+
 func (s *Session) SetOAuth2State(state string) {
 	s.store.UpdateAppSessionField(s.sessionID, "oauth2_state", state)
 }
@@ -49,6 +49,9 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		KeepFilterEntryRules:      user.KeepFilterEntryRules,
 		AlwaysOpenExternalLinks:   user.AlwaysOpenExternalLinks,
 		OpenExternalLinksInNewTab: user.OpenExternalLinksInNewTab,
+		DateViewUseLatestDate:     user.DateViewUseLatestDate,
+		DateViewExcludeSeen:       user.DateViewExcludeSeen,
+		DateViewDayResetHour:      user.DateViewDayResetHourOrRolling(),
 	}
 
 	creds, err := h.store.WebAuthnCredentialsByUserID(user.ID)
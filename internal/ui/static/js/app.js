@@ -1078,6 +1078,49 @@ function initializeMediaPlayerHandlers() {
     });
 }
 
+/**
+ * Save how far the entry content was scrolled through, for the in progress section of the date view.
+ *
+ * The progress is only sent when it moved forward by at least 5% of the content, or reached the end.
+ *
+ * @param {Element} contentElement The entry content element.
+ */
+function handleEntryReadProgressSave(contentElement) {
+    const contentHeight = contentElement.offsetHeight;
+    if (contentHeight <= 0) {
+        return;
+    }
+
+    const scrolledHeight = window.scrollY + window.innerHeight - contentElement.offsetTop;
+    const progress = Math.min(Math.max(scrolledHeight / contentHeight, 0), 1);
+    const lastKnownProgress = parseFloat(contentElement.dataset.readProgress) || 0;
+
+    if (progress >= lastKnownProgress + 0.05 || (progress === 1 && lastKnownProgress < 1)) {
+        contentElement.dataset.readProgress = progress.toString();
+        sendPOSTRequest(contentElement.dataset.readProgressUrl, { progress: progress });
+    }
+}
+
+/**
+ * Initialize the reading progress tracking of the entry page.
+ */
+function initializeReadProgressHandler() {
+    const contentElement = document.querySelector(".entry-content[data-read-progress-url]");
+    if (!contentElement) {
+        return;
+    }
+
+    let saveTimer = null;
+    window.addEventListener("scroll", () => {
+        if (saveTimer === null) {
+            saveTimer = setTimeout(() => {
+                saveTimer = null;
+                handleEntryReadProgressSave(contentElement);
+            }, 1000);
+        }
+    }, { passive: true });
+}
+
 /**
  * Initialize the service worker and PWA installation prompt.
  */
@@ -1272,6 +1315,7 @@ function initializeClickHandlers() {
 initializeMainMenuHandlers();
 initializeFormHandlers();
 initializeMediaPlayerHandlers();
+initializeReadProgressHandler();
 initializeWebAuthn();
 initializeKeyboardShortcuts();
 initializeTouchHandler();
@@ -98,6 +98,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 			FeedCreationRequest: model.FeedCreationRequest{
 				CategoryID:                  subscriptionForm.CategoryID,
 				FeedURL:                     subscriptions[0].URL,
+				Source:                      model.FeedSourceManual,
 				AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
 				Crawler:                     subscriptionForm.Crawler,
 				UserAgent:                   subscriptionForm.UserAgent,
@@ -128,6 +129,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		feed, localizedError := feedHandler.CreateFeed(h.store, user.ID, &model.FeedCreationRequest{
 			CategoryID:                  subscriptionForm.CategoryID,
 			FeedURL:                     subscriptions[0].URL,
+			Source:                      model.FeedSourceManual,
 			Crawler:                     subscriptionForm.Crawler,
 			AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
 			UserAgent:                   subscriptionForm.UserAgent,
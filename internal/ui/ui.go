@@ -21,7 +21,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	templateEngine := template.NewEngine(router)
 	templateEngine.ParseTemplates()
 
-	handler := &handler{router, store, templateEngine, pool}
+	handler := &handler{router, store, templateEngine, pool, newDateViewLoadLimiter(config.Opts.DateViewMaxConcurrentLoads())}
 
 	uiRouter := router.NewRoute().Subrouter()
 	uiRouter.Use(middleware.handleUserSession)
@@ -58,6 +58,24 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Date-based entries page (custom feature).
 	uiRouter.HandleFunc("/entries/by-date", handler.showDateEntriesPage).Name("dateEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entries/by-date/mark-all-as-read", handler.markDateEntriesAsRead).Name("markDateEntriesAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/mark-sample-as-read", handler.markDateSectionSampleAsRead).Name("markDateSectionSampleAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/mark-keeping-newest-as-read", handler.markDateEntriesKeepingNewestAsRead).Name("markDateEntriesKeepingNewestAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/mark-category-as-read", handler.markDateCategoryAsRead).Name("markDateCategoryAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/mark-entries-as-read", handler.markDateEntriesBatchAsRead).Name("markDateEntriesBatchAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/mark-rarely-read-as-read", handler.markDateSectionRarelyReadAsRead).Name("markDateSectionRarelyReadAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/mark-read-and-next", handler.markDateEntryAsReadAndShowNext).Name("markDateEntryAsReadAndShowNext").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/restore", handler.restoreDateEntries).Name("restoreDateEntries").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/save-section", handler.saveDateSectionEntries).Name("saveDateSectionEntries").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/fetch-content", handler.fetchDateSectionContent).Name("fetchDateSectionContent").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/collapse-section", handler.collapseDateSection).Name("collapseDateSection").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/sections", handler.updateDateSectionDefinitions).Name("updateDateSectionDefinitions").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/blocked-domains", handler.showDateViewBlockedDomains).Name("dateViewBlockedDomains").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entries/by-date/blocked-domains", handler.blockDateViewDomain).Name("blockDateViewDomain").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entries/by-date/blocked-domains", handler.unblockDateViewDomain).Name("unblockDateViewDomain").Methods(http.MethodDelete)
+	uiRouter.HandleFunc("/entries/by-date/digest", handler.showDateDigestPage).Name("dateDigest").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entries/by-date/random", handler.showRandomDateEntry).Name("randomDateEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entries/by-date/noisy-feeds", handler.showDateSectionNoisyFeeds).Name("dateSectionNoisyFeeds").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entries/by-date/noisy-feeds", handler.unsubscribeDateSectionNoisyFeeds).Name("unsubscribeDateSectionNoisyFeeds").Methods(http.MethodPost)
 
 	// Search pages.
 	uiRouter.HandleFunc("/search", handler.showSearchPage).Name("search").Methods(http.MethodGet)
@@ -95,6 +113,9 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/category/{categoryID}/entries/starred", handler.showCategoryEntriesStarredPage).Name("categoryEntriesStarred").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/category/{categoryID}/edit", handler.showEditCategoryPage).Name("editCategory").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/category/{categoryID}/update", handler.updateCategory).Name("updateCategory").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/category/{categoryID}/share", handler.createSharedCategory).Name("shareCategory").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/category/{categoryID}/unshare", handler.unshareCategory).Name("unshareCategory").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/share/category/{shareCode}", handler.sharedCategoryDigest).Name("sharedCategoryDigest").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/category/{categoryID}/remove", handler.removeCategory).Name("removeCategory").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/category/{categoryID}/mark-all-as-read", handler.markCategoryAsRead).Name("markCategoryAsRead").Methods(http.MethodPost)
 
@@ -106,11 +127,13 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/entry/status", handler.updateEntriesStatus).Name("updateEntriesStatus").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/save/{entryID}", handler.saveEntry).Name("saveEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/enclosure/{enclosureID}/save-progression", handler.saveEnclosureProgression).Name("saveEnclosureProgression").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/read-progress/{entryID}", handler.saveEntryReadProgress).Name("saveEntryReadProgress").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/download/{entryID}", handler.fetchContent).Name("fetchContent").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", handler.mediaProxy).Name("proxy").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/star/{entryID}", handler.toggleStarred).Name("toggleStarred").Methods(http.MethodPost)
 
 	// Share pages.
+	uiRouter.HandleFunc("/entry/labels/{entryID}", handler.updateEntryLabels).Name("updateEntryLabels").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/share/{entryID}", handler.createSharedEntry).Name("shareEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/unshare/{entryID}", handler.unshareEntry).Name("unshareEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/share/{shareCode}", handler.sharedEntry).Name("sharedEntry").Methods(http.MethodGet)
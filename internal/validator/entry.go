@@ -6,6 +6,7 @@ package validator // import "miniflux.app/v2/internal/validator"
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -19,6 +20,19 @@ func ValidateEntriesStatusUpdateRequest(request *model.EntriesStatusUpdateReques
 	return ValidateEntryStatus(request.Status)
 }
 
+// CUSTOM: ValidateEntriesMarkReadUpToCursorRequest makes sure the cursor points to an entry and its publication date.
+func ValidateEntriesMarkReadUpToCursorRequest(request *model.EntriesMarkReadUpToCursorRequest) error {
+	if request.EntryID <= 0 {
+		return errors.New(`the cursor entry ID must be greater than 0`)
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, request.SortValue); err != nil {
+		return fmt.Errorf(`the cursor sort value must be an RFC3339 publication date: %v`, err)
+	}
+
+	return nil
+}
+
 // ValidateEntryStatus makes sure the entry status is valid.
 func ValidateEntryStatus(status string) error {
 	switch status {
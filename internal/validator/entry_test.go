@@ -57,3 +57,17 @@ func TestValidateEntryOrder(t *testing.T) {
 		t.Error(`An invalid order should generate a error`)
 	}
 }
+
+func TestValidateEntriesMarkReadUpToCursorRequest(t *testing.T) {
+	if err := ValidateEntriesMarkReadUpToCursorRequest(&model.EntriesMarkReadUpToCursorRequest{SortValue: "2025-03-10T08:30:00Z", EntryID: 42}); err != nil {
+		t.Errorf(`A valid cursor should not generate any error, got %v`, err)
+	}
+
+	if err := ValidateEntriesMarkReadUpToCursorRequest(&model.EntriesMarkReadUpToCursorRequest{SortValue: "2025-03-10T08:30:00Z"}); err == nil {
+		t.Error(`A cursor without entry should generate an error`)
+	}
+
+	if err := ValidateEntriesMarkReadUpToCursorRequest(&model.EntriesMarkReadUpToCursorRequest{SortValue: "yesterday", EntryID: 42}); err == nil {
+		t.Error(`A cursor without publication date should generate an error`)
+	}
+}
@@ -102,5 +102,12 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	// CUSTOM: the age basis picks the date placing the entries in the date view sections
+	if request.AgeBasis != nil {
+		if !model.IsValidFeedAgeBasis(*request.AgeBasis) {
+			return locale.NewLocalizedError("error.feed_invalid_age_basis")
+		}
+	}
+
 	return nil
 }
@@ -87,6 +87,12 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.DateViewDayResetHour != nil {
+		if err := ValidateDateViewDayResetHour(*changes.DateViewDayResetHour); err != nil {
+			return err
+		}
+	}
+
 	if changes.CategoriesSortingOrder != nil {
 		if err := validateCategoriesSortingOrder(*changes.CategoriesSortingOrder); err != nil {
 			return err
@@ -222,6 +228,65 @@ func validateEntriesPerPage(entriesPerPage int) *locale.LocalizedError {
 	return nil
 }
 
+// CUSTOM: ValidateDateViewDayResetHour accepts an hour of the day, or -1 for the rolling 24 hours of the date view.
+func ValidateDateViewDayResetHour(hour int) *locale.LocalizedError {
+	if hour < -1 || hour > 23 {
+		return locale.NewLocalizedError("error.settings_day_reset_hour_range")
+	}
+	return nil
+}
+
+// CUSTOM: ValidateDateViewSettings validates the whole bundle of date view preferences before any is changed.
+func ValidateDateViewSettings(request *model.DateViewSettingsRequest) *locale.LocalizedError {
+	if request.UseLatestDate == nil || request.ExcludeSeen == nil || request.DayResetHour == nil || request.CollapsedSections == nil {
+		return locale.NewLocalizedError("error.date_view_settings_incomplete")
+	}
+
+	if err := ValidateDateViewDayResetHour(*request.DayResetHour); err != nil {
+		return err
+	}
+
+	for _, section := range *request.CollapsedSections {
+		if section == "" {
+			return locale.NewLocalizedError("error.date_view_settings_empty_section")
+		}
+	}
+
+	return nil
+}
+
+// CUSTOM: dateSectionDefinitionsMaxCount bounds the number of date sections a user can define.
+const dateSectionDefinitionsMaxCount = 12
+
+// CUSTOM: ValidateDateSectionDefinitions validates the date sections defined by the user, in their display order:
+// labels are unique and the number of days strictly increases, so the sections never overlap.
+func ValidateDateSectionDefinitions(definitions model.DateSectionDefinitions) *locale.LocalizedError {
+	if len(definitions) > dateSectionDefinitionsMaxCount {
+		return locale.NewLocalizedError("error.date_section_definitions_too_many", dateSectionDefinitionsMaxCount)
+	}
+
+	labels := make(map[string]bool, len(definitions))
+	previousDays := 0
+	for _, definition := range definitions {
+		label := strings.ToLower(strings.TrimSpace(definition.Label))
+		if label == "" {
+			return locale.NewLocalizedError("error.date_section_definition_empty_label")
+		}
+
+		if labels[label] {
+			return locale.NewLocalizedError("error.date_section_definition_duplicate_label", definition.Label)
+		}
+		labels[label] = true
+
+		if definition.Days <= previousDays {
+			return locale.NewLocalizedError("error.date_section_definition_days_order", definition.Label)
+		}
+		previousDays = definition.Days
+	}
+
+	return nil
+}
+
 func validateCategoriesSortingOrder(order string) *locale.LocalizedError {
 	if order != "alphabetical" && order != "unread_count" {
 		return locale.NewLocalizedError("error.invalid_categories_sorting_order")
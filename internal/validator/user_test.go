@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateDateViewSettings(t *testing.T) {
+	newRequest := func(dayResetHour int, collapsedSections []string) *model.DateViewSettingsRequest {
+		return &model.DateViewSettingsRequest{
+			UseLatestDate:     model.SetOptionalField(true),
+			ExcludeSeen:       model.SetOptionalField(false),
+			DayResetHour:      &dayResetHour,
+			CollapsedSections: &collapsedSections,
+		}
+	}
+
+	if err := ValidateDateViewSettings(newRequest(-1, []string{"earlier"})); err != nil {
+		t.Errorf(`Unexpected error for a valid bundle: %v`, err)
+	}
+
+	if err := ValidateDateViewSettings(newRequest(24, nil)); err == nil {
+		t.Error(`Expected an error for an out of range day reset hour`)
+	}
+
+	if err := ValidateDateViewSettings(newRequest(4, []string{""})); err == nil {
+		t.Error(`Expected an error for an empty section name`)
+	}
+
+	incomplete := newRequest(4, nil)
+	incomplete.ExcludeSeen = nil
+	if err := ValidateDateViewSettings(incomplete); err == nil {
+		t.Error(`Expected an error for an incomplete bundle`)
+	}
+}
+
+func TestValidateDateSectionDefinitions(t *testing.T) {
+	valid := model.DateSectionDefinitions{{Label: "Today", Days: 1}, {Label: "This week", Days: 7}, {Label: "This month", Days: 30}}
+	if err := ValidateDateSectionDefinitions(valid); err != nil {
+		t.Errorf(`Unexpected error for valid definitions: %v`, err)
+	}
+
+	if err := ValidateDateSectionDefinitions(nil); err != nil {
+		t.Errorf(`Unexpected error without definitions: %v`, err)
+	}
+
+	if err := ValidateDateSectionDefinitions(model.DateSectionDefinitions{{Label: "Today", Days: 1}, {Label: " today ", Days: 2}}); err == nil {
+		t.Error(`Expected an error for duplicate labels`)
+	}
+
+	if err := ValidateDateSectionDefinitions(model.DateSectionDefinitions{{Label: " ", Days: 1}}); err == nil {
+		t.Error(`Expected an error for an empty label`)
+	}
+
+	if err := ValidateDateSectionDefinitions(model.DateSectionDefinitions{{Label: "Week", Days: 7}, {Label: "Today", Days: 1}}); err == nil {
+		t.Error(`Expected an error for decreasing days`)
+	}
+
+	if err := ValidateDateSectionDefinitions(model.DateSectionDefinitions{{Label: "Today", Days: 1}, {Label: "Tomorrow", Days: 1}}); err == nil {
+		t.Error(`Expected an error for equal days`)
+	}
+
+	if err := ValidateDateSectionDefinitions(model.DateSectionDefinitions{{Label: "Never", Days: 0}}); err == nil {
+		t.Error(`Expected an error for a section without any day`)
+	}
+}